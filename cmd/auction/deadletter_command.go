@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/wallet"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"os"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const deadLetterUsage = "usage: auction deadletter list | replay <bidId> | discard <bidId>"
+
+// runDeadLetterCommand executa o subcomando de administração da dead letter de lances.
+func runDeadLetterCommand(ctx context.Context, database *mongo.Database, args []string) error {
+	// Só os repositórios da reposição; os workers de lances rodam no servidor
+	deadLetterUseCase := bid_usecase.NewDeadLetterUseCase(
+		bid.NewBidRepository(database, auction.NewAuctionRepository(database)),
+		bid.NewDeadLetterRepository(database),
+		wallet.NewWalletRepository(database))
+
+	if len(args) == 0 {
+		return errors.New(deadLetterUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		deadLetters, err := deadLetterUseCase.FindDeadLetters(ctx)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(deadLetters)
+
+	case "replay", "discard":
+		if len(args) != 2 {
+			return errors.New(deadLetterUsage)
+		}
+
+		if args[0] == "replay" {
+			if err := deadLetterUseCase.ReplayDeadLetter(ctx, args[1]); err != nil {
+				return err
+			}
+		} else if err := deadLetterUseCase.DiscardDeadLetter(ctx, args[1]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Dead letter bid %s %sed\n", args[1], args[0])
+		return nil
+
+	default:
+		return errors.New(deadLetterUsage)
+	}
+}
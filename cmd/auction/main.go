@@ -2,18 +2,44 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fullcycle-auction_go/configuration/database/mongodb"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/api/web/controller/admin_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/category_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/rating_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/restriction_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/wallet_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/watchlist_controller"
+	"fullcycle-auction_go/internal/infra/auth"
+	"fullcycle-auction_go/internal/infra/database/admin"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/database/rating"
+	"fullcycle-auction_go/internal/infra/database/restriction"
 	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/database/wallet"
+	"fullcycle-auction_go/internal/infra/database/watchlist"
+	"fullcycle-auction_go/internal/infra/stream"
+	"fullcycle-auction_go/internal/usecase/admin_usecase"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"fullcycle-auction_go/internal/usecase/rating_usecase"
+	"fullcycle-auction_go/internal/usecase/restriction_usecase"
 	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"fullcycle-auction_go/internal/usecase/wallet_usecase"
+	"fullcycle-auction_go/internal/usecase/watchlist_usecase"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -29,64 +55,200 @@ func main() {
 		return
 	}
 
+	// Sem segredo qualquer um assinaria tokens válidos; não há valor padrão
+	jwtSecret := auth.GetJWTSecret()
+	if jwtSecret == "" {
+		log.Fatal("JWT_SECRET must be set")
+		return
+	}
+
 	databaseConnection, err := mongodb.NewMongoDBConnection(ctx)
 	if err != nil {
 		log.Fatal(err.Error())
 		return
 	}
 
+	tokenService := auth.NewTokenService(jwtSecret, auth.GetJWTTTL())
+
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		if err := runTokenCommand(ctx, databaseConnection, tokenService, os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "deadletter" {
+		if err := runDeadLetterCommand(ctx, databaseConnection, os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(ctx, databaseConnection, os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "roles" {
+		if err := runRolesCommand(ctx, databaseConnection, os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	router := gin.Default()
 
-	userController, bidController, auctionsController := initDependencies(databaseConnection)
+	deps := initDependencies(databaseConnection)
+
+	if err := user.NewUserRepository(databaseConnection).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create user indexes", err)
+		return
+	}
+
+	if err := auction.NewAuctionRepository(databaseConnection).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create auction indexes", err)
+		return
+	}
+
+	if err := bid.NewBidRepository(databaseConnection, nil).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create bid indexes", err)
+		return
+	}
+
+	if err := wallet.NewWalletRepository(databaseConnection).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create wallet indexes", err)
+		return
+	}
+
+	if err := rating.NewRatingRepository(databaseConnection).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create rating indexes", err)
+		return
+	}
+
+	if err := restriction.NewRestrictionRepository(databaseConnection).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create restriction indexes", err)
+		return
+	}
+
+	if err := category.NewCategoryRepository(databaseConnection).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create category indexes", err)
+		return
+	}
 
-	// Start the background routine for closing expired auctions
+	if err := watchlist.NewWatchlistRepository(databaseConnection, nil).CreateIndexes(ctx); err != nil {
+		log.Fatal("Error trying to create watchlist indexes", err)
+		return
+	}
 
-	router.GET("/auction", auctionsController.FindAuctions)
-	router.GET("/auction/:auctionId", auctionsController.FindAuctionById)
-	router.POST("/auction", auctionsController.CreateAuction)
-	router.GET("/auction/winner/:auctionId", auctionsController.FindWinningBidByAuctionId)
-	router.POST("/bid", bidController.CreateBid)
-	router.GET("/bid/:auctionId", bidController.FindBidByAuctionId)
-	router.GET("/user/:userId", userController.FindUserById)
-	router.GET("/auctions/expired", auctionsController.FindExpiredAuctions)
-	router.GET("/auctions/closeexpiredauctions", auctionsController.CloseExpiredAuctions)
+	registerRoutes(router, deps, tokenService)
 
 	// Executa a goroutine para fechar leilões expirados a cada intervalo
-	go autoCloseExpiredAuctions()
+	go autoCloseExpiredAuctions(ctx, deps.auctionUseCase)
+
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err.Error())
+		}
+	}()
+
+	// No encerramento, a API para de aceitar lances antes que os workers
+	// gravem os que já estão na fila
+	stop, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	<-stop.Done()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Erro ao encerrar o servidor: %v\n", err)
+	}
 
-	router.Run(":8080")
+	deps.bidUseCase.Close()
 }
 
-func autoCloseExpiredAuctions() {
+func autoCloseExpiredAuctions(ctx context.Context, auctionUseCase auction_usecase.AuctionUseCaseInterface) {
 	for {
-		// Chama a rota para fechar leilões expirados
-		resp, err := http.Get("http://app:8080/auctions/closeexpiredauctions")
-		if err != nil {
-			log.Printf("Erro ao chamar a API para fechar leilões expirados: %v\n", err)
-		} else {
-			log.Println("Fechamento de leilões expirados executado com sucesso!")
-			resp.Body.Close()
+		if closed, err := auctionUseCase.CloseExpiredAuctions(ctx); err != nil {
+			log.Printf("Erro ao fechar leilões expirados: %v\n", err)
+		} else if closed > 0 {
+			log.Printf("%d leilões expirados fechados\n", closed)
 		}
 
-		// A cada 10 minutos, repete a verificação
-		time.Sleep(10 * time.Minute)
+		// A cada minuto, repete a verificação
+		time.Sleep(time.Minute)
 	}
 }
 
-func initDependencies(database *mongo.Database) (
-	userController *user_controller.UserController,
-	bidController *bid_controller.BidController,
-	auctionController *auction_controller.AuctionController) {
+type dependencies struct {
+	userController        *user_controller.UserController
+	bidController         *bid_controller.BidController
+	auctionController     *auction_controller.AuctionController
+	adminController       *admin_controller.AdminController
+	walletController      *wallet_controller.WalletController
+	ratingController      *rating_controller.RatingController
+	restrictionController *restriction_controller.RestrictionController
+	categoryController    *category_controller.CategoryController
+	watchlistController   *watchlist_controller.WatchlistController
+	auctionUseCase        auction_usecase.AuctionUseCaseInterface
+	bidUseCase            bid_usecase.BidUseCaseInterface
+	userRepository        user_entity.UserRepositoryInterface
+	restrictionRepository restriction_entity.RestrictionRepositoryInterface
+}
 
-	auctionRepository := auction.NewAuctionRepository(database)
+func initDependencies(database *mongo.Database) (deps dependencies) {
+	auctionRepository := auction.NewAuctionCache(
+		auction.NewAuctionRepository(database),
+		auction.GetAuctionCacheMaxEntries(),
+		auction.GetAuctionCacheTTL())
 	bidRepository := bid.NewBidRepository(database, auctionRepository)
-	userRepository := user.NewUserRepository(database)
-
-	userController = user_controller.NewUserController(
-		user_usecase.NewUserUseCase(userRepository))
-	auctionController = auction_controller.NewAuctionController(
-		auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository))
-	bidController = bid_controller.NewBidController(bid_usecase.NewBidUseCase(bidRepository, auctionRepository))
+	deadLetterRepository := bid.NewDeadLetterRepository(database)
+	userRepository := user.NewUserCache(
+		user.NewUserRepository(database),
+		user.GetUserCacheMaxEntries(),
+		user.GetUserCacheTTL())
+	adminActionRepository := admin.NewAdminActionRepository(database)
+	walletRepository := wallet.NewWalletRepository(database)
+	ratingRepository := rating.NewRatingRepository(database)
+	restrictionRepository := restriction.NewBanCache(
+		restriction.NewRestrictionRepository(database),
+		restriction.GetBanCacheMaxEntries(),
+		restriction.GetBanCacheTTL())
+	categoryRepository := category.NewCategoryRepository(database)
+	watchlistRepository := watchlist.NewWatchlistRepository(database, auctionRepository)
+	auctionBroker := stream.NewAuctionBroker(
+		stream.GetAuctionStreamHistory(),
+		stream.GetAuctionStreamBuffer())
+
+	deps.userRepository = userRepository
+	deps.restrictionRepository = restrictionRepository
+	deps.auctionUseCase = auction_usecase.NewAuctionUseCase(
+		auctionRepository, bidRepository, adminActionRepository, walletRepository, categoryRepository,
+		auctionBroker)
+
+	deps.userController = user_controller.NewUserController(
+		user_usecase.NewUserUseCase(userRepository, ratingRepository))
+	deps.auctionController = auction_controller.NewAuctionController(deps.auctionUseCase,
+		stream.GetAuctionStreamHeartbeat(), stream.GetAuctionStreamOrigins())
+	deps.bidUseCase = bid_usecase.NewBidUseCase(
+		bidRepository, auctionRepository, userRepository, deadLetterRepository,
+		walletRepository, restrictionRepository, auctionBroker)
+	deps.bidController = bid_controller.NewBidController(deps.bidUseCase)
+	deps.adminController = admin_controller.NewAdminController(admin_usecase.NewAdminUseCase(
+		auctionRepository, bidRepository, userRepository, adminActionRepository,
+		walletRepository, restrictionRepository, deps.auctionUseCase))
+	deps.walletController = wallet_controller.NewWalletController(
+		wallet_usecase.NewWalletUseCase(walletRepository))
+	deps.ratingController = rating_controller.NewRatingController(
+		rating_usecase.NewRatingUseCase(ratingRepository, auctionRepository, bidRepository))
+	deps.restrictionController = restriction_controller.NewRestrictionController(
+		restriction_usecase.NewRestrictionUseCase(restrictionRepository, userRepository))
+	deps.categoryController = category_controller.NewCategoryController(
+		category_usecase.NewCategoryUseCase(categoryRepository, adminActionRepository))
+	deps.watchlistController = watchlist_controller.NewWatchlistController(
+		watchlist_usecase.NewWatchlistUseCase(watchlistRepository, deps.auctionUseCase))
 
 	return
 }
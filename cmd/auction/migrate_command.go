@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const migrateUsage = "usage: auction migrate"
+
+// runMigrateCommand preenche os campos derivados de dados gravados antes de
+// eles existirem. As migrações podem ser repetidas sem efeito e rodam fora da
+// subida da API, para que a subida só crie índices.
+func runMigrateCommand(ctx context.Context, database *mongo.Database, args []string) error {
+	if len(args) > 0 {
+		return errors.New(migrateUsage)
+	}
+
+	auctionRepository := auction.NewAuctionRepository(database)
+
+	backfilled, err := bid.NewBidRepository(database, auctionRepository).BackfillBidState(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("auction bid state: %d auctions backfilled\n", backfilled)
+
+	backfilled, err = auctionRepository.BackfillEndsAt(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("auction end time: %d auctions backfilled\n", backfilled)
+
+	backfilled, err = category.NewCategoryRepository(database).BackfillCounts(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("category counts: %d categories backfilled\n", backfilled)
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/database/admin"
+	"fullcycle-auction_go/internal/infra/database/user"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const rolesUsage = "usage: auction roles <userId> <bidder|seller|admin>..."
+
+// cliAdminId identifica, na auditoria, alterações feitas pela linha de comando.
+const cliAdminId = "cli"
+
+// runRolesCommand define os papéis de um usuário. É a forma de criar o
+// primeiro administrador, que depois gerencia os demais pela API.
+func runRolesCommand(ctx context.Context, database *mongo.Database, args []string) error {
+	if len(args) < 2 {
+		return errors.New(rolesUsage)
+	}
+
+	userRepository := user.NewUserRepository(database)
+
+	userEntity, err := userRepository.FindUserById(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	userEntity.Roles = nil
+	for _, role := range args[1:] {
+		userEntity.Roles = append(userEntity.Roles, user_entity.Role(role))
+	}
+
+	if err := userEntity.Validate(); err != nil {
+		return err
+	}
+
+	if err := userRepository.UpdateUser(ctx, userEntity); err != nil {
+		return err
+	}
+
+	if err := admin.NewAdminActionRepository(database).SaveAdminAction(ctx, admin_entity.NewAdminAction(
+		cliAdminId, admin_entity.UpdateUserRoles, admin_entity.UserTarget, userEntity.Id, "")); err != nil {
+		return err
+	}
+
+	fmt.Printf("user %s roles: %v\n", userEntity.Id, userEntity.Roles)
+	return nil
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/api/web/controller/admin_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/rating_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/wallet_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/watchlist_controller"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/openapi"
+	"fullcycle-auction_go/internal/infra/api/web/route"
+	"fullcycle-auction_go/internal/infra/auth"
+	"fullcycle-auction_go/internal/usecase/admin_usecase"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"fullcycle-auction_go/internal/usecase/rating_usecase"
+	"fullcycle-auction_go/internal/usecase/restriction_usecase"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"fullcycle-auction_go/internal/usecase/wallet_usecase"
+	"fullcycle-auction_go/internal/usecase/watchlist_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerRoutes registra a API em /v1, os caminhos antigos como aliases
+// depreciados e a especificação OpenAPI gerada a partir da mesma tabela.
+func registerRoutes(router gin.IRouter, deps dependencies, tokenService *auth.TokenService) {
+	routes := apiRoutes(deps)
+
+	route.Register(router, routes, func(r route.Route) []gin.HandlerFunc {
+		if !r.Authenticated {
+			return nil
+		}
+
+		// Rotas autenticadas exigem um bearer token válido de um usuário não banido
+		guards := []gin.HandlerFunc{
+			middleware.Authenticate(tokenService),
+			middleware.RejectBanned(deps.restrictionRepository),
+		}
+		if len(r.Roles) > 0 {
+			guards = append(guards, middleware.RequireRole(deps.userRepository, r.Roles...))
+		}
+		return guards
+	})
+
+	document := openapi.Generate(openapi.Info{
+		Title:   "Auction API",
+		Version: "1.0.0",
+	}, routes)
+	router.GET(route.Version+"/openapi.json", openapi.Handler(document))
+}
+
+func apiRoutes(deps dependencies) []route.Route {
+	auctions, bids, users := deps.auctionController, deps.bidController, deps.userController
+	admin, categories := deps.adminController, deps.categoryController
+	watchlists := deps.watchlistController
+
+	bidder := []user_entity.Role{user_entity.RoleBidder}
+	seller := []user_entity.Role{user_entity.RoleSeller}
+	adminOnly := []user_entity.Role{user_entity.RoleAdmin}
+	// O vendedor altera o próprio leilão; administradores alteram qualquer um
+	auctionManager := []user_entity.Role{user_entity.RoleSeller, user_entity.RoleAdmin}
+
+	return []route.Route{
+		// Leilões
+		{
+			Method: http.MethodGet, Path: "/auctions", Legacy: []string{"/auction"},
+			Handler: auctions.FindAuctions, OperationId: "listAuctions", Tag: "auctions",
+			Summary: "Lista leilões com filtros, ordenação e paginação por cursor",
+			Query:   auction_usecase.AuctionListInputDTO{}, Response: auction_usecase.AuctionListOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/auctions", Legacy: []string{"/auction"},
+			Handler: auctions.CreateAuction, OperationId: "createAuction", Tag: "auctions",
+			Summary:       "Cria um leilão",
+			Authenticated: true, Roles: seller,
+			Body: auction_usecase.AuctionInputDTO{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/search", Legacy: []string{"/auction/search"},
+			Handler: auctions.SearchAuctions, OperationId: "searchAuctions", Tag: "auctions",
+			Summary: "Busca de texto nos leilões, ordenada por relevância",
+			Query:   auction_usecase.AuctionSearchInputDTO{}, Response: auction_usecase.AuctionSearchOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/expired", Legacy: []string{"/auctions/expired"},
+			Handler: auctions.FindExpiredAuctions, OperationId: "listExpiredAuctions", Tag: "auctions",
+			Summary:  "Lista os leilões ativos cujo prazo já terminou",
+			Response: []auction_usecase.AuctionOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/cache/stats", Legacy: []string{"/auction/cache/stats"},
+			Handler: auctions.FindCacheStats, OperationId: "getAuctionCacheStats", Tag: "auctions",
+			Summary:  "Estatísticas do cache de leilões",
+			Response: auction_usecase.AuctionCacheStatsOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/:auctionId", Legacy: []string{"/auction/:auctionId"},
+			Handler: auctions.FindAuctionById, OperationId: "getAuction", Tag: "auctions",
+			Summary:  "Busca um leilão com o estado ao vivo",
+			Response: auction_usecase.AuctionOutputDTO{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/auctions/:auctionId", Legacy: []string{"/auction/:auctionId"},
+			Handler: auctions.UpdateAuction, OperationId: "updateAuction", Tag: "auctions",
+			Summary:       "Edita um leilão ativo",
+			Authenticated: true, Roles: auctionManager,
+			Body: auction_usecase.AuctionUpdateInputDTO{}, Response: auction_usecase.AuctionOutputDTO{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/auctions/:auctionId", Legacy: []string{"/auction/:auctionId"},
+			Handler: auctions.CancelAuction, OperationId: "cancelAuction", Tag: "auctions",
+			Summary:       "Cancela um leilão ativo",
+			Authenticated: true, Roles: auctionManager,
+			Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/:auctionId/winner", Legacy: []string{"/auction/winner/:auctionId"},
+			Handler: auctions.FindWinningBidByAuctionId, OperationId: "getAuctionWinner", Tag: "auctions",
+			Summary:  "Busca o leilão e o lance vencedor",
+			Response: auction_usecase.WinningInfoOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/:auctionId/stream", Legacy: []string{"/auction/:auctionId/stream"},
+			Handler: auctions.StreamAuctionEvents, OperationId: "streamAuctionEvents", Tag: "auctions",
+			Summary:  "Acompanha os eventos do leilão por SSE ou WebSocket, retomando do Last-Event-ID",
+			Response: auction_usecase.AuctionEventOutputDTO{}, ContentType: "text/event-stream",
+		},
+		{
+			Method: http.MethodGet, Path: "/auctions/:auctionId/bids", Legacy: []string{"/bid/:auctionId"},
+			Handler: bids.FindBidByAuctionId, OperationId: "listAuctionBids", Tag: "bids",
+			Summary: "Lista os lances de um leilão com o resumo dos lances válidos",
+			Query:   bid_controller.AuctionBidListQuery{}, Response: bid_usecase.BidListOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/auctions/:auctionId/ratings", Legacy: []string{"/auction/:auctionId/rating"},
+			Handler: deps.ratingController.RateAuction, OperationId: "rateAuction", Tag: "ratings",
+			Summary:       "Avalia a outra parte de um leilão encerrado",
+			Authenticated: true,
+			Body:          rating_usecase.RatingInputDTO{}, Response: rating_usecase.RatingOutputDTO{},
+			Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodPut, Path: "/auctions/:auctionId/watch",
+			Handler: watchlists.WatchAuction, OperationId: "watchAuction", Tag: "watchlist",
+			Summary:       "Acompanha um leilão ativo sem dar lances",
+			Authenticated: true,
+			Status:        http.StatusNoContent,
+		},
+		{
+			Method: http.MethodDelete, Path: "/auctions/:auctionId/watch",
+			Handler: watchlists.UnwatchAuction, OperationId: "unwatchAuction", Tag: "watchlist",
+			Summary:       "Deixa de acompanhar um leilão",
+			Authenticated: true,
+			Status:        http.StatusNoContent,
+		},
+
+		// Lances
+		{
+			Method: http.MethodPost, Path: "/bids", Legacy: []string{"/bid"},
+			Handler: bids.CreateBid, OperationId: "createBid", Tag: "bids",
+			Summary:       "Envia um lance para processamento",
+			Authenticated: true, Roles: bidder,
+			Body: bid_usecase.BidInputDTO{}, Response: bid_usecase.BidStatusOutputDTO{},
+			Status: http.StatusAccepted,
+		},
+		{
+			Method: http.MethodGet, Path: "/bids/queue", Legacy: []string{"/bid/queue"},
+			Handler: bids.FindQueueStats, OperationId: "getBidQueueStats", Tag: "bids",
+			Summary:  "Estatísticas da fila de lances",
+			Response: bid_usecase.QueueStatsOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/bids/:bidId/status", Legacy: []string{"/bid/status/:bidId"},
+			Handler: bids.FindBidStatus, OperationId: "getBidStatus", Tag: "bids",
+			Summary:  "Situação do processamento de um lance",
+			Response: bid_usecase.BidStatusOutputDTO{},
+		},
+
+		// Usuários
+		{
+			Method: http.MethodGet, Path: "/users", Legacy: []string{"/user"},
+			Handler: users.FindUsers, OperationId: "listUsers", Tag: "users",
+			Summary:       "Lista os usuários",
+			Authenticated: true, Roles: adminOnly,
+			Query: user_controller.UserListQuery{}, Response: user_usecase.UserListOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/users", Legacy: []string{"/user"},
+			Handler: users.CreateUser, OperationId: "createUser", Tag: "users",
+			Summary: "Cadastra um usuário",
+			Body:    user_usecase.UserInputDTO{}, Response: user_usecase.UserOutputDTO{},
+			Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:userId", Legacy: []string{"/user/:userId"},
+			Handler: users.FindUserById, OperationId: "getUser", Tag: "users",
+			Summary:  "Busca um usuário com a reputação",
+			Response: user_usecase.UserOutputDTO{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/users/:userId", Legacy: []string{"/user/:userId"},
+			Handler: users.UpdateUser, OperationId: "updateUser", Tag: "users",
+			Summary:       "Altera o próprio cadastro",
+			Authenticated: true,
+			Body:          user_usecase.UserUpdateInputDTO{}, Response: user_usecase.UserOutputDTO{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/users/:userId", Legacy: []string{"/user/:userId"},
+			Handler: users.DeactivateUser, OperationId: "deactivateUser", Tag: "users",
+			Summary:       "Desativa o próprio cadastro",
+			Authenticated: true,
+			Status:        http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:userId/auctions", Legacy: []string{"/user/:userId/auctions"},
+			Handler: auctions.FindAuctionsBySellerId, OperationId: "listUserAuctions", Tag: "users",
+			Summary:  "Lista os leilões de um vendedor",
+			Response: []auction_usecase.AuctionOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:userId/bids", Legacy: []string{"/user/:userId/bids"},
+			Handler: bids.FindBidsByUserId, OperationId: "listUserBids", Tag: "users",
+			Summary: "Lista os lances de um usuário com o resultado de cada um",
+			Query:   bid_controller.UserBidListQuery{}, Response: bid_usecase.UserBidListOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:userId/wins", Legacy: []string{"/user/:userId/wins"},
+			Handler: bids.FindWinsByUserId, OperationId: "listUserWins", Tag: "users",
+			Summary: "Lista os leilões arrematados por um usuário",
+			Query:   bid_controller.UserBidListQuery{}, Response: bid_usecase.UserBidListOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:userId/ratings", Legacy: []string{"/user/:userId/ratings"},
+			Handler: deps.ratingController.FindRatingsByUserId, OperationId: "listUserRatings", Tag: "ratings",
+			Summary: "Lista as avaliações recebidas por um usuário",
+			Query:   rating_controller.RatingListQuery{}, Response: rating_usecase.RatingListOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:userId/watchlist", Legacy: []string{"/user/:userId/watchlist"},
+			Handler: watchlists.FindWatchlist, OperationId: "listUserWatchlist", Tag: "watchlist",
+			Summary:       "Leilões acompanhados pelo usuário autenticado, com o estado ao vivo",
+			Authenticated: true,
+			Query:         watchlist_controller.WatchlistQuery{}, Response: watchlist_usecase.WatchlistOutputDTO{},
+		},
+
+		// Carteira
+		{
+			Method: http.MethodGet, Path: "/wallet", Legacy: []string{"/wallet"},
+			Handler: deps.walletController.FindWallet, OperationId: "getWallet", Tag: "wallet",
+			Summary:       "Saldo da carteira do licitante autenticado",
+			Authenticated: true, Roles: bidder,
+			Response: wallet_usecase.WalletOutputDTO{},
+		},
+		{
+			Method: http.MethodGet, Path: "/wallet/ledger", Legacy: []string{"/wallet/ledger"},
+			Handler: deps.walletController.FindLedger, OperationId: "listWalletLedger", Tag: "wallet",
+			Summary:       "Movimentações da carteira",
+			Authenticated: true, Roles: bidder,
+			Query: wallet_controller.LedgerQuery{}, Response: wallet_usecase.LedgerListOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/wallet/deposits", Legacy: []string{"/wallet/deposit"},
+			Handler: deps.walletController.Deposit, OperationId: "depositToWallet", Tag: "wallet",
+			Summary:       "Deposita na carteira",
+			Authenticated: true, Roles: bidder,
+			Body: wallet_usecase.WalletAmountInputDTO{}, Response: wallet_usecase.WalletOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/wallet/withdrawals", Legacy: []string{"/wallet/withdraw"},
+			Handler: deps.walletController.Withdraw, OperationId: "withdrawFromWallet", Tag: "wallet",
+			Summary:       "Saca o saldo livre da carteira",
+			Authenticated: true, Roles: bidder,
+			Body: wallet_usecase.WalletAmountInputDTO{}, Response: wallet_usecase.WalletOutputDTO{},
+		},
+
+		// Bloqueios do vendedor
+		{
+			Method: http.MethodGet, Path: "/blocklist", Legacy: []string{"/blocklist"},
+			Handler: deps.restrictionController.FindBlockedBidders, OperationId: "listBlockedBidders", Tag: "blocklist",
+			Summary:       "Licitantes bloqueados pelo vendedor autenticado",
+			Authenticated: true, Roles: seller,
+			Response: []restriction_usecase.SellerBlockOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/blocklist", Legacy: []string{"/blocklist"},
+			Handler: deps.restrictionController.BlockBidder, OperationId: "blockBidder", Tag: "blocklist",
+			Summary:       "Bloqueia um licitante em todos os leilões do vendedor",
+			Authenticated: true, Roles: seller,
+			Body: restriction_usecase.BlockInputDTO{}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodDelete, Path: "/blocklist/:userId", Legacy: []string{"/blocklist/:userId"},
+			Handler: deps.restrictionController.UnblockBidder, OperationId: "unblockBidder", Tag: "blocklist",
+			Summary:       "Desbloqueia um licitante",
+			Authenticated: true, Roles: seller,
+			Status: http.StatusNoContent,
+		},
+
+		// Categorias
+		{
+			Method: http.MethodGet, Path: "/categories",
+			Handler: categories.FindCategories, OperationId: "listCategories", Tag: "categories",
+			Summary:  "Árvore das categorias ativas",
+			Response: []category_usecase.CategoryOutputDTO{},
+		},
+
+		// Administração
+		{
+			Method: http.MethodGet, Path: "/admin/actions", Legacy: []string{"/admin/actions"},
+			Handler: admin.FindAdminActions, OperationId: "listAdminActions", Tag: "admin",
+			Summary:       "Histórico das ações administrativas",
+			Authenticated: true, Roles: adminOnly,
+			Query: admin_controller.AdminActionListQuery{}, Response: []admin_usecase.AdminActionOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/auctions/close-expired", Legacy: []string{"/admin/auctions/close-expired"},
+			Handler: admin.CloseExpiredAuctions, OperationId: "closeExpiredAuctions", Tag: "admin",
+			Summary:       "Fecha os leilões expirados",
+			Authenticated: true, Roles: adminOnly,
+			Response: admin_usecase.CloseExpiredAuctionsOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/auctions/:auctionId/close", Legacy: []string{"/admin/auctions/:auctionId/close"},
+			Handler: admin.CloseAuction, OperationId: "closeAuction", Tag: "admin",
+			Summary:       "Fecha um leilão",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.AdminActionInputDTO{}, BodyOptional: true, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/auctions/:auctionId/reopen", Legacy: []string{"/admin/auctions/:auctionId/reopen"},
+			Handler: admin.ReopenAuction, OperationId: "reopenAuction", Tag: "admin",
+			Summary:       "Reabre um leilão fechado",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.AdminActionInputDTO{}, BodyOptional: true, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/bids/dead-letter", Legacy: []string{"/admin/bids/deadletter"},
+			Handler: bids.FindDeadLetters, OperationId: "listDeadLetterBids", Tag: "admin",
+			Summary:       "Lances que não puderam ser gravados",
+			Authenticated: true, Roles: adminOnly,
+			Response: []bid_usecase.DeadLetterBidOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/bids/dead-letter/:bidId/replay", Legacy: []string{"/admin/bids/deadletter/:bidId/replay"},
+			Handler: bids.ReplayDeadLetter, OperationId: "replayDeadLetterBid", Tag: "admin",
+			Summary:       "Reprocessa um lance da dead letter",
+			Authenticated: true, Roles: adminOnly,
+			Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodDelete, Path: "/admin/bids/dead-letter/:bidId", Legacy: []string{"/admin/bids/deadletter/:bidId"},
+			Handler: bids.DiscardDeadLetter, OperationId: "discardDeadLetterBid", Tag: "admin",
+			Summary:       "Descarta um lance da dead letter",
+			Authenticated: true, Roles: adminOnly,
+			Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/bids/:bidId/void", Legacy: []string{"/admin/bids/:bidId/void"},
+			Handler: admin.VoidBid, OperationId: "voidBid", Tag: "admin",
+			Summary:       "Anula um lance",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.AdminActionInputDTO{}, BodyOptional: true, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/users/:userId/suspend", Legacy: []string{"/admin/users/:userId/suspend"},
+			Handler: admin.SuspendUser, OperationId: "suspendUser", Tag: "admin",
+			Summary:       "Suspende um usuário",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.AdminActionInputDTO{}, BodyOptional: true, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/users/:userId/ban", Legacy: []string{"/admin/users/:userId/ban"},
+			Handler: admin.BanUser, OperationId: "banUser", Tag: "admin",
+			Summary:       "Bane um usuário da plataforma",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.BanInputDTO{}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodDelete, Path: "/admin/users/:userId/ban", Legacy: []string{"/admin/users/:userId/ban"},
+			Handler: admin.LiftBan, OperationId: "liftBan", Tag: "admin",
+			Summary:       "Remove o banimento de um usuário",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.AdminActionInputDTO{}, BodyOptional: true, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPut, Path: "/admin/users/:userId/roles", Legacy: []string{"/admin/users/:userId/roles"},
+			Handler: admin.UpdateUserRoles, OperationId: "updateUserRoles", Tag: "admin",
+			Summary:       "Define os papéis de um usuário",
+			Authenticated: true, Roles: adminOnly,
+			Body: admin_usecase.UserRolesInputDTO{}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/categories",
+			Handler: categories.FindAllCategories, OperationId: "listAllCategories", Tag: "admin",
+			Summary:       "Árvore completa das categorias, com as inativas",
+			Authenticated: true, Roles: adminOnly,
+			Response: []category_usecase.CategoryOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/categories",
+			Handler: categories.CreateCategory, OperationId: "createCategory", Tag: "admin",
+			Summary:       "Cria uma categoria",
+			Authenticated: true, Roles: adminOnly,
+			Body: category_usecase.CategoryInputDTO{}, Response: category_usecase.CategoryOutputDTO{},
+			Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodPatch, Path: "/admin/categories/:categoryId",
+			Handler: categories.UpdateCategory, OperationId: "updateCategory", Tag: "admin",
+			Summary:       "Renomeia, ativa ou desativa uma categoria",
+			Authenticated: true, Roles: adminOnly,
+			Body: category_usecase.CategoryUpdateInputDTO{}, Response: category_usecase.CategoryOutputDTO{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/admin/categories/:categoryId",
+			Handler: categories.DeleteCategory, OperationId: "deleteCategory", Tag: "admin",
+			Summary:       "Remove uma categoria sem subcategorias nem leilões",
+			Authenticated: true, Roles: adminOnly,
+			Status: http.StatusNoContent,
+		},
+	}
+}
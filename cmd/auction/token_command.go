@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/auth"
+	"fullcycle-auction_go/internal/infra/database/user"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const tokenUsage = "usage: auction token <userId>"
+
+// runTokenCommand emite um bearer token para um usuário ativo já cadastrado.
+func runTokenCommand(
+	ctx context.Context, database *mongo.Database, tokenService *auth.TokenService, args []string) error {
+	if len(args) != 1 {
+		return errors.New(tokenUsage)
+	}
+
+	userEntity, err := user.NewUserRepository(database).FindUserById(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if userEntity.Status != user_entity.Active {
+		return fmt.Errorf("user %s is %s", userEntity.Id, userEntity.Status)
+	}
+
+	token, tokenErr := tokenService.NewToken(userEntity.Id)
+	if tokenErr != nil {
+		return tokenErr
+	}
+
+	fmt.Println(token)
+	return nil
+}
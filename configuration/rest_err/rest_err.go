@@ -6,10 +6,13 @@ import (
 )
 
 type RestErr struct {
-	Message string   `json:"message"`
-	Err     string   `json:"err"`
-	Code    int      `json:"code"`
-	Causes  []Causes `json:"causes"`
+	Message string `json:"message"`
+	Err     string `json:"err"`
+	// ErrorCode é o código estável do erro (ex.: auction_closed); sem um código
+	// específico repete Err
+	ErrorCode string   `json:"error_code"`
+	Code      int      `json:"code"`
+	Causes    []Causes `json:"causes"`
 }
 
 type Causes struct {
@@ -21,40 +24,148 @@ func (r *RestErr) Error() string {
 	return r.Message
 }
 
+// ConvertError traduz o erro interno para a resposta HTTP, preservando o
+// código estável e as causas.
 func ConvertError(internalError *internal_error.InternalError) *RestErr {
+	restErr := newRestErr(internalError)
+
+	if internalError.Code != "" {
+		restErr.ErrorCode = internalError.Code
+	}
+	for _, cause := range internalError.Causes {
+		restErr.Causes = append(restErr.Causes, Causes{Field: cause.Field, Message: cause.Message})
+	}
+
+	return restErr
+}
+
+func newRestErr(internalError *internal_error.InternalError) *RestErr {
 	switch internalError.Err {
 	case "bad_request":
 		return NewBadRequestError(internalError.Error())
 	case "not_found":
 		return NewNotFoundError(internalError.Error())
+	case "unauthorized":
+		return NewUnauthorizedError(internalError.Error())
+	case "forbidden":
+		return NewForbiddenError(internalError.Error())
+	case "conflict":
+		return NewConflictError(internalError.Error())
+	case "gone":
+		return NewGoneError(internalError.Error())
+	case "unprocessable_entity":
+		return NewUnprocessableEntityError(internalError.Error())
+	case "too_many_requests":
+		return NewTooManyRequestsError(internalError.Error())
+	case "service_unavailable":
+		return NewServiceUnavailableError(internalError.Error())
 	default:
 		return NewInternalServerError(internalError.Error())
 	}
 }
 
+// WithCode define o código estável do erro.
+func (r *RestErr) WithCode(code string) *RestErr {
+	r.ErrorCode = code
+	return r
+}
+
 func NewBadRequestError(message string, causes ...Causes) *RestErr {
 	return &RestErr{
-		Message: message,
-		Err:     "bad_request",
-		Code:    http.StatusBadRequest,
-		Causes:  causes,
+		Message:   message,
+		Err:       "bad_request",
+		ErrorCode: "bad_request",
+		Code:      http.StatusBadRequest,
+		Causes:    causes,
 	}
 }
 
 func NewInternalServerError(message string) *RestErr {
 	return &RestErr{
-		Message: message,
-		Err:     "internal_server",
-		Code:    http.StatusInternalServerError,
-		Causes:  nil,
+		Message:   message,
+		Err:       "internal_server",
+		ErrorCode: "internal_server",
+		Code:      http.StatusInternalServerError,
+		Causes:    nil,
 	}
 }
 
 func NewNotFoundError(message string) *RestErr {
 	return &RestErr{
-		Message: message,
-		Err:     "not_found",
-		Code:    http.StatusNotFound,
-		Causes:  nil,
+		Message:   message,
+		Err:       "not_found",
+		ErrorCode: "not_found",
+		Code:      http.StatusNotFound,
+		Causes:    nil,
+	}
+}
+
+func NewUnauthorizedError(message string) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "unauthorized",
+		ErrorCode: "unauthorized",
+		Code:      http.StatusUnauthorized,
+		Causes:    nil,
+	}
+}
+
+func NewForbiddenError(message string) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "forbidden",
+		ErrorCode: "forbidden",
+		Code:      http.StatusForbidden,
+		Causes:    nil,
+	}
+}
+
+func NewConflictError(message string) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "conflict",
+		ErrorCode: "conflict",
+		Code:      http.StatusConflict,
+		Causes:    nil,
+	}
+}
+
+func NewTooManyRequestsError(message string) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "too_many_requests",
+		ErrorCode: "too_many_requests",
+		Code:      http.StatusTooManyRequests,
+		Causes:    nil,
+	}
+}
+
+func NewServiceUnavailableError(message string) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "service_unavailable",
+		ErrorCode: "service_unavailable",
+		Code:      http.StatusServiceUnavailable,
+		Causes:    nil,
+	}
+}
+
+func NewGoneError(message string) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "gone",
+		ErrorCode: "gone",
+		Code:      http.StatusGone,
+		Causes:    nil,
+	}
+}
+
+func NewUnprocessableEntityError(message string, causes ...Causes) *RestErr {
+	return &RestErr{
+		Message:   message,
+		Err:       "unprocessable_entity",
+		ErrorCode: "unprocessable_entity",
+		Code:      http.StatusUnprocessableEntity,
+		Causes:    causes,
 	}
 }
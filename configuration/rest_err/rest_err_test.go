@@ -90,3 +90,78 @@ func TestNewNotFoundError(t *testing.T) {
 	assert.Equal(t, 404, restErr.Code) // HTTP Status NotFound
 	assert.Nil(t, restErr.Causes)
 }
+
+func TestConvertError_TooManyRequests(t *testing.T) {
+	internalErr := internal_error.NewTooManyRequestsError("Bid queue is full")
+	restErr := rest_err.ConvertError(internalErr)
+
+	assert.Equal(t, "Bid queue is full", restErr.Message)
+	assert.Equal(t, "too_many_requests", restErr.Err)
+	assert.Equal(t, 429, restErr.Code) // HTTP Status TooManyRequests
+}
+
+func TestConvertError_ServiceUnavailable(t *testing.T) {
+	internalErr := internal_error.NewServiceUnavailableError("Bid queue timeout")
+	restErr := rest_err.ConvertError(internalErr)
+
+	assert.Equal(t, "Bid queue timeout", restErr.Message)
+	assert.Equal(t, "service_unavailable", restErr.Err)
+	assert.Equal(t, 503, restErr.Code) // HTTP Status ServiceUnavailable
+}
+
+func TestConvertError_Conflict(t *testing.T) {
+	internalErr := internal_error.NewConflictError("Email already registered")
+	restErr := rest_err.ConvertError(internalErr)
+
+	assert.Equal(t, "Email already registered", restErr.Message)
+	assert.Equal(t, "conflict", restErr.Err)
+	assert.Equal(t, 409, restErr.Code) // HTTP Status Conflict
+}
+
+func TestConvertError_Unauthorized(t *testing.T) {
+	internalErr := internal_error.NewUnauthorizedError("Missing bearer token")
+	restErr := rest_err.ConvertError(internalErr)
+
+	assert.Equal(t, "unauthorized", restErr.Err)
+	assert.Equal(t, 401, restErr.Code) // HTTP Status Unauthorized
+}
+
+func TestConvertError_Forbidden(t *testing.T) {
+	internalErr := internal_error.NewForbiddenError("Not allowed")
+	restErr := rest_err.ConvertError(internalErr)
+
+	assert.Equal(t, "forbidden", restErr.Err)
+	assert.Equal(t, 403, restErr.Code) // HTTP Status Forbidden
+}
+
+func TestConvertError_GoneAndUnprocessableEntity(t *testing.T) {
+	restErr := rest_err.ConvertError(internal_error.NewGoneError("Auction is closed"))
+	assert.Equal(t, "gone", restErr.Err)
+	assert.Equal(t, 410, restErr.Code) // HTTP Status Gone
+
+	restErr = rest_err.ConvertError(internal_error.NewUnprocessableEntityError("Insufficient balance"))
+	assert.Equal(t, "unprocessable_entity", restErr.Err)
+	assert.Equal(t, 422, restErr.Code) // HTTP Status UnprocessableEntity
+}
+
+func TestConvertError_KeepsCodeAndCauses(t *testing.T) {
+	internalErr := internal_error.NewBadRequestError("invalid auction object").
+		WithCode(internal_error.CodeValidationFailed).
+		WithCauses(internal_error.Cause{Field: "category", Message: "category is too short"})
+	restErr := rest_err.ConvertError(internalErr)
+
+	assert.Equal(t, 400, restErr.Code)
+	assert.Equal(t, "bad_request", restErr.Err)
+	assert.Equal(t, internal_error.CodeValidationFailed, restErr.ErrorCode)
+	assert.Equal(t, []rest_err.Causes{{Field: "category", Message: "category is too short"}}, restErr.Causes)
+}
+
+func TestConvertError_DefaultsCodeToCategory(t *testing.T) {
+	restErr := rest_err.ConvertError(internal_error.NewConflictError("Email already registered"))
+	assert.Equal(t, "conflict", restErr.ErrorCode)
+
+	restErr = rest_err.ConvertError(internal_error.NewInternalServerError("Error trying to find auction"))
+	assert.Equal(t, 500, restErr.Code)
+	assert.Equal(t, "internal_server", restErr.ErrorCode)
+	assert.Nil(t, restErr.Causes)
+}
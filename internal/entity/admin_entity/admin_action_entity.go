@@ -0,0 +1,67 @@
+package admin_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminAction is the audit record of a privileged operation.
+type AdminAction struct {
+	Id         string
+	AdminId    string
+	Action     ActionType
+	TargetType TargetType
+	TargetId   string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+type ActionType string
+type TargetType string
+
+const (
+	CloseAuction         ActionType = "close_auction"
+	ReopenAuction        ActionType = "reopen_auction"
+	UpdateAuction        ActionType = "update_auction"
+	CancelAuction        ActionType = "cancel_auction"
+	CloseExpiredAuctions ActionType = "close_expired_auctions"
+	VoidBid              ActionType = "void_bid"
+	SuspendUser          ActionType = "suspend_user"
+	BanUser              ActionType = "ban_user"
+	LiftBan              ActionType = "lift_ban"
+	UpdateUserRoles      ActionType = "update_user_roles"
+	CreateCategory       ActionType = "create_category"
+	UpdateCategory       ActionType = "update_category"
+	DeleteCategory       ActionType = "delete_category"
+)
+
+const (
+	AuctionTarget  TargetType = "auction"
+	BidTarget      TargetType = "bid"
+	UserTarget     TargetType = "user"
+	CategoryTarget TargetType = "category"
+)
+
+func NewAdminAction(
+	adminId string, action ActionType, targetType TargetType, targetId, reason string) AdminAction {
+	return AdminAction{
+		Id:         uuid.New().String(),
+		AdminId:    adminId,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+}
+
+type AdminActionRepositoryInterface interface {
+	SaveAdminAction(
+		ctx context.Context, adminAction AdminAction) *internal_error.InternalError
+
+	FindAdminActions(
+		ctx context.Context, limit int64) ([]AdminAction, *internal_error.InternalError)
+}
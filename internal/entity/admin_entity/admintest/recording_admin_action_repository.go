@@ -0,0 +1,24 @@
+// Package admintest reúne os dublês da auditoria administrativa usados pelos
+// testes de mais de um pacote.
+package admintest
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// RecordingAdminActionRepository guarda em Actions as ações auditadas, na
+// ordem em que foram gravadas.
+type RecordingAdminActionRepository struct {
+	Actions []admin_entity.AdminAction
+}
+
+func (r *RecordingAdminActionRepository) SaveAdminAction(ctx context.Context, adminAction admin_entity.AdminAction) *internal_error.InternalError {
+	r.Actions = append(r.Actions, adminAction)
+	return nil
+}
+
+func (r *RecordingAdminActionRepository) FindAdminActions(ctx context.Context, limit int64) ([]admin_entity.AdminAction, *internal_error.InternalError) {
+	return r.Actions, nil
+}
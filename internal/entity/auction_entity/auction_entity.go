@@ -3,16 +3,18 @@ package auction_entity
 import (
 	"context"
 	"fullcycle-auction_go/internal/internal_error"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 func CreateAuction(
-	productName, category, description string,
+	sellerId, productName, category, description string,
 	condition ProductCondition) (*Auction, *internal_error.InternalError) {
 	auction := &Auction{
 		Id:          uuid.New().String(),
+		SellerId:    sellerId,
 		ProductName: productName,
 		Category:    category,
 		Description: description,
@@ -29,12 +31,31 @@ func CreateAuction(
 }
 
 func (au *Auction) Validate() *internal_error.InternalError {
-	// Validação de campos obrigatórios e valores válidos
-	if len(au.ProductName) <= 1 ||
-		len(au.Category) <= 2 ||
-		len(au.Description) <= 10 || // Descrição deve ter mais de 10 caracteres
-		(au.Condition != New && au.Condition != Refurbished && au.Condition != Used) { // Condição deve ser New, Refurbished ou Used
-		return internal_error.NewBadRequestError("invalid auction object")
+	// Validação de campos obrigatórios e valores válidos; cada campo inválido vira uma causa
+	var causes []internal_error.Cause
+	if au.SellerId == "" {
+		causes = append(causes, internal_error.Cause{Field: "seller_id", Message: "seller_id is required"})
+	}
+	if len(au.ProductName) <= 1 {
+		causes = append(causes, internal_error.Cause{Field: "product_name", Message: "product_name is too short"})
+	}
+	if len(au.Category) <= 2 {
+		causes = append(causes, internal_error.Cause{Field: "category", Message: "category is too short"})
+	}
+	// Descrição deve ter mais de 10 caracteres
+	if len(au.Description) <= 10 {
+		causes = append(causes, internal_error.Cause{Field: "description", Message: "description must be longer than 10 characters"})
+	}
+	if au.ReservePrice < 0 {
+		causes = append(causes, internal_error.Cause{Field: "reserve_price", Message: "reserve_price must not be negative"})
+	}
+	// Condição deve ser New, Refurbished ou Used
+	if au.Condition != New && au.Condition != Refurbished && au.Condition != Used {
+		causes = append(causes, internal_error.Cause{Field: "condition", Message: "condition is not a valid value"})
+	}
+
+	if len(causes) > 0 {
+		return internal_error.NewBadRequestError("invalid auction object").WithCauses(causes...)
 	}
 
 	return nil
@@ -42,12 +63,45 @@ func (au *Auction) Validate() *internal_error.InternalError {
 
 type Auction struct {
 	Id          string
+	SellerId    string
 	ProductName string
 	Category    string
 	Description string
 	Condition   ProductCondition
 	Status      AuctionStatus
 	Timestamp   time.Time
+	// ReservePrice é o menor preço que o vendedor aceita; zero significa sem reserva
+	ReservePrice float64
+	// CurrentPrice, BidCount e LeadingBidderId descrevem os lances válidos e
+	// são atualizados juntos pelo repositório de lances (ver AuctionBidState)
+	CurrentPrice    float64
+	BidCount        int64
+	LeadingBidderId string
+	// WatcherCount é mantido pelo repositório da lista de acompanhamento
+	WatcherCount int64
+}
+
+// EndsAt é quando o leilão deixa de aceitar lances.
+func (au *Auction) EndsAt(auctionDuration time.Duration) time.Time {
+	return au.Timestamp.Add(auctionDuration)
+}
+
+// ReserveMet diz se o lance líder alcança o preço de reserva. Sem reserva,
+// qualquer lance a atinge.
+func (au *Auction) ReserveMet() bool {
+	return au.BidCount > 0 && au.CurrentPrice >= au.ReservePrice
+}
+
+// AuctionBidState é gravado em uma única atualização, então uma leitura do
+// leilão sempre vê preço, quantidade de lances e líder que combinam entre si.
+type AuctionBidState struct {
+	CurrentPrice    float64
+	BidCount        int64
+	LeadingBidderId string
+	// Revision cresce a cada lance gravado ou anulado. Um estado só substitui
+	// outro de revisão anterior, então uma atualização lenta nunca desfaz uma
+	// mais nova.
+	Revision int64
 }
 
 type ProductCondition int
@@ -56,6 +110,7 @@ type AuctionStatus int
 const (
 	Active AuctionStatus = iota
 	Completed
+	Cancelled
 )
 
 const (
@@ -64,20 +119,156 @@ const (
 	Refurbished
 )
 
+type AuctionSort string
+
+const (
+	SortEndingSoon AuctionSort = "ending_soon"
+	SortNewest     AuctionSort = "newest"
+	SortPriceDesc  AuctionSort = "price_desc"
+)
+
+// AuctionQuery é uma página da listagem de leilões. Valores zero significam
+// "sem filtro": Statuses vazio aceita qualquer status e datas zero deixam o
+// intervalo aberto. Cursor é o token opaco devolvido com a página anterior e
+// só vale para o mesmo Sort.
+type AuctionQuery struct {
+	Statuses []AuctionStatus
+	Category string
+	// IncludeSubcategories inclui as categorias abaixo de Category, escritas
+	// como caminhos "pai/filho".
+	IncludeSubcategories bool
+	Condition            ProductCondition
+	ProductName          string
+	MinPrice             *float64
+	MaxPrice             *float64
+	// CreatedFrom e CreatedTo limitam o timestamp do leilão, inclusive.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// EndingFrom e EndingTo limitam quando o leilão deixa de aceitar lances,
+	// inclusive.
+	EndingFrom time.Time
+	EndingTo   time.Time
+	Sort       AuctionSort
+	Limit      int64
+	Cursor     string
+}
+
+func (q *AuctionQuery) Validate() *internal_error.InternalError {
+	for _, status := range q.Statuses {
+		if status != Active && status != Completed && status != Cancelled {
+			return internal_error.NewBadRequestError("Invalid auction status").
+				WithCauses(internal_error.Cause{Field: "status", Message: "status is not a valid value"})
+		}
+	}
+
+	if q.Condition != 0 && q.Condition != New && q.Condition != Used && q.Condition != Refurbished {
+		return internal_error.NewBadRequestError("Invalid product condition").
+			WithCauses(internal_error.Cause{Field: "condition", Message: "condition is not a valid value"})
+	}
+
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return internal_error.NewBadRequestError("Minimum price is greater than maximum price").
+			WithCauses(internal_error.Cause{Field: "min_price", Message: "min_price must not exceed max_price"})
+	}
+
+	if !q.CreatedFrom.IsZero() && !q.CreatedTo.IsZero() && q.CreatedFrom.After(q.CreatedTo) {
+		return internal_error.NewBadRequestError("Date range start is after its end").
+			WithCauses(internal_error.Cause{Field: "created_from", Message: "created_from must not be after created_to"})
+	}
+
+	if !q.EndingFrom.IsZero() && !q.EndingTo.IsZero() && q.EndingFrom.After(q.EndingTo) {
+		return internal_error.NewBadRequestError("Date range start is after its end").
+			WithCauses(internal_error.Cause{Field: "ending_from", Message: "ending_from must not be after ending_to"})
+	}
+
+	return nil
+}
+
+// AuctionSearchQuery é uma página da busca textual. Text usa a sintaxe da
+// busca textual do Mongo: palavras, "frases entre aspas" e -palavras excluídas.
+type AuctionSearchQuery struct {
+	Text                 string
+	Statuses             []AuctionStatus
+	Category             string
+	IncludeSubcategories bool
+	Page                 int64
+	Limit                int64
+}
+
+func (q *AuctionSearchQuery) Validate() *internal_error.InternalError {
+	if strings.TrimSpace(q.Text) == "" {
+		return internal_error.NewBadRequestError("Search text is empty").
+			WithCauses(internal_error.Cause{Field: "q", Message: "q is required"})
+	}
+
+	filters := AuctionQuery{Statuses: q.Statuses}
+	return filters.Validate()
+}
+
+// AuctionSearchResult é um leilão encontrado pela busca e a sua pontuação.
+type AuctionSearchResult struct {
+	Auction Auction
+	Score   float64
+}
+
 type AuctionRepositoryInterface interface {
 	CreateAuction(
 		ctx context.Context,
 		auctionEntity *Auction) *internal_error.InternalError
 
+	// FindAuctions devolve uma página de leilões e o cursor da próxima,
+	// vazio quando não há mais leilões.
 	FindAuctions(
 		ctx context.Context,
-		status AuctionStatus,
-		category, productName string) ([]Auction, *internal_error.InternalError)
+		query AuctionQuery) ([]Auction, string, *internal_error.InternalError)
+
+	// SearchAuctions devolve uma página dos leilões encontrados, com a maior
+	// pontuação primeiro, e o total de leilões encontrados.
+	SearchAuctions(
+		ctx context.Context,
+		query AuctionSearchQuery) ([]AuctionSearchResult, int64, *internal_error.InternalError)
 
 	FindAuctionById(
 		ctx context.Context, id string) (*Auction, *internal_error.InternalError)
 
-	FindExpiredAuctions(ctx context.Context, auctionTimeoutSeconds int64) ([]Auction, *internal_error.InternalError)
+	FindAuctionsBySellerId(
+		ctx context.Context, sellerId string) ([]Auction, *internal_error.InternalError)
+
+	UpdateAuction(
+		ctx context.Context,
+		auctionEntity *Auction) *internal_error.InternalError
+
+	FindExpiredAuctions(ctx context.Context, now time.Time) ([]Auction, *internal_error.InternalError)
+
+	// UpdateAuctionStatus leva um leilão ativo para status. Devolve conflito
+	// quando o leilão já não está ativo, para que só quem venceu a transição
+	// liquide ou devolva as reservas.
+	UpdateAuctionStatus(ctx context.Context, id string, status AuctionStatus) *internal_error.InternalError
+
+	// IncrementWatcherCount soma delta, +1 ou -1, aos usuários que acompanham o leilão.
+	IncrementWatcherCount(ctx context.Context, id string, delta int64) *internal_error.InternalError
+
+	UpdateBidState(
+		ctx context.Context, id string, state AuctionBidState) *internal_error.InternalError
+
+	// ReopenAuction reativa um leilão encerrado ou cancelado, com um novo
+	// prazo de lances a partir de timestamp.
+	ReopenAuction(
+		ctx context.Context, id string, timestamp time.Time) *internal_error.InternalError
+}
+
+type AuctionCacheStats struct {
+	Hits       uint64
+	Misses     uint64
+	Evictions  uint64
+	Size       int
+	MaxEntries int
+	TTL        time.Duration
+}
 
-	UpdateAuctionStatus(ctx context.Context, id string, status int) *internal_error.InternalError
+// AuctionCacheInterface é implementada pelos repositórios que guardam leilões
+// em memória e precisam ser invalidados quando um leilão muda.
+type AuctionCacheInterface interface {
+	Invalidate(id string)
+	Stats() AuctionCacheStats
 }
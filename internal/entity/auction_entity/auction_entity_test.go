@@ -1,21 +1,24 @@
 package auction_entity_test
 
 import (
+	"encoding/json"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateAuction_ValidData(t *testing.T) {
 	// Teste de criação de leilão com dados válidos
-	auction, err := auction_entity.CreateAuction("Produto Teste", "Categoria Teste", "Descrição do Produto", auction_entity.New)
+	auction, err := auction_entity.CreateAuction("seller-1", "Produto Teste", "Categoria Teste", "Descrição do Produto", auction_entity.New)
 
 	// Verificando se o erro é nil, ou seja, criação bem-sucedida
 	assert.Nil(t, err)
 	// Verificando se o ID foi gerado
 	assert.NotEmpty(t, auction.Id)
+	assert.Equal(t, "seller-1", auction.SellerId)
 	// Verificando os valores dos campos
 	assert.Equal(t, "Produto Teste", auction.ProductName)
 	assert.Equal(t, "Categoria Teste", auction.Category)
@@ -26,47 +29,62 @@ func TestCreateAuction_ValidData(t *testing.T) {
 
 func TestCreateAuction_InvalidProductName(t *testing.T) {
 	// Teste de criação de leilão com nome de produto inválido
-	auction, err := auction_entity.CreateAuction("", "Categoria Teste", "Descrição do Produto", auction_entity.New)
+	auction, err := auction_entity.CreateAuction("seller-1", "", "Categoria Teste", "Descrição do Produto", auction_entity.New)
 
 	// Verificando se o erro é retornado devido ao nome do produto inválido
 	assert.NotNil(t, err)
 	assert.Nil(t, auction)
-	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object"), err)
+	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object").
+		WithCauses(internal_error.Cause{Field: "product_name", Message: "product_name is too short"}), err)
+}
+
+func TestCreateAuction_MissingSeller(t *testing.T) {
+	// Todo leilão precisa de um vendedor
+	auction, err := auction_entity.CreateAuction("", "Produto Teste", "Categoria Teste", "Descrição do Produto", auction_entity.New)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, auction)
+	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object").
+		WithCauses(internal_error.Cause{Field: "seller_id", Message: "seller_id is required"}), err)
 }
 
 func TestCreateAuction_InvalidCategory(t *testing.T) {
 	// Teste de criação de leilão com categoria inválida
-	auction, err := auction_entity.CreateAuction("Produto Teste", "Ca", "Descrição do Produto", auction_entity.New)
+	auction, err := auction_entity.CreateAuction("seller-1", "Produto Teste", "Ca", "Descrição do Produto", auction_entity.New)
 
 	// Verificando se o erro é retornado devido à categoria inválida
 	assert.NotNil(t, err)
 	assert.Nil(t, auction)
-	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object"), err)
+	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object").
+		WithCauses(internal_error.Cause{Field: "category", Message: "category is too short"}), err)
 }
 
 func TestCreateAuction_InvalidDescription(t *testing.T) {
 	// Teste de criação de leilão com descrição inválida
-	auction, err := auction_entity.CreateAuction("Produto Teste", "Categoria Teste", "Desc", auction_entity.New)
+	auction, err := auction_entity.CreateAuction("seller-1", "Produto Teste", "Categoria Teste", "Desc", auction_entity.New)
 
 	// Verificando se o erro é retornado devido à descrição inválida
 	assert.NotNil(t, err)
 	assert.Nil(t, auction)
-	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object"), err)
+	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object").
+		WithCauses(internal_error.Cause{Field: "description", Message: "description must be longer than 10 characters"}), err)
 }
 
 func TestCreateAuction_InvalidCondition(t *testing.T) {
 	// Teste de criação de leilão com condição inválida
-	auction, err := auction_entity.CreateAuction("Produto Teste", "Categoria Teste", "Descrição do Produto", 100) // Condição inválida
+	auction, err := auction_entity.CreateAuction("seller-1", "Produto Teste", "Categoria Teste", "Descrição do Produto", 100) // Condição inválida
 
 	// Verificando se o erro é retornado devido à condição inválida
 	assert.NotNil(t, err)
 	assert.Nil(t, auction)
-	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object"), err)
+	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object").
+		WithCauses(internal_error.Cause{Field: "condition", Message: "condition is not a valid value"}), err)
 }
 
 func TestValidateAuction_Valid(t *testing.T) {
 	// Teste de validação com dados válidos
 	auction := &auction_entity.Auction{
+		SellerId:    "seller-1",
 		ProductName: "Produto Teste",
 		Category:    "Categoria Teste",
 		Description: "Descrição do Produto",
@@ -90,5 +108,64 @@ func TestValidateAuction_Invalid(t *testing.T) {
 	// Verificando se o erro é retornado devido ao nome do produto inválido
 	err := auction.Validate()
 	assert.NotNil(t, err)
-	assert.Equal(t, internal_error.NewBadRequestError("invalid auction object"), err)
+	assert.Equal(t, "invalid auction object", err.Message)
+	assert.Equal(t, "bad_request", err.Err)
+
+	// Cada campo inválido vira uma causa
+	fields := []string{}
+	for _, cause := range err.Causes {
+		fields = append(fields, cause.Field)
+	}
+	assert.Equal(t, []string{"seller_id", "product_name"}, fields)
+}
+
+func TestAuction_ReserveMetAndEndsAt(t *testing.T) {
+	auction := auction_entity.Auction{Timestamp: time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC), ReservePrice: 100}
+
+	assert.Equal(t, time.Date(2026, 1, 10, 13, 0, 0, 0, time.UTC), auction.EndsAt(time.Hour))
+	assert.False(t, auction.ReserveMet())
+
+	auction.BidCount, auction.CurrentPrice = 2, 99.99
+	assert.False(t, auction.ReserveMet())
+
+	auction.CurrentPrice = 100
+	assert.True(t, auction.ReserveMet())
+
+	// Sem reserva qualquer lance atinge o preço mínimo
+	noReserve := auction_entity.Auction{BidCount: 1, CurrentPrice: 1}
+	assert.True(t, noReserve.ReserveMet())
+}
+
+func TestProductCondition_JSONUsesNamesAndAcceptsNumbers(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Condition auction_entity.ProductCondition `json:"condition"`
+		Status    auction_entity.AuctionStatus    `json:"status"`
+	}{auction_entity.Refurbished, auction_entity.Completed})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"condition":"refurbished","status":"closed"}`, string(data))
+
+	var condition auction_entity.ProductCondition
+	assert.Nil(t, json.Unmarshal([]byte(`"used"`), &condition))
+	assert.Equal(t, auction_entity.Used, condition)
+	// Números continuam aceitos por compatibilidade com os clientes antigos
+	assert.Nil(t, json.Unmarshal([]byte(`1`), &condition))
+	assert.Equal(t, auction_entity.New, condition)
+
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, json.Unmarshal([]byte(`"broken"`), &condition), &typeErr)
+}
+
+func TestParseAuctionStatus_AcceptsNamesAndNumbers(t *testing.T) {
+	status, err := auction_entity.ParseAuctionStatus("closed")
+	assert.Nil(t, err)
+	assert.Equal(t, auction_entity.Completed, status)
+
+	status, err = auction_entity.ParseAuctionStatus("2")
+	assert.Nil(t, err)
+	assert.Equal(t, auction_entity.Cancelled, status)
+
+	_, err = auction_entity.ParseAuctionStatus("open")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{"active", "closed", "cancelled"}, auction_entity.AuctionStatus(0).EnumValues())
 }
@@ -0,0 +1,156 @@
+package auction_entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Nomes públicos das condições e status. É o único mapeamento entre os
+// valores numéricos, gravados no Mongo, e os nomes expostos pela API.
+var (
+	conditionNames = map[ProductCondition]string{
+		New:         "new",
+		Used:        "used",
+		Refurbished: "refurbished",
+	}
+	statusNames = map[AuctionStatus]string{
+		Active:    "active",
+		Completed: "closed",
+		Cancelled: "cancelled",
+	}
+)
+
+// ParseProductCondition aceita o nome da condição ou, por compatibilidade,
+// o seu valor numérico. Vazio devolve zero, que significa "sem condição".
+func ParseProductCondition(value string) (ProductCondition, error) {
+	condition, err := parseEnum(value, conditionNames)
+	if err != nil {
+		return 0, fmt.Errorf("invalid product condition %q", value)
+	}
+	return condition, nil
+}
+
+// ParseAuctionStatus aceita o nome do status ou o seu valor numérico.
+func ParseAuctionStatus(value string) (AuctionStatus, error) {
+	status, err := parseEnum(value, statusNames)
+	if err != nil {
+		return 0, fmt.Errorf("invalid auction status %q", value)
+	}
+	return status, nil
+}
+
+func (c ProductCondition) String() string {
+	if name, ok := conditionNames[c]; ok {
+		return name
+	}
+	return strconv.Itoa(int(c))
+}
+
+// EnumValues lista os nomes aceitos, na ordem dos valores numéricos.
+func (ProductCondition) EnumValues() []string {
+	return enumValues(conditionNames)
+}
+
+func (c ProductCondition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *ProductCondition) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, c, conditionNames)
+}
+
+// UnmarshalParam permite o uso do tipo em query strings com o binding do gin.
+func (c *ProductCondition) UnmarshalParam(param string) error {
+	condition, err := ParseProductCondition(param)
+	if err != nil {
+		return err
+	}
+	*c = condition
+	return nil
+}
+
+func (s AuctionStatus) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+func (AuctionStatus) EnumValues() []string {
+	return enumValues(statusNames)
+}
+
+func (s AuctionStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *AuctionStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, s, statusNames)
+}
+
+func (s *AuctionStatus) UnmarshalParam(param string) error {
+	status, err := ParseAuctionStatus(param)
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+func parseEnum[T ~int](value string, names map[T]string) (T, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	for enum, name := range names {
+		if strings.EqualFold(value, name) {
+			return enum, nil
+		}
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	return T(number), nil
+}
+
+// unmarshalEnum aceita o nome em string ou o número; nomes desconhecidos viram
+// UnmarshalTypeError, que a validação da API devolve como causa do campo.
+func unmarshalEnum[T ~int](data []byte, target *T, names map[T]string) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var number int
+		if err := json.Unmarshal(data, &number); err != nil {
+			return &json.UnmarshalTypeError{Value: string(data), Type: reflect.TypeOf(*target)}
+		}
+		*target = T(number)
+		return nil
+	}
+
+	value, err := parseEnum(name, names)
+	if err != nil || name == "" {
+		return &json.UnmarshalTypeError{Value: "string " + strconv.Quote(name), Type: reflect.TypeOf(*target)}
+	}
+	*target = value
+	return nil
+}
+
+func enumValues[T ~int](names map[T]string) []string {
+	enums := make([]T, 0, len(names))
+	for enum := range names {
+		enums = append(enums, enum)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i] < enums[j] })
+
+	values := make([]string, 0, len(enums))
+	for _, enum := range enums {
+		values = append(values, names[enum])
+	}
+	return values
+}
@@ -0,0 +1,70 @@
+package auction_entity
+
+import "time"
+
+type AuctionEventType string
+
+const (
+	// EventSnapshot carries the current state to a subscriber that could not
+	// resume; it is never published.
+	EventSnapshot    AuctionEventType = "snapshot"
+	EventBidAccepted AuctionEventType = "bid_accepted"
+	// EventPriceChanged is published when a bid takes the lead or the leading
+	// bid is voided.
+	EventPriceChanged AuctionEventType = "price_changed"
+	// EventAuctionExtended is published when an auction is reopened, the only
+	// operation that moves its end time.
+	EventAuctionExtended AuctionEventType = "auction_extended"
+	EventAuctionClosed   AuctionEventType = "auction_closed"
+)
+
+// AuctionEvent is something that happened to an auction. Auction is the state
+// as stored once the event was persisted; Bid is the accepted bid, or the
+// winning bid when the auction closes, and is nil otherwise. Id is assigned by
+// the broker.
+type AuctionEvent struct {
+	Id        string
+	Type      AuctionEventType
+	Auction   Auction
+	Bid       *AuctionEventBid
+	Timestamp time.Time
+}
+
+type AuctionEventBid struct {
+	Id        string
+	BidderId  string
+	Amount    float64
+	Timestamp time.Time
+}
+
+func NewAuctionEvent(eventType AuctionEventType, auction Auction, bid *AuctionEventBid) AuctionEvent {
+	return AuctionEvent{
+		Type:      eventType,
+		Auction:   auction,
+		Bid:       bid,
+		Timestamp: time.Now(),
+	}
+}
+
+// AuctionEventSubscription delivers the events of one auction. Missed holds
+// the events published after the Last-Event-ID the subscriber resumed from;
+// when Resumed is false the history no longer reaches it and the subscriber
+// should start over from a snapshot taken at Cursor. Events is closed when
+// the subscriber falls too far behind or Cancel is called.
+type AuctionEventSubscription struct {
+	Missed  []AuctionEvent
+	Resumed bool
+	Cursor  string
+	Events  <-chan AuctionEvent
+	Cancel  func()
+}
+
+type AuctionEventPublisher interface {
+	Publish(event AuctionEvent)
+}
+
+type AuctionEventBroker interface {
+	AuctionEventPublisher
+
+	Subscribe(auctionId, lastEventId string) *AuctionEventSubscription
+}
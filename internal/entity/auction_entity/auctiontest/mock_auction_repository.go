@@ -0,0 +1,82 @@
+// Package auctiontest reúne os dublês do repositório de leilões usados pelos
+// testes de mais de um pacote.
+package auctiontest
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuctionRepository registra cada chamada no mock.Mock. Os retornos
+// configurados com Return aceitam nil sem tipo no lugar do erro e dos ponteiros.
+type MockAuctionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuctionRepository) CreateAuction(ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	return internalError(m.Called(ctx, auctionEntity), 0)
+}
+
+func (m *MockAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	args := m.Called(ctx, id)
+	auction, _ := args.Get(0).(*auction_entity.Auction)
+	return auction, internalError(args, 1)
+}
+
+func (m *MockAuctionRepository) FindAuctions(ctx context.Context, query auction_entity.AuctionQuery) ([]auction_entity.Auction, string, *internal_error.InternalError) {
+	args := m.Called(ctx, query)
+	auctions, _ := args.Get(0).([]auction_entity.Auction)
+	return auctions, args.String(1), internalError(args, 2)
+}
+
+func (m *MockAuctionRepository) SearchAuctions(ctx context.Context, query auction_entity.AuctionSearchQuery) ([]auction_entity.AuctionSearchResult, int64, *internal_error.InternalError) {
+	args := m.Called(ctx, query)
+	results, _ := args.Get(0).([]auction_entity.AuctionSearchResult)
+	return results, args.Get(1).(int64), internalError(args, 2)
+}
+
+func (m *MockAuctionRepository) FindAuctionsBySellerId(ctx context.Context, sellerId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	args := m.Called(ctx, sellerId)
+	auctions, _ := args.Get(0).([]auction_entity.Auction)
+	return auctions, internalError(args, 1)
+}
+
+func (m *MockAuctionRepository) UpdateAuction(ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	return internalError(m.Called(ctx, auctionEntity), 0)
+}
+
+func (m *MockAuctionRepository) FindExpiredAuctions(ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+	args := m.Called(ctx, now)
+	auctions, _ := args.Get(0).([]auction_entity.Auction)
+	return auctions, internalError(args, 1)
+}
+
+func (m *MockAuctionRepository) ReopenAuction(ctx context.Context, id string, timestamp time.Time) *internal_error.InternalError {
+	return internalError(m.Called(ctx, id, timestamp), 0)
+}
+
+func (m *MockAuctionRepository) UpdateBidState(ctx context.Context, id string, state auction_entity.AuctionBidState) *internal_error.InternalError {
+	return internalError(m.Called(ctx, id, state), 0)
+}
+
+func (m *MockAuctionRepository) UpdateAuctionStatus(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	return internalError(m.Called(ctx, id, status), 0)
+}
+
+func (m *MockAuctionRepository) IncrementWatcherCount(ctx context.Context, id string, delta int64) *internal_error.InternalError {
+	return internalError(m.Called(ctx, id, delta), 0)
+}
+
+// internalError lê o erro configurado na posição index, que pode faltar ou
+// ser nil sem tipo.
+func internalError(args mock.Arguments, index int) *internal_error.InternalError {
+	if len(args) <= index {
+		return nil
+	}
+	err, _ := args.Get(index).(*internal_error.InternalError)
+	return err
+}
@@ -2,6 +2,8 @@ package bid_entity
 
 import (
 	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
 	"time"
@@ -13,6 +15,8 @@ type Bid struct {
 	AuctionId string
 	Amount    float64
 	Timestamp time.Time
+	// Voided bids were cancelled by an administrator and never win
+	Voided bool
 }
 
 func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.InternalError) {
@@ -33,24 +37,125 @@ func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.
 
 func (b *Bid) Validate() *internal_error.InternalError {
 	if err := uuid.Validate(b.UserId); err != nil {
-		return internal_error.NewBadRequestError("UserId is not a valid id")
+		return internal_error.NewBadRequestError("UserId is not a valid id").
+			WithCauses(internal_error.Cause{Field: "user_id", Message: "Invalid UUID value"})
 	} else if err := uuid.Validate(b.AuctionId); err != nil {
-		return internal_error.NewBadRequestError("AuctionId is not a valid id")
+		return internal_error.NewBadRequestError("AuctionId is not a valid id").
+			WithCauses(internal_error.Cause{Field: "auction_id", Message: "Invalid UUID value"})
 	} else if b.Amount <= 0 {
-		return internal_error.NewBadRequestError("Amount is not a valid value")
+		return internal_error.NewBadRequestError("Amount is not a valid value").
+			WithCauses(internal_error.Cause{Field: "amount", Message: "amount must be greater than 0"})
 	}
 
 	return nil
 }
 
+type BidStatus string
+
+const (
+	Pending  BidStatus = "pending"
+	Accepted BidStatus = "accepted"
+	Rejected BidStatus = "rejected"
+	Failed   BidStatus = "failed"
+)
+
+// BidRejection is a bid refused at persistence time, e.g. placed after the auction ended.
+type BidRejection struct {
+	BidId  string
+	Reason string
+}
+
 type BidEntityRepository interface {
+	// CreateBid persists the bids that are still valid for their auction and
+	// returns the ones rejected; the error is reserved for infrastructure failures.
 	CreateBid(
 		ctx context.Context,
-		bidEntities []Bid) *internal_error.InternalError
+		bidEntities []Bid) ([]BidRejection, *internal_error.InternalError)
 
+	// FindBidByAuctionId pages through the auction bids, voided ones
+	// included, and returns the total of bids.
 	FindBidByAuctionId(
-		ctx context.Context, auctionId string) ([]Bid, *internal_error.InternalError)
+		ctx context.Context,
+		auctionId string,
+		sort BidSort,
+		page, limit int64) ([]Bid, int64, *internal_error.InternalError)
+
+	// FindBidSummary aggregates the valid bids of an auction. An auction
+	// without bids has an empty summary.
+	FindBidSummary(
+		ctx context.Context, auctionId string) (*BidSummary, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*Bid, *internal_error.InternalError)
+
+	FindBidById(
+		ctx context.Context, bidId string) (*Bid, *internal_error.InternalError)
+
+	VoidBid(ctx context.Context, bidId string) *internal_error.InternalError
+
+	// FindBidsByUserId pages through the user's bids, newest first. An empty
+	// outcome returns every bid.
+	FindBidsByUserId(
+		ctx context.Context,
+		userId string,
+		outcome BidOutcome,
+		page, limit int64) ([]UserBid, int64, *internal_error.InternalError)
+}
+
+type BidSort string
+
+const (
+	BidSortNewest  BidSort = "newest"
+	BidSortHighest BidSort = "highest"
+)
+
+// BidSummary describes the valid bids of an auction. OpeningBid is the
+// amount of the first bid; LastBidAt is zero when there are no bids.
+type BidSummary struct {
+	Count         int64
+	UniqueBidders int64
+	HighBid       float64
+	OpeningBid    float64
+	LastBidAt     time.Time
+}
+
+// BidOutcome is where a bid stands from the bidder's point of view.
+type BidOutcome string
+
+const (
+	OutcomeActive    BidOutcome = "active"
+	OutcomeOutbid    BidOutcome = "outbid"
+	OutcomeWon       BidOutcome = "won"
+	OutcomeLost      BidOutcome = "lost"
+	OutcomeCancelled BidOutcome = "cancelled"
+	OutcomeVoided    BidOutcome = "voided"
+)
+
+// UserBid is a bid joined with its auction. Leading tells whether it is the
+// highest valid bid of the auction; Settlement is the status of the bid's
+// wallet hold, empty when the bid never reserved funds.
+type UserBid struct {
+	Bid        Bid
+	Auction    auction_entity.Auction
+	Leading    bool
+	Settlement wallet_entity.HoldStatus
+}
+
+// Outcome treats a leading bid below the reserve price of a completed
+// auction as lost, since the auction closed without a sale.
+func (ub UserBid) Outcome() BidOutcome {
+	switch {
+	case ub.Bid.Voided:
+		return OutcomeVoided
+	case ub.Auction.Status == auction_entity.Cancelled:
+		return OutcomeCancelled
+	case ub.Auction.Status == auction_entity.Completed && ub.Leading && ub.Bid.Amount >= ub.Auction.ReservePrice:
+		return OutcomeWon
+	case ub.Auction.Status == auction_entity.Completed:
+		return OutcomeLost
+	case ub.Leading:
+		return OutcomeActive
+	default:
+		return OutcomeOutbid
+	}
 }
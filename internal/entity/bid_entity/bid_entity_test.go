@@ -0,0 +1,38 @@
+package bid_entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+)
+
+func TestUserBid_Outcome(t *testing.T) {
+	cases := []struct {
+		status  auction_entity.AuctionStatus
+		reserve float64
+		leading bool
+		voided  bool
+		want    bid_entity.BidOutcome
+	}{
+		{auction_entity.Active, 0, true, false, bid_entity.OutcomeActive},
+		{auction_entity.Active, 0, false, false, bid_entity.OutcomeOutbid},
+		{auction_entity.Completed, 0, true, false, bid_entity.OutcomeWon},
+		{auction_entity.Completed, 10, true, false, bid_entity.OutcomeWon},
+		{auction_entity.Completed, 11, true, false, bid_entity.OutcomeLost},
+		{auction_entity.Completed, 0, false, false, bid_entity.OutcomeLost},
+		{auction_entity.Cancelled, 0, true, false, bid_entity.OutcomeCancelled},
+		{auction_entity.Completed, 0, false, true, bid_entity.OutcomeVoided},
+	}
+
+	for _, c := range cases {
+		userBid := bid_entity.UserBid{
+			Bid:     bid_entity.Bid{Amount: 10, Voided: c.voided},
+			Auction: auction_entity.Auction{Status: c.status, ReservePrice: c.reserve},
+			Leading: c.leading,
+		}
+		assert.Equal(t, c.want, userBid.Outcome())
+	}
+}
@@ -0,0 +1,30 @@
+package bid_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// DeadLetterBid is a bid that could not be persisted after all retries.
+type DeadLetterBid struct {
+	Bid      Bid
+	Error    string
+	Attempts int
+	FailedAt time.Time
+}
+
+type DeadLetterRepositoryInterface interface {
+	SaveDeadLetter(
+		ctx context.Context,
+		deadLetter DeadLetterBid) *internal_error.InternalError
+
+	FindDeadLetters(
+		ctx context.Context) ([]DeadLetterBid, *internal_error.InternalError)
+
+	FindDeadLetterByBidId(
+		ctx context.Context, bidId string) (*DeadLetterBid, *internal_error.InternalError)
+
+	DeleteDeadLetter(
+		ctx context.Context, bidId string) *internal_error.InternalError
+}
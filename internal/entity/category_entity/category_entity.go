@@ -0,0 +1,148 @@
+package category_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+// PathSeparator separa os slugs no caminho da categoria ("eletronicos/celulares").
+// O caminho é o valor gravado na categoria do leilão.
+const PathSeparator = "/"
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Category é um nó da taxonomia dos leilões. Path é o caminho do pai seguido
+// do slug e identifica a categoria nos leilões e nos filtros.
+type Category struct {
+	Id        string
+	Slug      string
+	Name      string
+	ParentId  string
+	Path      string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCategory cria uma categoria ativa. Sem slug, ele é gerado a partir do
+// nome; sem parent a categoria é uma raiz.
+func NewCategory(name, slug string, parent *Category) (*Category, *internal_error.InternalError) {
+	if slug == "" {
+		slug = Slugify(name)
+	}
+
+	category := &Category{
+		Id:        uuid.New().String(),
+		Slug:      slug,
+		Name:      strings.TrimSpace(name),
+		Path:      slug,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	category.UpdatedAt = category.CreatedAt
+
+	if parent != nil {
+		category.ParentId = parent.Id
+		category.Path = parent.Path + PathSeparator + slug
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (c *Category) Validate() *internal_error.InternalError {
+	var causes []internal_error.Cause
+	if len(c.Name) < 2 {
+		causes = append(causes, internal_error.Cause{Field: "name", Message: "name is too short"})
+	}
+	if !slugPattern.MatchString(c.Slug) {
+		causes = append(causes, internal_error.Cause{
+			Field: "slug", Message: "slug must have only lowercase letters, digits and hyphens"})
+	}
+
+	if len(causes) > 0 {
+		return internal_error.NewBadRequestError("invalid category object").WithCauses(causes...)
+	}
+
+	return nil
+}
+
+// AncestorPaths devolve os caminhos das categorias acima desta, da raiz ao pai.
+func (c *Category) AncestorPaths() []string {
+	slugs := strings.Split(c.Path, PathSeparator)
+
+	paths := make([]string, 0, len(slugs)-1)
+	for i := 1; i < len(slugs); i++ {
+		paths = append(paths, strings.Join(slugs[:i], PathSeparator))
+	}
+	return paths
+}
+
+// Slugify gera o slug de um nome: minúsculas sem acentos, com hífens no lugar
+// dos demais caracteres ("Eletrônicos & Games" vira "eletronicos-games").
+func Slugify(name string) string {
+	var slug strings.Builder
+	hyphen := false
+
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Acentos separados da letra pela normalização
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if hyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			hyphen = false
+		default:
+			hyphen = true
+		}
+	}
+
+	return slug.String()
+}
+
+type CategoryRepositoryInterface interface {
+	// CreateCategory devolve conflito quando o caminho já existe.
+	CreateCategory(
+		ctx context.Context, category *Category) *internal_error.InternalError
+
+	UpdateCategory(
+		ctx context.Context, category *Category) *internal_error.InternalError
+
+	// DeleteCategory devolve conflito enquanto a categoria tiver
+	// subcategorias ou leilões.
+	DeleteCategory(
+		ctx context.Context, id string) *internal_error.InternalError
+
+	// AddAuction conta mais um leilão na categoria antes de ele ser gravado.
+	// Devolve not found se a categoria foi removida, para que nenhum leilão
+	// seja criado em uma categoria removida.
+	AddAuction(
+		ctx context.Context, id string) *internal_error.InternalError
+
+	// RemoveAuction desfaz AddAuction quando o leilão sai da categoria ou
+	// não pôde ser gravado.
+	RemoveAuction(
+		ctx context.Context, id string) *internal_error.InternalError
+
+	FindCategoryById(
+		ctx context.Context, id string) (*Category, *internal_error.InternalError)
+
+	FindCategoryByPath(
+		ctx context.Context, path string) (*Category, *internal_error.InternalError)
+
+	// FindCategories devolve a taxonomia inteira, ativa ou não, ordenada pelo caminho.
+	FindCategories(
+		ctx context.Context) ([]Category, *internal_error.InternalError)
+}
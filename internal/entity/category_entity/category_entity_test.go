@@ -0,0 +1,41 @@
+package category_entity_test
+
+import (
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify_RemovesAccentsAndSymbols(t *testing.T) {
+	assert.Equal(t, "eletronicos-games", category_entity.Slugify("Eletrônicos & Games"))
+	assert.Equal(t, "cafe-e-cha", category_entity.Slugify("  Café e Chá!  "))
+	assert.Equal(t, "tvs-4k", category_entity.Slugify("TVs 4K"))
+}
+
+func TestNewCategory_BuildsPathFromParent(t *testing.T) {
+	parent, err := category_entity.NewCategory("Eletrônicos", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "eletronicos", parent.Path)
+	assert.True(t, parent.Active)
+
+	child, err := category_entity.NewCategory("Celulares", "", parent)
+	assert.Nil(t, err)
+	assert.Equal(t, parent.Id, child.ParentId)
+	assert.Equal(t, "eletronicos/celulares", child.Path)
+
+	grandchild, err := category_entity.NewCategory("Android", "android", child)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eletronicos", "eletronicos/celulares"}, grandchild.AncestorPaths())
+	assert.Empty(t, parent.AncestorPaths())
+}
+
+func TestNewCategory_RejectsInvalidSlug(t *testing.T) {
+	_, err := category_entity.NewCategory("Celulares", "Celulares/Novos", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, "slug", err.Causes[0].Field)
+
+	_, err = category_entity.NewCategory("!!", "", nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,103 @@
+package rating_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RaterRole tells which side of the sale left the rating.
+type RaterRole string
+
+const (
+	Buyer  RaterRole = "buyer"
+	Seller RaterRole = "seller"
+)
+
+const (
+	MinScore         = 1
+	MaxScore         = 5
+	MaxCommentLength = 1000
+)
+
+// Rating is left by the buyer or the seller of a settled auction about the
+// other side of the sale. Each side rates an auction at most once.
+type Rating struct {
+	Id        string
+	AuctionId string
+	RaterId   string
+	RateeId   string
+	RaterRole RaterRole
+	Score     int
+	Comment   string
+	CreatedAt time.Time
+}
+
+// Reputation aggregates the ratings received by a user and the sales and
+// purchases completed on settled auctions.
+type Reputation struct {
+	UserId             string
+	AverageScore       float64
+	RatingCount        int64
+	CompletedSales     int64
+	CompletedPurchases int64
+}
+
+func CreateRating(
+	auctionId, raterId, rateeId string,
+	raterRole RaterRole,
+	score int,
+	comment string) (*Rating, *internal_error.InternalError) {
+	rating := &Rating{
+		Id:        uuid.New().String(),
+		AuctionId: auctionId,
+		RaterId:   raterId,
+		RateeId:   rateeId,
+		RaterRole: raterRole,
+		Score:     score,
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	}
+
+	if err := rating.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rating, nil
+}
+
+func (r *Rating) Validate() *internal_error.InternalError {
+	if r.Score < MinScore || r.Score > MaxScore {
+		return internal_error.NewBadRequestError("Score must be between 1 and 5")
+	}
+
+	if len(r.Comment) > MaxCommentLength {
+		return internal_error.NewBadRequestError("Comment is too long")
+	}
+
+	if r.AuctionId == "" || r.RaterId == "" || r.RateeId == "" || r.RaterId == r.RateeId {
+		return internal_error.NewBadRequestError("invalid rating object")
+	}
+
+	if r.RaterRole != Buyer && r.RaterRole != Seller {
+		return internal_error.NewBadRequestError("invalid rating object")
+	}
+
+	return nil
+}
+
+type RatingRepositoryInterface interface {
+	// CreateRating fails with a conflict if the rater already rated the auction.
+	CreateRating(
+		ctx context.Context, rating *Rating) *internal_error.InternalError
+
+	FindRatingsByUserId(
+		ctx context.Context,
+		rateeId string,
+		page, limit int64) ([]Rating, int64, *internal_error.InternalError)
+
+	FindReputation(
+		ctx context.Context, userId string) (*Reputation, *internal_error.InternalError)
+}
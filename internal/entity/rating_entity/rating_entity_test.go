@@ -0,0 +1,41 @@
+package rating_entity_test
+
+import (
+	"fullcycle-auction_go/internal/entity/rating_entity"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRating_ValidData(t *testing.T) {
+	rating, err := rating_entity.CreateRating("a1", "buyer-1", "seller-1", rating_entity.Buyer, 5, "Entrega rápida")
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, rating.Id)
+	assert.Equal(t, "seller-1", rating.RateeId)
+	assert.Equal(t, rating_entity.Buyer, rating.RaterRole)
+}
+
+func TestCreateRating_InvalidScore(t *testing.T) {
+	for _, score := range []int{0, 6} {
+		rating, err := rating_entity.CreateRating("a1", "buyer-1", "seller-1", rating_entity.Buyer, score, "")
+
+		assert.Nil(t, rating)
+		assert.NotNil(t, err)
+		assert.Equal(t, "Score must be between 1 and 5", err.Message)
+	}
+}
+
+func TestCreateRating_InvalidData(t *testing.T) {
+	// Comentário longo demais
+	_, err := rating_entity.CreateRating("a1", "buyer-1", "seller-1", rating_entity.Buyer, 3, strings.Repeat("a", 1001))
+	assert.NotNil(t, err)
+
+	// Ninguém avalia a si mesmo
+	_, err = rating_entity.CreateRating("a1", "user-1", "user-1", rating_entity.Seller, 3, "")
+	assert.NotNil(t, err)
+
+	_, err = rating_entity.CreateRating("a1", "buyer-1", "seller-1", "other", 3, "")
+	assert.NotNil(t, err)
+}
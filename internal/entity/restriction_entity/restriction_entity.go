@@ -0,0 +1,105 @@
+package restriction_entity
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// SellerBlock keeps a bidder out of every auction of one seller.
+type SellerBlock struct {
+	SellerId  string
+	BidderId  string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Ban keeps a user out of the platform until ExpiresAt. A zero ExpiresAt
+// means the ban is permanent until an admin lifts it.
+type Ban struct {
+	UserId    string
+	AdminId   string
+	Reason    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func NewSellerBlock(sellerId, bidderId, reason string) (*SellerBlock, *internal_error.InternalError) {
+	if sellerId == bidderId {
+		return nil, internal_error.NewBadRequestError("Sellers cannot block themselves")
+	}
+
+	return &SellerBlock{
+		SellerId:  sellerId,
+		BidderId:  bidderId,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func NewBan(userId, adminId, reason string, expiresAt time.Time) (*Ban, *internal_error.InternalError) {
+	if reason == "" {
+		return nil, internal_error.NewBadRequestError("A ban requires a reason")
+	}
+
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return nil, internal_error.NewBadRequestError("Ban expiry must be in the future")
+	}
+
+	return &Ban{
+		UserId:    userId,
+		AdminId:   adminId,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (b *Ban) Permanent() bool {
+	return b.ExpiresAt.IsZero()
+}
+
+func (b *Ban) ActiveAt(now time.Time) bool {
+	return b.Permanent() || now.Before(b.ExpiresAt)
+}
+
+// Error is the forbidden error returned to a banned user.
+func (b *Ban) Error() *internal_error.InternalError {
+	if b.Permanent() {
+		return internal_error.NewForbiddenError(
+			fmt.Sprintf("User is banned from the platform: %s", b.Reason)).
+			WithCode(internal_error.CodeUserBanned)
+	}
+
+	return internal_error.NewForbiddenError(fmt.Sprintf(
+		"User is banned from the platform until %s: %s",
+		b.ExpiresAt.UTC().Format(time.RFC3339), b.Reason)).
+		WithCode(internal_error.CodeUserBanned)
+}
+
+type RestrictionRepositoryInterface interface {
+	// BlockBidder is idempotent; blocking again only updates the reason.
+	BlockBidder(
+		ctx context.Context, block *SellerBlock) *internal_error.InternalError
+
+	UnblockBidder(
+		ctx context.Context, sellerId, bidderId string) *internal_error.InternalError
+
+	FindBlockedBidders(
+		ctx context.Context, sellerId string) ([]SellerBlock, *internal_error.InternalError)
+
+	IsBidderBlocked(
+		ctx context.Context, sellerId, bidderId string) (bool, *internal_error.InternalError)
+
+	// BanUser replaces any previous ban of the user.
+	BanUser(
+		ctx context.Context, ban *Ban) *internal_error.InternalError
+
+	LiftBan(
+		ctx context.Context, userId string) *internal_error.InternalError
+
+	// FindActiveBan returns nil when the user is not banned or the ban expired.
+	FindActiveBan(
+		ctx context.Context, userId string) (*Ban, *internal_error.InternalError)
+}
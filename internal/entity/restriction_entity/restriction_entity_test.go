@@ -0,0 +1,42 @@
+package restriction_entity_test
+
+import (
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSellerBlock_RejectsSelfBlock(t *testing.T) {
+	block, err := restriction_entity.NewSellerBlock("seller-1", "seller-1", "")
+
+	assert.Nil(t, block)
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+}
+
+func TestNewBan_Validation(t *testing.T) {
+	_, err := restriction_entity.NewBan("u1", "admin-1", "", time.Time{})
+	assert.NotNil(t, err)
+
+	_, err = restriction_entity.NewBan("u1", "admin-1", "fraude", time.Now().Add(-time.Hour))
+	assert.NotNil(t, err)
+
+	ban, err := restriction_entity.NewBan("u1", "admin-1", "fraude", time.Time{})
+	assert.Nil(t, err)
+	assert.True(t, ban.Permanent())
+}
+
+func TestBan_ActiveUntilExpiry(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	ban, err := restriction_entity.NewBan("u1", "admin-1", "spam", expiresAt)
+	assert.Nil(t, err)
+
+	assert.True(t, ban.ActiveAt(time.Now()))
+	assert.False(t, ban.ActiveAt(expiresAt.Add(time.Second)))
+
+	banErr := ban.Error()
+	assert.Equal(t, "forbidden", banErr.Err)
+	assert.Contains(t, banErr.Message, "spam")
+}
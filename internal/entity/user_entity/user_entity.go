@@ -3,14 +3,129 @@ package user_entity
 import (
 	"context"
 	"fullcycle-auction_go/internal/internal_error"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type User struct {
-	Id   string
-	Name string
+	Id        string
+	Name      string
+	Email     string
+	Status    UserStatus
+	Roles     []Role
+	CreatedAt time.Time
+}
+
+type UserStatus string
+
+const (
+	Active    UserStatus = "active"
+	Inactive  UserStatus = "inactive"
+	Suspended UserStatus = "suspended"
+)
+
+type Role string
+
+const (
+	RoleBidder Role = "bidder"
+	RoleSeller Role = "seller"
+	RoleAdmin  Role = "admin"
+)
+
+// CreateUser registers a user; without explicit roles the user can only bid.
+func CreateUser(name, email string, roles ...Role) (*User, *internal_error.InternalError) {
+	if len(roles) == 0 {
+		roles = []Role{RoleBidder}
+	}
+
+	user := &User{
+		Id:        uuid.New().String(),
+		Name:      strings.TrimSpace(name),
+		Email:     NormalizeEmail(email),
+		Status:    Active,
+		Roles:     roles,
+		CreatedAt: time.Now(),
+	}
+
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *User) Validate() *internal_error.InternalError {
+	return u.validate(true)
+}
+
+// Update changes the given fields and validates the result. Users registered
+// before emails were required have none and can still be updated without one.
+func (u *User) Update(name, email *string) *internal_error.InternalError {
+	if name != nil {
+		u.Name = strings.TrimSpace(*name)
+	}
+
+	if email != nil {
+		u.Email = NormalizeEmail(*email)
+	}
+
+	return u.validate(email != nil || u.Email != "")
+}
+
+func (u *User) validate(requireEmail bool) *internal_error.InternalError {
+	if len(u.Name) < 2 {
+		return internal_error.NewBadRequestError("Name is not a valid value")
+	}
+
+	if requireEmail {
+		if _, err := mail.ParseAddress(u.Email); err != nil {
+			return internal_error.NewBadRequestError("Email is not a valid value")
+		}
+	}
+
+	if len(u.Roles) == 0 {
+		return internal_error.NewBadRequestError("Roles must not be empty")
+	}
+
+	for _, role := range u.Roles {
+		if role != RoleBidder && role != RoleSeller && role != RoleAdmin {
+			return internal_error.NewBadRequestError("Role is not a valid value")
+		}
+	}
+
+	return nil
+}
+
+func (u *User) HasRole(role Role) bool {
+	for _, userRole := range u.Roles {
+		if userRole == role {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEmail keeps emails comparable for the uniqueness index.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
 
 type UserRepositoryInterface interface {
+	CreateUser(
+		ctx context.Context, userEntity *User) *internal_error.InternalError
+
+	UpdateUser(
+		ctx context.Context, userEntity *User) *internal_error.InternalError
+
+	UpdateUserStatus(
+		ctx context.Context, userId string, status UserStatus) *internal_error.InternalError
+
 	FindUserById(
 		ctx context.Context, userId string) (*User, *internal_error.InternalError)
+
+	FindUsers(
+		ctx context.Context, page, limit int64) ([]User, int64, *internal_error.InternalError)
 }
@@ -0,0 +1,48 @@
+package user_entity_test
+
+import (
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUser_ValidData(t *testing.T) {
+	user, err := user_entity.CreateUser(" Maria Silva ", " Maria@Example.com ")
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, user.Id)
+	assert.Equal(t, "Maria Silva", user.Name)
+	// O email é normalizado para a verificação de unicidade
+	assert.Equal(t, "maria@example.com", user.Email)
+	assert.Equal(t, user_entity.Active, user.Status)
+	assert.Equal(t, []user_entity.Role{user_entity.RoleBidder}, user.Roles)
+}
+
+func TestCreateUser_InvalidName(t *testing.T) {
+	user, err := user_entity.CreateUser("M", "maria@example.com")
+
+	assert.Nil(t, user)
+	assert.Equal(t, internal_error.NewBadRequestError("Name is not a valid value"), err)
+}
+
+func TestCreateUser_InvalidEmail(t *testing.T) {
+	user, err := user_entity.CreateUser("Maria Silva", "maria.example.com")
+
+	assert.Nil(t, user)
+	assert.Equal(t, internal_error.NewBadRequestError("Email is not a valid value"), err)
+}
+
+func TestCreateUser_Roles(t *testing.T) {
+	user, err := user_entity.CreateUser("Maria Silva", "maria@example.com",
+		user_entity.RoleBidder, user_entity.RoleSeller)
+
+	assert.Nil(t, err)
+	assert.True(t, user.HasRole(user_entity.RoleSeller))
+	assert.False(t, user.HasRole(user_entity.RoleAdmin))
+
+	user, err = user_entity.CreateUser("Maria Silva", "maria@example.com", "owner")
+	assert.Nil(t, user)
+	assert.Equal(t, internal_error.NewBadRequestError("Role is not a valid value"), err)
+}
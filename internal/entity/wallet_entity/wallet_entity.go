@@ -0,0 +1,139 @@
+package wallet_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Wallet is a user's balance. Held is the part of the balance reserved by
+// bids that may still win, so only Balance - Held can be bid or withdrawn.
+type Wallet struct {
+	UserId    string
+	Balance   float64
+	Held      float64
+	UpdatedAt time.Time
+}
+
+func (w *Wallet) Available() float64 {
+	return w.Balance - w.Held
+}
+
+type HoldStatus string
+
+const (
+	Held     HoldStatus = "held"
+	Released HoldStatus = "released"
+	Captured HoldStatus = "captured"
+)
+
+// Hold reserves the amount of one bid. Its id is the bid id, which makes
+// placing a hold for a retried or replayed bid idempotent.
+type Hold struct {
+	Id        string
+	UserId    string
+	AuctionId string
+	Amount    float64
+	Status    HoldStatus
+	CreatedAt time.Time
+}
+
+func NewHold(bidId, userId, auctionId string, amount float64) Hold {
+	return Hold{
+		Id:        bidId,
+		UserId:    userId,
+		AuctionId: auctionId,
+		Amount:    amount,
+		Status:    Held,
+		CreatedAt: time.Now(),
+	}
+}
+
+type LedgerEntryType string
+
+const (
+	Deposit      LedgerEntryType = "deposit"
+	Withdrawal   LedgerEntryType = "withdrawal"
+	HoldPlaced   LedgerEntryType = "hold"
+	HoldReleased LedgerEntryType = "release"
+	HoldCaptured LedgerEntryType = "capture"
+)
+
+// LedgerEntry records every movement of a wallet.
+type LedgerEntry struct {
+	Id        string
+	UserId    string
+	Type      LedgerEntryType
+	Amount    float64
+	Reference string
+	CreatedAt time.Time
+}
+
+func NewLedgerEntry(userId string, entryType LedgerEntryType, amount float64, reference string) LedgerEntry {
+	return LedgerEntry{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		Type:      entryType,
+		Amount:    amount,
+		Reference: reference,
+		CreatedAt: time.Now(),
+	}
+}
+
+func ValidateAmount(amount float64) *internal_error.InternalError {
+	if amount <= 0 {
+		return internal_error.NewBadRequestError("Amount is not a valid value")
+	}
+	return nil
+}
+
+// OutbidHolds returns the holds that can no longer win once leadingBidId is
+// the highest bid. Holds above the leading amount belong to bids still in
+// the queue and are kept.
+func OutbidHolds(holds []Hold, leadingBidId string, leadingAmount float64) []Hold {
+	var outbid []Hold
+	for _, hold := range holds {
+		if hold.Id != leadingBidId && hold.Amount <= leadingAmount {
+			outbid = append(outbid, hold)
+		}
+	}
+	return outbid
+}
+
+type WalletRepositoryInterface interface {
+	// FindWallet returns an empty wallet for users that never deposited.
+	FindWallet(
+		ctx context.Context, userId string) (*Wallet, *internal_error.InternalError)
+
+	Deposit(
+		ctx context.Context, userId string, amount float64) (*Wallet, *internal_error.InternalError)
+
+	// Withdraw fails without changing the wallet if the available balance is not enough.
+	Withdraw(
+		ctx context.Context, userId string, amount float64) (*Wallet, *internal_error.InternalError)
+
+	// PlaceHold reserves the hold amount atomically, failing if the available
+	// balance is not enough. Placing an existing hold again is a no-op.
+	PlaceHold(ctx context.Context, hold Hold) *internal_error.InternalError
+
+	// ReleaseHold and CaptureHold only act on holds still held, so each hold
+	// is released or captured at most once. Settling a hold again the same
+	// way is a no-op; settling it the other way fails with a conflict.
+	ReleaseHold(ctx context.Context, holdId string) *internal_error.InternalError
+
+	CaptureHold(ctx context.Context, holdId string) *internal_error.InternalError
+
+	FindHeldHoldsByAuctionId(
+		ctx context.Context, auctionId string) ([]Hold, *internal_error.InternalError)
+
+	// HasCapturedHold reports whether the winner of the auction was already charged.
+	HasCapturedHold(
+		ctx context.Context, auctionId string) (bool, *internal_error.InternalError)
+
+	FindLedger(
+		ctx context.Context,
+		userId string,
+		page, limit int64) ([]LedgerEntry, int64, *internal_error.InternalError)
+}
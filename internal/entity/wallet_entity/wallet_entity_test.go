@@ -0,0 +1,30 @@
+package wallet_entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+)
+
+func TestOutbidHolds(t *testing.T) {
+	holds := []wallet_entity.Hold{
+		{Id: "lower", Amount: 50},
+		{Id: "tie", Amount: 100},
+		{Id: "leader", Amount: 100},
+		{Id: "queued", Amount: 150},
+	}
+
+	outbid := wallet_entity.OutbidHolds(holds, "leader", 100)
+
+	assert.Equal(t, []wallet_entity.Hold{holds[0], holds[1]}, outbid)
+}
+
+func TestWallet_Available(t *testing.T) {
+	wallet := wallet_entity.Wallet{Balance: 300, Held: 120}
+
+	assert.Equal(t, 180.0, wallet.Available())
+	assert.NotNil(t, wallet_entity.ValidateAmount(0))
+	assert.Nil(t, wallet_entity.ValidateAmount(0.01))
+}
@@ -0,0 +1,116 @@
+// Package wallettest reúne os dublês da carteira usados pelos testes de mais
+// de um pacote.
+package wallettest
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+// FakeWalletRepository guarda as reservas em memória e liquida cada uma só
+// uma vez, como o repositório real. Usuários fora de Balances têm saldo
+// ilimitado; os demais são cobrados na captura.
+type FakeWalletRepository struct {
+	wallet_entity.WalletRepositoryInterface
+
+	mu       sync.Mutex
+	Balances map[string]float64
+	holds    map[string]wallet_entity.Hold
+}
+
+// NewFakeWalletRepository cria a carteira com as reservas holds já gravadas.
+func NewFakeWalletRepository(holds ...wallet_entity.Hold) *FakeWalletRepository {
+	f := &FakeWalletRepository{holds: map[string]wallet_entity.Hold{}}
+	for _, hold := range holds {
+		f.holds[hold.Id] = hold
+	}
+	return f
+}
+
+func (f *FakeWalletRepository) PlaceHold(ctx context.Context, hold wallet_entity.Hold) *internal_error.InternalError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holds == nil {
+		f.holds = map[string]wallet_entity.Hold{}
+	}
+
+	if balance, limited := f.Balances[hold.UserId]; limited {
+		held := 0.0
+		for _, existing := range f.holds {
+			if existing.UserId == hold.UserId && existing.Status == wallet_entity.Held {
+				held += existing.Amount
+			}
+		}
+		if balance-held < hold.Amount {
+			return internal_error.NewUnprocessableEntityError("Insufficient available balance for this bid").
+				WithCode(internal_error.CodeInsufficientBalance)
+		}
+	}
+
+	f.holds[hold.Id] = hold
+	return nil
+}
+
+func (f *FakeWalletRepository) ReleaseHold(ctx context.Context, holdId string) *internal_error.InternalError {
+	return f.settle(holdId, wallet_entity.Released)
+}
+
+func (f *FakeWalletRepository) CaptureHold(ctx context.Context, holdId string) *internal_error.InternalError {
+	return f.settle(holdId, wallet_entity.Captured)
+}
+
+func (f *FakeWalletRepository) settle(holdId string, status wallet_entity.HoldStatus) *internal_error.InternalError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hold, ok := f.holds[holdId]
+	if !ok || hold.Status == status {
+		return nil
+	}
+	if hold.Status != wallet_entity.Held {
+		return internal_error.NewConflictError(fmt.Sprintf("Hold was already %s", hold.Status)).
+			WithCode(internal_error.CodeHoldAlreadySettled)
+	}
+
+	hold.Status = status
+	f.holds[holdId] = hold
+	if _, limited := f.Balances[hold.UserId]; limited && status == wallet_entity.Captured {
+		f.Balances[hold.UserId] -= hold.Amount
+	}
+	return nil
+}
+
+func (f *FakeWalletRepository) HasCapturedHold(ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, hold := range f.holds {
+		if hold.AuctionId == auctionId && hold.Status == wallet_entity.Captured {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *FakeWalletRepository) FindHeldHoldsByAuctionId(ctx context.Context, auctionId string) ([]wallet_entity.Hold, *internal_error.InternalError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var holds []wallet_entity.Hold
+	for _, hold := range f.holds {
+		if hold.AuctionId == auctionId && hold.Status == wallet_entity.Held {
+			holds = append(holds, hold)
+		}
+	}
+	sort.Slice(holds, func(i, j int) bool { return holds[i].Id < holds[j].Id })
+	return holds, nil
+}
+
+// HoldStatus devolve o status da reserva holdId, vazio quando ela não existe.
+func (f *FakeWalletRepository) HoldStatus(holdId string) wallet_entity.HoldStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.holds[holdId].Status
+}
@@ -0,0 +1,40 @@
+package watchlist_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// Watch is a user following an auction without bidding on it.
+type Watch struct {
+	UserId    string
+	AuctionId string
+	CreatedAt time.Time
+	// Auction is filled when the watchlist is read.
+	Auction auction_entity.Auction
+}
+
+func NewWatch(userId, auctionId string) *Watch {
+	return &Watch{
+		UserId:    userId,
+		AuctionId: auctionId,
+		CreatedAt: time.Now(),
+	}
+}
+
+type WatchlistRepositoryInterface interface {
+	// WatchAuction is idempotent; watching again keeps the original date.
+	WatchAuction(
+		ctx context.Context, watch *Watch) *internal_error.InternalError
+
+	UnwatchAuction(
+		ctx context.Context, userId, auctionId string) *internal_error.InternalError
+
+	// FindWatchesByUserId returns a page of the watchlist with the watched
+	// auctions, most recent first, and the total number of watched auctions.
+	// Watches of auctions that no longer exist are left out of both.
+	FindWatchesByUserId(
+		ctx context.Context, userId string, page, limit int64) ([]Watch, int64, *internal_error.InternalError)
+}
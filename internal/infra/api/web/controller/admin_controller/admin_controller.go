@@ -0,0 +1,185 @@
+package admin_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/admin_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminController struct {
+	adminUseCase admin_usecase.AdminUseCaseInterface
+}
+
+func NewAdminController(adminUseCase admin_usecase.AdminUseCaseInterface) *AdminController {
+	return &AdminController{
+		adminUseCase: adminUseCase,
+	}
+}
+
+func (u *AdminController) CloseAuction(c *gin.Context) {
+	u.runAction(c, "auctionId", u.adminUseCase.CloseAuction)
+}
+
+func (u *AdminController) ReopenAuction(c *gin.Context) {
+	u.runAction(c, "auctionId", u.adminUseCase.ReopenAuction)
+}
+
+func (u *AdminController) VoidBid(c *gin.Context) {
+	u.runAction(c, "bidId", u.adminUseCase.VoidBid)
+}
+
+func (u *AdminController) SuspendUser(c *gin.Context) {
+	u.runAction(c, "userId", u.adminUseCase.SuspendUser)
+}
+
+func (u *AdminController) CloseExpiredAuctions(c *gin.Context) {
+	result, err := u.adminUseCase.CloseExpiredAuctions(
+		context.Background(), middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (u *AdminController) LiftBan(c *gin.Context) {
+	u.runAction(c, "userId", u.adminUseCase.LiftBan)
+}
+
+func (u *AdminController) BanUser(c *gin.Context) {
+	userId, ok := validateId(c, "userId")
+	if !ok {
+		return
+	}
+
+	var banInputDTO admin_usecase.BanInputDTO
+
+	if err := c.ShouldBindJSON(&banInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	err := u.adminUseCase.BanUser(
+		context.Background(), middleware.AuthenticatedUserId(c), userId, banInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *AdminController) UpdateUserRoles(c *gin.Context) {
+	userId, ok := validateId(c, "userId")
+	if !ok {
+		return
+	}
+
+	var userRolesInputDTO admin_usecase.UserRolesInputDTO
+
+	if err := c.ShouldBindJSON(&userRolesInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	err := u.adminUseCase.UpdateUserRoles(
+		context.Background(), middleware.AuthenticatedUserId(c), userId, userRolesInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+type AdminActionListQuery struct {
+	Limit int64 `form:"limit,default=50" binding:"min=1,max=500"`
+}
+
+func (u *AdminController) FindAdminActions(c *gin.Context) {
+	var query AdminActionListQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	adminActions, err := u.adminUseCase.FindAdminActions(context.Background(), query.Limit)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, adminActions)
+}
+
+// runAction executa uma ação administrativa sobre o recurso do parâmetro
+// informado. O corpo com o motivo é opcional.
+func (u *AdminController) runAction(
+	c *gin.Context,
+	param string,
+	action func(context.Context, string, string, admin_usecase.AdminActionInputDTO) *internal_error.InternalError) {
+	targetId, ok := validateId(c, param)
+	if !ok {
+		return
+	}
+
+	var adminActionInputDTO admin_usecase.AdminActionInputDTO
+
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&adminActionInputDTO); err != nil {
+			restErr := validation.ValidateErr(err)
+
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+	}
+
+	err := action(context.Background(), middleware.AuthenticatedUserId(c), targetId, adminActionInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func validateId(c *gin.Context, param string) (string, bool) {
+	id := c.Param(param)
+
+	if err := uuid.Validate(id); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   param,
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return id, true
+}
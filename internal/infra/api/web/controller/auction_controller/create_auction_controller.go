@@ -3,20 +3,29 @@ package auction_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuctionController struct {
-	auctionUseCase auction_usecase.AuctionUseCaseInterface
+	auctionUseCase  auction_usecase.AuctionUseCaseInterface
+	streamHeartbeat time.Duration
+	streamOrigins   []string
 }
 
-func NewAuctionController(auctionUseCase auction_usecase.AuctionUseCaseInterface) *AuctionController {
+func NewAuctionController(
+	auctionUseCase auction_usecase.AuctionUseCaseInterface,
+	streamHeartbeat time.Duration,
+	streamOrigins []string) *AuctionController {
 	return &AuctionController{
-		auctionUseCase: auctionUseCase,
+		auctionUseCase:  auctionUseCase,
+		streamHeartbeat: streamHeartbeat,
+		streamOrigins:   streamOrigins,
 	}
 }
 
@@ -30,6 +39,8 @@ func (u *AuctionController) CreateAuction(c *gin.Context) {
 		return
 	}
 
+	auctionInputDTO.SellerId = middleware.AuthenticatedUserId(c)
+
 	err := u.auctionUseCase.CreateAuction(context.Background(), auctionInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
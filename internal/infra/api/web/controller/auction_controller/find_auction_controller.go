@@ -3,10 +3,9 @@ package auction_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
-	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -36,19 +35,60 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 }
 
 func (u *AuctionController) FindAuctions(c *gin.Context) {
-	status := c.Query("status")
-	category := c.Query("category")
-	productName := c.Query("productName")
+	var listInput auction_usecase.AuctionListInputDTO
+	if err := c.ShouldBindQuery(&listInput); err != nil {
+		errRest := validation.ValidateErr(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	// productName era o nome do parâmetro antes de product_name
+	if listInput.ProductName == "" {
+		listInput.ProductName = c.Query("productName")
+	}
 
-	statusNumber, errConv := strconv.Atoi(status)
-	if errConv != nil {
-		errRest := rest_err.NewBadRequestError("Error trying to validate auction status param")
+	auctions, err := u.auctionUseCase.FindAuctions(context.Background(), listInput)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	auctions, err := u.auctionUseCase.FindAuctions(context.Background(),
-		auction_usecase.AuctionStatus(statusNumber), category, productName)
+	c.JSON(http.StatusOK, auctions)
+}
+
+func (u *AuctionController) SearchAuctions(c *gin.Context) {
+	var searchInput auction_usecase.AuctionSearchInputDTO
+	if err := c.ShouldBindQuery(&searchInput); err != nil {
+		errRest := validation.ValidateErr(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	results, err := u.auctionUseCase.SearchAuctions(context.Background(), searchInput)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+func (u *AuctionController) FindAuctionsBySellerId(c *gin.Context) {
+	userId := c.Param("userId")
+
+	if err := uuid.Validate(userId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "userId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	auctions, err := u.auctionUseCase.FindAuctionsBySellerId(context.Background(), userId)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -95,22 +135,13 @@ func (u *AuctionController) FindExpiredAuctions(c *gin.Context) {
 	c.JSON(http.StatusOK, auctions)
 }
 
-func (u *AuctionController) CloseExpiredAuctions(c *gin.Context) {
-	log.Println("Iniciando fechamento de leilões expirados...")
-
-	// Chama o UseCase para fechar os leilões expirados
-	err := u.auctionUseCase.CloseExpiredAuctions(context.Background())
+func (u *AuctionController) FindCacheStats(c *gin.Context) {
+	stats, err := u.auctionUseCase.FindCacheStats(context.Background())
 	if err != nil {
-		log.Printf("Erro ao fechar leilões expirados: %v\n", err)
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	log.Println("Fechamento de leilões expirados concluído com sucesso.")
-
-	// Retorna uma mensagem indicando que os leilões expirados foram processados
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Expired auctions processed successfully.",
-	})
+	c.JSON(http.StatusOK, stats)
 }
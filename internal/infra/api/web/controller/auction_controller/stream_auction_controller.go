@@ -0,0 +1,131 @@
+package auction_controller
+
+import (
+	"fmt"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+// StreamAuctionEvents envia os eventos do leilão por Server-Sent Events ou,
+// com o cabeçalho Upgrade: websocket, como mensagens JSON de um WebSocket. O
+// último evento recebido vem de Last-Event-ID ou de last_event_id, já que o
+// WebSocket do navegador não envia cabeçalhos.
+func (u *AuctionController) StreamAuctionEvents(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Query("last_event_id")
+	}
+
+	stream, err := u.auctionUseCase.SubscribeAuctionEvents(c.Request.Context(), auctionId, lastEventId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	defer stream.Close()
+
+	if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		u.streamWebSocket(c, stream)
+		return
+	}
+
+	u.streamServerSentEvents(c, stream)
+}
+
+func (u *AuctionController) streamServerSentEvents(c *gin.Context, stream *auction_usecase.AuctionEventStream) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// O heartbeat mantém a conexão aberta em proxies que encerram conexões ociosas
+	heartbeat := time.NewTicker(u.streamHeartbeat)
+	defer heartbeat.Stop()
+
+	// Quando o stream fecha, o EventSource reconecta com o Last-Event-ID
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-stream.Events:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{Id: event.Id, Event: string(event.Type), Data: event})
+			return true
+
+		case <-heartbeat.C:
+			io.WriteString(w, ": heartbeat\n\n")
+			return true
+
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func (u *AuctionController) streamWebSocket(c *gin.Context, stream *auction_usecase.AuctionEventStream) {
+	server := websocket.Server{
+		// Uma origem recusada recebe 403 antes do upgrade
+		Handshake: u.checkStreamOrigin,
+		Handler: func(conn *websocket.Conn) {
+			// Mensagens do cliente são ignoradas; a leitura só detecta o fechamento
+			go func() {
+				io.Copy(io.Discard, conn)
+				stream.Close()
+			}()
+
+			for event := range stream.Events {
+				if err := websocket.JSON.Send(conn, event); err != nil {
+					return
+				}
+			}
+		},
+	}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkStreamOrigin aceita o WebSocket aberto pela própria API ou por uma das
+// origens configuradas. Clientes fora do navegador não enviam Origin e, sem
+// os cookies de outro site, não precisam da verificação.
+func (u *AuctionController) checkStreamOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil || origin == nil {
+		return err
+	}
+	config.Origin = origin
+
+	if strings.EqualFold(origin.Host, req.Host) {
+		return nil
+	}
+
+	for _, allowed := range u.streamOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin.Scheme+"://"+origin.Host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
@@ -0,0 +1,191 @@
+package auction_controller_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
+	"fullcycle-auction_go/internal/infra/stream"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+)
+
+const streamAuctionId = "9b2f3c1e-5d4a-4f6b-8c7d-0e1f2a3b4c5d"
+
+type fakeAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+}
+
+func (fakeAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	return &auction_entity.Auction{Id: id, Status: auction_entity.Active, CurrentPrice: 30}, nil
+}
+
+// newStreamServer serve o stream com o broker em memória e um heartbeat curto.
+func newStreamServer(t *testing.T, origins ...string) (*httptest.Server, *stream.AuctionBroker) {
+	gin.SetMode(gin.TestMode)
+	broker := stream.NewAuctionBroker(10, 10)
+	auctionUC := auction_usecase.NewAuctionUseCase(fakeAuctionRepository{}, nil, nil, nil, nil, broker)
+	controller := auction_controller.NewAuctionController(auctionUC, 20*time.Millisecond, origins)
+
+	router := gin.New()
+	router.GET("/auctions/:auctionId/stream", controller.StreamAuctionEvents)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, broker
+}
+
+func publish(broker *stream.AuctionBroker, eventType auction_entity.AuctionEventType) {
+	broker.Publish(auction_entity.NewAuctionEvent(eventType, auction_entity.Auction{Id: streamAuctionId}, nil))
+}
+
+// readBlock lê um bloco SSE: as linhas até a linha em branco.
+func readBlock(t *testing.T, reader *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if !assert.Nil(t, err) {
+			return lines
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+// readEvent pula os heartbeats e devolve os campos do próximo evento.
+func readEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	for {
+		block := readBlock(t, reader)
+		if len(block) == 1 && block[0] == ": heartbeat" {
+			continue
+		}
+
+		fields := make(map[string]string)
+		for _, line := range block {
+			key, value, _ := strings.Cut(line, ":")
+			fields[key] = strings.TrimSpace(value)
+		}
+		return fields
+	}
+}
+
+func openServerSentEvents(t *testing.T, server *httptest.Server, lastEventId string) (*http.Response, *bufio.Reader) {
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/auctions/"+streamAuctionId+"/stream", nil)
+	if lastEventId != "" {
+		request.Header.Set("Last-Event-ID", lastEventId)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response, bufio.NewReader(response.Body)
+}
+
+func TestStreamAuctionEvents_ServerSentEventsResumeFromLastEventId(t *testing.T) {
+	server, broker := newStreamServer(t)
+
+	response, reader := openServerSentEvents(t, server, "")
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	snapshot := readEvent(t, reader)
+	assert.Equal(t, "snapshot", snapshot["event"])
+	var output auction_usecase.AuctionEventOutputDTO
+	assert.Nil(t, json.Unmarshal([]byte(snapshot["data"]), &output))
+	assert.Equal(t, 30.0, output.Auction.CurrentPrice)
+
+	publish(broker, auction_entity.EventBidAccepted)
+	accepted := readEvent(t, reader)
+	assert.Equal(t, "bid_accepted", accepted["event"])
+	assert.NotEqual(t, snapshot["id"], accepted["id"])
+
+	// Sem eventos, a conexão recebe só o comentário de heartbeat
+	assert.Equal(t, []string{": heartbeat"}, readBlock(t, reader))
+
+	// O evento publicado com a conexão caída chega na retomada, sem snapshot
+	response.Body.Close()
+	publish(broker, auction_entity.EventPriceChanged)
+
+	_, reader = openServerSentEvents(t, server, accepted["id"])
+	missed := readEvent(t, reader)
+	assert.Equal(t, "price_changed", missed["event"])
+
+	// Um id desconhecido recomeça pelo snapshot
+	_, reader = openServerSentEvents(t, server, "other-epoch-1")
+	assert.Equal(t, "snapshot", readEvent(t, reader)["event"])
+}
+
+func dialWebSocket(server *httptest.Server, origin, lastEventId string) (*websocket.Conn, error) {
+	location := "ws" + strings.TrimPrefix(server.URL, "http") + "/auctions/" + streamAuctionId + "/stream"
+	if lastEventId != "" {
+		location += "?last_event_id=" + lastEventId
+	}
+	return websocket.Dial(location, "", origin)
+}
+
+func receive(t *testing.T, conn *websocket.Conn) auction_usecase.AuctionEventOutputDTO {
+	var event auction_usecase.AuctionEventOutputDTO
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	assert.Nil(t, websocket.JSON.Receive(conn, &event))
+	return event
+}
+
+func TestStreamAuctionEvents_WebSocketResumesFromLastEventId(t *testing.T) {
+	server, broker := newStreamServer(t)
+
+	conn, err := dialWebSocket(server, server.URL, "")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, auction_entity.EventSnapshot, receive(t, conn).Type)
+
+	publish(broker, auction_entity.EventBidAccepted)
+	accepted := receive(t, conn)
+	assert.Equal(t, auction_entity.EventBidAccepted, accepted.Type)
+	assert.Equal(t, streamAuctionId, accepted.AuctionId)
+	conn.Close()
+
+	publish(broker, auction_entity.EventPriceChanged)
+	conn, err = dialWebSocket(server, server.URL, accepted.Id)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+	assert.Equal(t, auction_entity.EventPriceChanged, receive(t, conn).Type)
+}
+
+func TestStreamAuctionEvents_WebSocketChecksTheOrigin(t *testing.T) {
+	server, _ := newStreamServer(t, "https://app.example.com")
+
+	// Outro site não abre o stream no navegador de quem o visita
+	_, err := dialWebSocket(server, "https://evil.example.com", "")
+	assert.NotNil(t, err)
+
+	for _, origin := range []string{server.URL, "https://app.example.com"} {
+		conn, err := dialWebSocket(server, origin, "")
+		if assert.Nil(t, err, origin) {
+			assert.Equal(t, auction_entity.EventSnapshot, receive(t, conn).Type)
+			conn.Close()
+		}
+	}
+
+	server, _ = newStreamServer(t, "*")
+	conn, err := dialWebSocket(server, "https://evil.example.com", "")
+	if assert.Nil(t, err) {
+		conn.Close()
+	}
+}
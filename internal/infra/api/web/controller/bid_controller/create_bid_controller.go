@@ -3,10 +3,12 @@ package bid_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 )
 
 type BidController struct {
@@ -29,13 +31,27 @@ func (u *BidController) CreateBid(c *gin.Context) {
 		return
 	}
 
-	err := u.bidUseCase.CreateBid(context.Background(), bidInputDTO)
+	// O licitante é sempre o usuário autenticado
+	authenticatedUserId := middleware.AuthenticatedUserId(c)
+	if bidInputDTO.UserId != "" && bidInputDTO.UserId != authenticatedUserId {
+		restErr := rest_err.NewForbiddenError("user_id does not match the authenticated user")
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+	bidInputDTO.UserId = authenticatedUserId
+
+	bidStatus, err := u.bidUseCase.CreateBid(context.Background(), bidInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 
+		if restErr.Code == http.StatusTooManyRequests || restErr.Code == http.StatusServiceUnavailable {
+			c.Header("Retry-After", strconv.Itoa(u.bidUseCase.QueueStats().RetryAfterSeconds))
+		}
+
 		c.JSON(restErr.Code, restErr)
 		return
 	}
 
-	c.Status(http.StatusCreated)
+	c.JSON(http.StatusAccepted, bidStatus)
 }
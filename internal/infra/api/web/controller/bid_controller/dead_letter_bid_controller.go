@@ -0,0 +1,67 @@
+package bid_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (u *BidController) FindDeadLetters(c *gin.Context) {
+	deadLetters, err := u.bidUseCase.FindDeadLetters(context.Background())
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetters)
+}
+
+func (u *BidController) ReplayDeadLetter(c *gin.Context) {
+	bidId, ok := validateBidId(c)
+	if !ok {
+		return
+	}
+
+	if err := u.bidUseCase.ReplayDeadLetter(context.Background(), bidId); err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *BidController) DiscardDeadLetter(c *gin.Context) {
+	bidId, ok := validateBidId(c)
+	if !ok {
+		return
+	}
+
+	if err := u.bidUseCase.DiscardDeadLetter(context.Background(), bidId); err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func validateBidId(c *gin.Context) (string, bool) {
+	bidId := c.Param("bidId")
+
+	if err := uuid.Validate(bidId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "bidId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return bidId, true
+}
@@ -3,11 +3,19 @@ package bid_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
 )
 
+type AuctionBidListQuery struct {
+	Page  int64              `form:"page,default=1" binding:"min=1"`
+	Limit int64              `form:"limit,default=20" binding:"min=1,max=100"`
+	Sort  bid_entity.BidSort `form:"sort,default=newest" binding:"oneof=newest highest"`
+}
+
 func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
@@ -21,7 +29,16 @@ func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 		return
 	}
 
-	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(context.Background(), auctionId)
+	var query AuctionBidListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(
+		context.Background(), auctionId, query.Sort, query.Page, query.Limit)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -30,3 +47,23 @@ func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, bidOutputList)
 }
+
+func (u *BidController) FindBidStatus(c *gin.Context) {
+	bidId, ok := validateBidId(c)
+	if !ok {
+		return
+	}
+
+	bidStatus, err := u.bidUseCase.FindBidStatus(context.Background(), bidId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, bidStatus)
+}
+
+func (u *BidController) FindQueueStats(c *gin.Context) {
+	c.JSON(http.StatusOK, u.bidUseCase.QueueStats())
+}
@@ -0,0 +1,76 @@
+package bid_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UserBidListQuery struct {
+	Page    int64                 `form:"page,default=1" binding:"min=1"`
+	Limit   int64                 `form:"limit,default=20" binding:"min=1,max=100"`
+	Outcome bid_entity.BidOutcome `form:"status" binding:"omitempty,oneof=active outbid won lost cancelled voided"`
+}
+
+func (u *BidController) FindBidsByUserId(c *gin.Context) {
+	userId, query, ok := bindUserBidQuery(c)
+	if !ok {
+		return
+	}
+
+	userBids, err := u.bidUseCase.FindBidsByUserId(
+		context.Background(), userId, query.Outcome, query.Page, query.Limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, userBids)
+}
+
+func (u *BidController) FindWinsByUserId(c *gin.Context) {
+	userId, query, ok := bindUserBidQuery(c)
+	if !ok {
+		return
+	}
+
+	userWins, err := u.bidUseCase.FindWinsByUserId(
+		context.Background(), userId, query.Page, query.Limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, userWins)
+}
+
+func bindUserBidQuery(c *gin.Context) (string, UserBidListQuery, bool) {
+	var query UserBidListQuery
+
+	userId := c.Param("userId")
+	if err := uuid.Validate(userId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "userId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", query, false
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return "", query, false
+	}
+
+	return userId, query, true
+}
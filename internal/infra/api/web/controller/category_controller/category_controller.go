@@ -0,0 +1,126 @@
+package category_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CategoryController struct {
+	categoryUseCase category_usecase.CategoryUseCaseInterface
+}
+
+func NewCategoryController(
+	categoryUseCase category_usecase.CategoryUseCaseInterface) *CategoryController {
+	return &CategoryController{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+// FindCategories devolve a árvore das categorias ativas.
+func (u *CategoryController) FindCategories(c *gin.Context) {
+	u.findCategories(c, false)
+}
+
+// FindAllCategories devolve a árvore completa, com as categorias inativas.
+func (u *CategoryController) FindAllCategories(c *gin.Context) {
+	u.findCategories(c, true)
+}
+
+func (u *CategoryController) CreateCategory(c *gin.Context) {
+	var categoryInputDTO category_usecase.CategoryInputDTO
+	if err := c.ShouldBindJSON(&categoryInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	category, err := u.categoryUseCase.CreateCategory(
+		context.Background(), middleware.AuthenticatedUserId(c), categoryInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+func (u *CategoryController) UpdateCategory(c *gin.Context) {
+	categoryId, ok := validateCategoryId(c)
+	if !ok {
+		return
+	}
+
+	var categoryUpdateInputDTO category_usecase.CategoryUpdateInputDTO
+	if err := c.ShouldBindJSON(&categoryUpdateInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	category, err := u.categoryUseCase.UpdateCategory(
+		context.Background(), middleware.AuthenticatedUserId(c), categoryId, categoryUpdateInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func (u *CategoryController) DeleteCategory(c *gin.Context) {
+	categoryId, ok := validateCategoryId(c)
+	if !ok {
+		return
+	}
+
+	err := u.categoryUseCase.DeleteCategory(
+		context.Background(), middleware.AuthenticatedUserId(c), categoryId)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *CategoryController) findCategories(c *gin.Context, includeInactive bool) {
+	categories, err := u.categoryUseCase.FindCategories(context.Background(), includeInactive)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+func validateCategoryId(c *gin.Context) (string, bool) {
+	categoryId := c.Param("categoryId")
+	if err := uuid.Validate(categoryId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "categoryId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return categoryId, true
+}
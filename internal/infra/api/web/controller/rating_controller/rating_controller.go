@@ -0,0 +1,94 @@
+package rating_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/rating_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RatingController struct {
+	ratingUseCase rating_usecase.RatingUseCaseInterface
+}
+
+type RatingListQuery struct {
+	Page  int64 `form:"page,default=1" binding:"min=1"`
+	Limit int64 `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+func NewRatingController(ratingUseCase rating_usecase.RatingUseCaseInterface) *RatingController {
+	return &RatingController{
+		ratingUseCase: ratingUseCase,
+	}
+}
+
+// RateAuction registra a avaliação do usuário autenticado sobre a outra parte da venda.
+func (u *RatingController) RateAuction(c *gin.Context) {
+	auctionId, ok := validateId(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	var ratingInputDTO rating_usecase.RatingInputDTO
+	if err := c.ShouldBindJSON(&ratingInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	rating, err := u.ratingUseCase.RateAuction(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), ratingInputDTO)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rating)
+}
+
+func (u *RatingController) FindRatingsByUserId(c *gin.Context) {
+	userId, ok := validateId(c, "userId")
+	if !ok {
+		return
+	}
+
+	var query RatingListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	ratings, err := u.ratingUseCase.FindRatingsByUserId(
+		context.Background(), userId, query.Page, query.Limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, ratings)
+}
+
+func validateId(c *gin.Context, param string) (string, bool) {
+	id := c.Param(param)
+	if err := uuid.Validate(id); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   param,
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return id, true
+}
@@ -0,0 +1,80 @@
+package restriction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/restriction_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RestrictionController struct {
+	restrictionUseCase restriction_usecase.RestrictionUseCaseInterface
+}
+
+func NewRestrictionController(
+	restrictionUseCase restriction_usecase.RestrictionUseCaseInterface) *RestrictionController {
+	return &RestrictionController{
+		restrictionUseCase: restrictionUseCase,
+	}
+}
+
+// FindBlockedBidders lista os licitantes bloqueados pelo vendedor autenticado.
+func (u *RestrictionController) FindBlockedBidders(c *gin.Context) {
+	blocks, err := u.restrictionUseCase.FindBlockedBidders(
+		context.Background(), middleware.AuthenticatedUserId(c))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
+
+func (u *RestrictionController) BlockBidder(c *gin.Context) {
+	var blockInputDTO restriction_usecase.BlockInputDTO
+	if err := c.ShouldBindJSON(&blockInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	err := u.restrictionUseCase.BlockBidder(
+		context.Background(), middleware.AuthenticatedUserId(c), blockInputDTO)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *RestrictionController) UnblockBidder(c *gin.Context) {
+	bidderId := c.Param("userId")
+	if err := uuid.Validate(bidderId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "userId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	err := u.restrictionUseCase.UnblockBidder(
+		context.Background(), middleware.AuthenticatedUserId(c), bidderId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -3,9 +3,9 @@ package user_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/user_usecase"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"net/http"
 )
 
@@ -20,15 +20,8 @@ func NewUserController(userUseCase user_usecase.UserUseCaseInterface) *UserContr
 }
 
 func (u *UserController) FindUserById(c *gin.Context) {
-	userId := c.Param("userId")
-
-	if err := uuid.Validate(userId); err != nil {
-		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
-			Field:   "userId",
-			Message: "Invalid UUID value",
-		})
-
-		c.JSON(errRest.Code, errRest)
+	userId, ok := validateUserId(c)
+	if !ok {
 		return
 	}
 
@@ -41,3 +34,28 @@ func (u *UserController) FindUserById(c *gin.Context) {
 
 	c.JSON(http.StatusOK, userData)
 }
+
+type UserListQuery struct {
+	Page  int64 `form:"page,default=1" binding:"min=1"`
+	Limit int64 `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+func (u *UserController) FindUsers(c *gin.Context) {
+	var query UserListQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	users, err := u.userUseCase.FindUsers(context.Background(), query.Page, query.Limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
@@ -0,0 +1,89 @@
+package wallet_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/wallet_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalletController struct {
+	walletUseCase wallet_usecase.WalletUseCaseInterface
+}
+
+type LedgerQuery struct {
+	Page  int64 `form:"page,default=1" binding:"min=1"`
+	Limit int64 `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+func NewWalletController(walletUseCase wallet_usecase.WalletUseCaseInterface) *WalletController {
+	return &WalletController{
+		walletUseCase: walletUseCase,
+	}
+}
+
+// FindWallet devolve a carteira do usuário autenticado.
+func (u *WalletController) FindWallet(c *gin.Context) {
+	wallet, err := u.walletUseCase.FindWallet(context.Background(), middleware.AuthenticatedUserId(c))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+func (u *WalletController) Deposit(c *gin.Context) {
+	u.move(c, u.walletUseCase.Deposit)
+}
+
+func (u *WalletController) Withdraw(c *gin.Context) {
+	u.move(c, u.walletUseCase.Withdraw)
+}
+
+func (u *WalletController) FindLedger(c *gin.Context) {
+	var query LedgerQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	ledger, err := u.walletUseCase.FindLedger(
+		context.Background(), middleware.AuthenticatedUserId(c), query.Page, query.Limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, ledger)
+}
+
+func (u *WalletController) move(
+	c *gin.Context,
+	operation func(context.Context, string, wallet_usecase.WalletAmountInputDTO) (*wallet_usecase.WalletOutputDTO, *internal_error.InternalError)) {
+	var input wallet_usecase.WalletAmountInputDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	wallet, err := operation(context.Background(), middleware.AuthenticatedUserId(c), input)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
@@ -0,0 +1,103 @@
+package watchlist_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/watchlist_usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WatchlistController struct {
+	watchlistUseCase watchlist_usecase.WatchlistUseCaseInterface
+}
+
+func NewWatchlistController(
+	watchlistUseCase watchlist_usecase.WatchlistUseCaseInterface) *WatchlistController {
+	return &WatchlistController{
+		watchlistUseCase: watchlistUseCase,
+	}
+}
+
+type WatchlistQuery struct {
+	Page  int64 `form:"page,default=1" binding:"min=1"`
+	Limit int64 `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+func (u *WatchlistController) WatchAuction(c *gin.Context) {
+	auctionId, ok := validateId(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	err := u.watchlistUseCase.WatchAuction(
+		context.Background(), middleware.AuthenticatedUserId(c), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *WatchlistController) UnwatchAuction(c *gin.Context) {
+	auctionId, ok := validateId(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	err := u.watchlistUseCase.UnwatchAuction(
+		context.Background(), middleware.AuthenticatedUserId(c), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *WatchlistController) FindWatchlist(c *gin.Context) {
+	userId, ok := validateId(c, "userId")
+	if !ok {
+		return
+	}
+
+	var query WatchlistQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	watchlist, err := u.watchlistUseCase.FindWatchlist(
+		context.Background(), middleware.AuthenticatedUserId(c), userId, query.Page, query.Limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlist)
+}
+
+func validateId(c *gin.Context, param string) (string, bool) {
+	id := c.Param(param)
+	if err := uuid.Validate(id); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   param,
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return id, true
+}
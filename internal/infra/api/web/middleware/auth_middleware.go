@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/auth"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const authenticatedUserIdKey = "authenticatedUserId"
+
+// Authenticate exige um bearer token válido e guarda o usuário autenticado no contexto.
+func Authenticate(tokenService *auth.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			abortUnauthorized(c, "Missing bearer token", internal_error.CodeMissingToken)
+			return
+		}
+
+		claims, err := tokenService.ParseToken(strings.TrimSpace(token))
+		if err != nil {
+			if errors.Is(err, auth.ErrExpiredToken) {
+				abortUnauthorized(c, "Bearer token is expired", internal_error.CodeExpiredToken)
+				return
+			}
+			abortUnauthorized(c, "Invalid bearer token", internal_error.CodeInvalidToken)
+			return
+		}
+
+		c.Set(authenticatedUserIdKey, claims.Subject)
+		c.Next()
+	}
+}
+
+// AuthenticatedUserId devolve o id do usuário autenticado pelo middleware Authenticate.
+func AuthenticatedUserId(c *gin.Context) string {
+	return c.GetString(authenticatedUserIdKey)
+}
+
+func abortUnauthorized(c *gin.Context, message, code string) {
+	restErr := rest_err.NewUnauthorizedError(message).WithCode(code)
+
+	c.Header("WWW-Authenticate", `Bearer realm="auction"`)
+	c.AbortWithStatusJSON(restErr.Code, restErr)
+}
@@ -0,0 +1,121 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/auth"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// fakeUserRepository devolve os usuários informados
+type fakeUserRepository struct {
+	user_entity.UserRepositoryInterface
+	users map[string]user_entity.User
+}
+
+func (f *fakeUserRepository) FindUserById(ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	user, ok := f.users[userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("User not found")
+	}
+	return &user, nil
+}
+
+func newRouter(tokenService *auth.TokenService, handlers ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handlers = append([]gin.HandlerFunc{middleware.Authenticate(tokenService)}, handlers...)
+	handlers = append(handlers, func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.AuthenticatedUserId(c))
+	})
+	router.GET("/protected", handlers...)
+	return router
+}
+
+func request(router *gin.Engine, authorization string) (*httptest.ResponseRecorder, rest_err.RestErr) {
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var restErr rest_err.RestErr
+	if recorder.Code != http.StatusOK {
+		json.Unmarshal(recorder.Body.Bytes(), &restErr)
+	}
+	return recorder, restErr
+}
+
+func TestAuthenticate_AcceptsValidToken(t *testing.T) {
+	tokenService := auth.NewTokenService("secret", time.Hour)
+	token, _ := tokenService.NewToken("user-1")
+
+	recorder, _ := request(newRouter(tokenService), "Bearer "+token)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "user-1", recorder.Body.String())
+}
+
+func TestAuthenticate_RejectsMissingInvalidAndExpiredTokens(t *testing.T) {
+	tokenService := auth.NewTokenService("secret", time.Hour)
+	foreignToken, _ := auth.NewTokenService("other-secret", time.Hour).NewToken("user-1")
+	expiredToken, _ := auth.NewTokenService("secret", -time.Minute).NewToken("user-1")
+
+	for authorization, code := range map[string]string{
+		"":                       internal_error.CodeMissingToken,
+		"Bearer ":                internal_error.CodeMissingToken,
+		"Basic dXNlcjpwYXNz":     internal_error.CodeMissingToken,
+		"Bearer garbage":         internal_error.CodeInvalidToken,
+		"Bearer " + foreignToken: internal_error.CodeInvalidToken,
+		"Bearer " + expiredToken: internal_error.CodeExpiredToken,
+	} {
+		recorder, restErr := request(newRouter(tokenService), authorization)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, authorization)
+		assert.Equal(t, code, restErr.ErrorCode, authorization)
+		assert.Equal(t, `Bearer realm="auction"`, recorder.Header().Get("WWW-Authenticate"))
+	}
+}
+
+func TestRequireRole_ChecksStatusAndRoles(t *testing.T) {
+	tokenService := auth.NewTokenService("secret", time.Hour)
+	users := &fakeUserRepository{users: map[string]user_entity.User{
+		"seller":    {Id: "seller", Status: user_entity.Active, Roles: []user_entity.Role{user_entity.RoleSeller}},
+		"bidder":    {Id: "bidder", Status: user_entity.Active, Roles: []user_entity.Role{user_entity.RoleBidder}},
+		"suspended": {Id: "suspended", Status: user_entity.Suspended, Roles: []user_entity.Role{user_entity.RoleSeller}},
+	}}
+	router := newRouter(tokenService, middleware.RequireRole(users, user_entity.RoleSeller, user_entity.RoleAdmin))
+
+	token := func(userId string) string {
+		token, _ := tokenService.NewToken(userId)
+		return "Bearer " + token
+	}
+
+	recorder, _ := request(router, token("seller"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder, restErr := request(router, token("bidder"))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, internal_error.CodeMissingRole, restErr.ErrorCode)
+	assert.Equal(t, "This operation requires one of the roles: seller, admin", restErr.Message)
+
+	recorder, restErr = request(router, token("suspended"))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, internal_error.CodeAccountInactive, restErr.ErrorCode)
+
+	// Um token válido de um usuário que não existe mais não autentica
+	recorder, _ = request(router, token("deleted"))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RejectBanned recusa as requisições de usuários banidos da plataforma.
+// Deve ser usado após Authenticate.
+func RejectBanned(restrictionRepository restriction_entity.RestrictionRepositoryInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ban, err := restrictionRepository.FindActiveBan(context.Background(), AuthenticatedUserId(c))
+		if err != nil {
+			restErr := rest_err.ConvertError(err)
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		if ban != nil {
+			restErr := rest_err.ConvertError(ban.Error())
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		c.Next()
+	}
+}
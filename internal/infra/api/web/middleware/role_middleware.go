@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const authenticatedUserKey = "authenticatedUser"
+
+// RequireRole carrega o usuário autenticado e exige que ele esteja ativo e
+// tenha ao menos um dos papéis informados. Deve ser usado após Authenticate.
+func RequireRole(
+	userRepository user_entity.UserRepositoryInterface, roles ...user_entity.Role) gin.HandlerFunc {
+	allowed := make([]string, 0, len(roles))
+	for _, role := range roles {
+		allowed = append(allowed, string(role))
+	}
+
+	return func(c *gin.Context) {
+		user, err := userRepository.FindUserById(context.Background(), AuthenticatedUserId(c))
+		if err != nil {
+			if err.Err == "not_found" {
+				restErr := rest_err.NewUnauthorizedError("Authenticated user does not exist")
+				c.AbortWithStatusJSON(restErr.Code, restErr)
+				return
+			}
+
+			restErr := rest_err.ConvertError(err)
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		if user.Status != user_entity.Active {
+			restErr := rest_err.NewForbiddenError("User account is not active").
+				WithCode(internal_error.CodeAccountInactive)
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		for _, role := range roles {
+			if user.HasRole(role) {
+				c.Set(authenticatedUserKey, user)
+				c.Next()
+				return
+			}
+		}
+
+		restErr := rest_err.NewForbiddenError(
+			"This operation requires one of the roles: " + strings.Join(allowed, ", ")).
+			WithCode(internal_error.CodeMissingRole)
+		c.AbortWithStatusJSON(restErr.Code, restErr)
+	}
+}
+
+// AuthenticatedUser devolve o usuário carregado pelo middleware RequireRole.
+func AuthenticatedUser(c *gin.Context) *user_entity.User {
+	user, _ := c.Get(authenticatedUserKey)
+	authenticatedUser, _ := user.(*user_entity.User)
+	return authenticatedUser
+}
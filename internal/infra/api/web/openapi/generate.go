@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/route"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonContentType = "application/json"
+	bearerScheme    = "bearerAuth"
+)
+
+// Generate monta a especificação OpenAPI 3 a partir da tabela de rotas. Os
+// schemas vêm das tags json, form e binding dos DTOs de cada rota; os caminhos
+// antigos aparecem como operações depreciadas.
+func Generate(info Info, routes []route.Route) *Document {
+	registry := newSchemaRegistry()
+	errorSchema := registry.schemaFor(reflect.TypeOf(rest_err.RestErr{}))
+
+	document := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]map[string]*Operation{},
+	}
+
+	for _, r := range routes {
+		operation := newOperation(registry, r, errorSchema)
+		addOperation(document, r.VersionedPath(), r.Method, operation)
+
+		for _, legacy := range r.Legacy {
+			deprecated := *operation
+			deprecated.OperationId = ""
+			deprecated.Deprecated = true
+			addOperation(document, legacy, r.Method, &deprecated)
+		}
+	}
+
+	document.Components = Components{
+		Schemas: registry.schemas,
+		SecuritySchemes: map[string]SecurityScheme{
+			bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		},
+	}
+
+	return document
+}
+
+func newOperation(registry *schemaRegistry, r route.Route, errorSchema *Schema) *Operation {
+	operation := &Operation{
+		OperationId: r.OperationId,
+		Summary:     r.Summary,
+		Parameters:  pathParameters(r.Path),
+		Responses: map[string]Response{
+			"default": {
+				Description: "Erro",
+				Content:     map[string]MediaType{jsonContentType: {Schema: errorSchema}},
+			},
+		},
+	}
+
+	if r.Tag != "" {
+		operation.Tags = []string{r.Tag}
+	}
+
+	if r.Authenticated {
+		operation.Security = []map[string][]string{{bearerScheme: {}}}
+	}
+
+	if r.Query != nil {
+		operation.Parameters = append(operation.Parameters,
+			queryParameters(registry, reflect.TypeOf(r.Query))...)
+	}
+
+	if r.Body != nil {
+		operation.RequestBody = &RequestBody{
+			Required: !r.BodyOptional,
+			Content: map[string]MediaType{
+				jsonContentType: {Schema: registry.schemaFor(reflect.TypeOf(r.Body))},
+			},
+		}
+	}
+
+	status := r.StatusOrDefault()
+	response := Response{Description: http.StatusText(status)}
+	if r.Response != nil {
+		response.Content = map[string]MediaType{
+			r.ContentTypeOrDefault(): {Schema: registry.schemaFor(reflect.TypeOf(r.Response))},
+		}
+	}
+	operation.Responses[strconv.Itoa(status)] = response
+
+	return operation
+}
+
+func addOperation(document *Document, ginPath, method string, operation *Operation) {
+	openAPIPath := toOpenAPIPath(ginPath)
+	if document.Paths[openAPIPath] == nil {
+		document.Paths[openAPIPath] = map[string]*Operation{}
+	}
+	document.Paths[openAPIPath][strings.ToLower(method)] = operation
+}
+
+// toOpenAPIPath troca os parâmetros do gin (:id) pela sintaxe do OpenAPI ({id}).
+func toOpenAPIPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParameters documenta os parâmetros de caminho; os ids da API são UUIDs.
+func pathParameters(ginPath string) []Parameter {
+	var parameters []Parameter
+	for _, segment := range strings.Split(ginPath, "/") {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+
+		schema := &Schema{Type: "string"}
+		if strings.HasSuffix(name, "Id") {
+			schema.Format = "uuid"
+		}
+		parameters = append(parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return parameters
+}
+
+// queryParameters documenta os campos com tag form da struct de query.
+func queryParameters(registry *schemaRegistry, t reflect.Type) []Parameter {
+	t = indirect(t)
+
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		formTag := field.Tag.Get("form")
+		if !field.IsExported() || formTag == "" || formTag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(formTag, ",")
+		schema, required := registry.fieldSchema(field)
+		if defaultValue, ok := strings.CutPrefix(options, "default="); ok {
+			schema.Default = typedValue(schema, defaultValue)
+		}
+
+		parameters = append(parameters, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return parameters
+}
@@ -0,0 +1,136 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/infra/api/web/openapi"
+	"fullcycle-auction_go/internal/infra/api/web/route"
+)
+
+type itemInputDTO struct {
+	Name      string     `json:"name" binding:"required,min=2,max=100"`
+	Condition int        `json:"condition" binding:"oneof=1 2 3"`
+	Price     float64    `json:"price" binding:"required,gt=0"`
+	Tags      []string   `json:"tags" binding:"omitempty,dive,oneof=new used"`
+	Internal  string     `json:"-"`
+	Status    itemStatus `json:"status" binding:"oneof=1 2"`
+}
+
+type itemStatus int
+
+func (itemStatus) EnumValues() []string { return []string{"open", "closed"} }
+
+type itemOutputDTO struct {
+	Id        string         `json:"id"`
+	Parent    *itemOutputDTO `json:"parent"`
+	Price     *float64       `json:"price"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type itemListQuery struct {
+	Page  int64    `form:"page,default=1" binding:"min=1"`
+	Sort  string   `form:"sort,default=newest" binding:"oneof=newest oldest"`
+	Tags  []string `form:"tag"`
+	Query string   `form:"q" binding:"required"`
+}
+
+func generate() *openapi.Document {
+	return openapi.Generate(openapi.Info{Title: "Test", Version: "1"}, []route.Route{
+		{
+			Method: http.MethodGet, Path: "/items", Legacy: []string{"/item"},
+			OperationId: "listItems", Tag: "items",
+			Query: itemListQuery{}, Response: []itemOutputDTO{},
+		},
+		{
+			Method: http.MethodPost, Path: "/items/:itemId",
+			OperationId: "updateItem", Authenticated: true,
+			Body: itemInputDTO{}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/items/:itemId/stream",
+			OperationId: "streamItem", Response: itemOutputDTO{}, ContentType: "text/event-stream",
+		},
+	})
+}
+
+func TestGenerate_BuildsSchemasFromTags(t *testing.T) {
+	schemas := generate().Components.Schemas
+
+	input := schemas["itemInputDTO"]
+	assert.Equal(t, []string{"name", "price"}, input.Required)
+	assert.NotContains(t, input.Properties, "Internal")
+	assert.EqualValues(t, 2, *input.Properties["name"].MinLength)
+	assert.EqualValues(t, 100, *input.Properties["name"].MaxLength)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, input.Properties["condition"].Enum)
+	assert.EqualValues(t, 0, *input.Properties["price"].Minimum)
+	assert.True(t, input.Properties["price"].ExclusiveMinimum)
+	assert.Equal(t, []any{"new", "used"}, input.Properties["tags"].Items.Enum)
+	// Tipos com nomes fixos são documentados pelos nomes, não pelo oneof numérico
+	assert.Equal(t, "string", input.Properties["status"].Type)
+	assert.Equal(t, []any{"open", "closed"}, input.Properties["status"].Enum)
+
+	output := schemas["itemOutputDTO"]
+	assert.Equal(t, "date-time", output.Properties["created_at"].Format)
+	assert.True(t, output.Properties["price"].Nullable)
+	assert.True(t, output.Properties["parent"].Nullable)
+	assert.Equal(t, "#/components/schemas/itemOutputDTO", output.Properties["parent"].AllOf[0].Ref)
+
+	assert.Contains(t, schemas, "RestErr")
+	assert.Contains(t, schemas, "Causes")
+}
+
+func TestGenerate_DocumentsOperations(t *testing.T) {
+	document := generate()
+
+	list := document.Paths["/v1/items"]["get"]
+	assert.Equal(t, "listItems", list.OperationId)
+	assert.False(t, list.Deprecated)
+	assert.Equal(t, "array", list.Responses["200"].Content["application/json"].Schema.Type)
+	assert.Equal(t, "#/components/schemas/RestErr", list.Responses["default"].Content["application/json"].Schema.Ref)
+
+	parameters := map[string]openapi.Parameter{}
+	for _, parameter := range list.Parameters {
+		parameters[parameter.Name] = parameter
+	}
+	assert.EqualValues(t, 1, parameters["page"].Schema.Default)
+	assert.Equal(t, "newest", parameters["sort"].Schema.Default)
+	assert.Equal(t, []any{"newest", "oldest"}, parameters["sort"].Schema.Enum)
+	assert.Equal(t, "array", parameters["tag"].Schema.Type)
+	assert.True(t, parameters["q"].Required)
+
+	legacy := document.Paths["/item"]["get"]
+	assert.True(t, legacy.Deprecated)
+	assert.Empty(t, legacy.OperationId)
+
+	update := document.Paths["/v1/items/{itemId}"]["post"]
+	assert.Equal(t, "path", update.Parameters[0].In)
+	assert.Equal(t, "uuid", update.Parameters[0].Schema.Format)
+	assert.True(t, update.RequestBody.Required)
+	assert.Contains(t, update.Responses, "204")
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, update.Security)
+
+	stream := document.Paths["/v1/items/{itemId}/stream"]["get"]
+	assert.Contains(t, stream.Responses["200"].Content, "text/event-stream")
+	assert.NotContains(t, stream.Responses["200"].Content, "application/json")
+}
+
+func TestHandler_ServesDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/openapi.json", openapi.Handler(generate()))
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var document map[string]any
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &document))
+	assert.Equal(t, "3.0.3", document["openapi"])
+}
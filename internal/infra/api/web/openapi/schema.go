@@ -0,0 +1,272 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	enumsType = reflect.TypeOf((*enumer)(nil)).Elem()
+)
+
+// enumer é implementado pelos tipos que trafegam no JSON como um nome entre
+// valores fixos, como a condição e o status do leilão.
+type enumer interface {
+	EnumValues() []string
+}
+
+// schemaRegistry guarda os schemas nomeados em components.schemas, um por tipo Go.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// schemaFor devolve o schema de um tipo; structs nomeadas viram referências.
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	switch {
+	case t.Kind() == reflect.Pointer:
+		return nullable(r.schemaFor(t.Elem()))
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(enumsType):
+		return enumSchema(reflect.Zero(t).Interface().(enumer))
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.objectSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + r.register(t)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	default:
+		return &Schema{}
+	}
+}
+
+// register gera o schema da struct uma única vez. Tipos de pacotes diferentes
+// com o mesmo nome são qualificados pelo pacote.
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := r.schemas[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+
+	// Reserva o nome antes de descer nos campos, para suportar tipos recursivos
+	r.names[t] = name
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.objectSchema(t)
+
+	return name
+}
+
+func (r *schemaRegistry) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Structs embutidas sem nome no json têm os campos promovidos
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			embedded := r.objectSchema(indirect(field.Type))
+			for propertyName, property := range embedded.Properties {
+				schema.Properties[propertyName] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property, required := r.fieldSchema(field)
+		schema.Properties[name] = property
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// fieldSchema aplica as regras de binding do campo ao schema do seu tipo.
+func (r *schemaRegistry) fieldSchema(field reflect.StructField) (*Schema, bool) {
+	schema := r.schemaFor(field.Type)
+	return applyBinding(schema, field.Tag.Get("binding"))
+}
+
+// applyBinding traduz as regras do validator para restrições do schema.
+// As regras após dive valem para os itens da lista.
+func applyBinding(schema *Schema, binding string) (*Schema, bool) {
+	required := false
+	target := schema
+
+	for _, rule := range strings.Split(binding, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		// Restrições não se aplicam a referências; só o required importa
+		if target.Ref != "" || target.AllOf != nil {
+			if name == "required" && target == schema {
+				required = true
+			}
+			continue
+		}
+
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil {
+				return schema, required
+			}
+			target = target.Items
+		case "min":
+			setLowerBound(target, value)
+		case "max":
+			setUpperBound(target, value)
+		case "gte":
+			target.Minimum = parseFloat(value)
+		case "gt":
+			target.Minimum = parseFloat(value)
+			target.ExclusiveMinimum = true
+		case "lte":
+			target.Maximum = parseFloat(value)
+		case "oneof":
+			// Tipos nomeados já documentam os seus valores pelo nome
+			if target.Enum != nil {
+				continue
+			}
+			for _, option := range strings.Fields(value) {
+				target.Enum = append(target.Enum, typedValue(target, option))
+			}
+		case "uuid", "email":
+			target.Format = name
+		}
+	}
+
+	return schema, required
+}
+
+func setLowerBound(schema *Schema, value string) {
+	switch schema.Type {
+	case "string":
+		schema.MinLength = parseInt(value)
+	case "array":
+		schema.MinItems = parseInt(value)
+	default:
+		schema.Minimum = parseFloat(value)
+	}
+}
+
+func setUpperBound(schema *Schema, value string) {
+	switch schema.Type {
+	case "string":
+		schema.MaxLength = parseInt(value)
+	case "array":
+		schema.MaxItems = parseInt(value)
+	default:
+		schema.Maximum = parseFloat(value)
+	}
+}
+
+// typedValue converte um valor textual da tag para o tipo do schema.
+func typedValue(schema *Schema, value string) any {
+	switch schema.Type {
+	case "integer":
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	case "number":
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	case "boolean":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+func enumSchema(enum enumer) *Schema {
+	schema := &Schema{Type: "string"}
+	for _, value := range enum.EnumValues() {
+		schema.Enum = append(schema.Enum, value)
+	}
+	return schema
+}
+
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+	schema.Nullable = true
+	return schema
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func parseInt(value string) *int64 {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func parseFloat(value string) *float64 {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
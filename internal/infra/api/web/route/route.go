@@ -0,0 +1,94 @@
+package route
+
+import (
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version é o prefixo das rotas atuais da API.
+const Version = "/v1"
+
+// Route descreve um endpoint da API: o caminho sob /v1, os caminhos antigos
+// mantidos como aliases e os tipos usados para gerar a especificação OpenAPI.
+type Route struct {
+	Method  string
+	Path    string
+	Legacy  []string
+	Handler gin.HandlerFunc
+
+	// Authenticated exige bearer token; Roles restringe aos papéis informados.
+	Authenticated bool
+	Roles         []user_entity.Role
+
+	OperationId string
+	Summary     string
+	Tag         string
+	Query       any
+	Body        any
+	Response    any
+	Status      int
+
+	// ContentType documenta respostas que não são JSON; Response descreve cada mensagem.
+	ContentType string
+
+	// BodyOptional documenta corpos que podem ser omitidos, como o motivo das ações administrativas.
+	BodyOptional bool
+}
+
+// VersionedPath devolve o caminho completo da rota sob /v1.
+func (r Route) VersionedPath() string {
+	return Version + r.Path
+}
+
+// Guard devolve os middlewares de autenticação e autorização de uma rota.
+type Guard func(Route) []gin.HandlerFunc
+
+// Register registra as rotas sob /v1 e os caminhos antigos como aliases depreciados.
+func Register(router gin.IRouter, routes []Route, guard Guard) {
+	for _, r := range routes {
+		handlers := append(guard(r), r.Handler)
+		router.Handle(r.Method, r.VersionedPath(), handlers...)
+
+		for _, legacy := range r.Legacy {
+			legacyHandlers := append([]gin.HandlerFunc{Deprecated(r.VersionedPath())}, handlers...)
+			router.Handle(r.Method, legacy, legacyHandlers...)
+		}
+	}
+}
+
+// Deprecated marca a resposta de um caminho antigo e aponta para o caminho
+// equivalente em /v1, com os parâmetros da requisição já preenchidos.
+func Deprecated(successorPath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		successor := successorPath
+		for _, param := range c.Params {
+			successor = strings.Replace(successor, ":"+param.Key, param.Value, 1)
+		}
+		if c.Request.URL.RawQuery != "" {
+			successor += "?" + c.Request.URL.RawQuery
+		}
+
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
+
+// StatusOrDefault devolve o status de sucesso documentado da rota.
+func (r Route) StatusOrDefault() int {
+	if r.Status != 0 {
+		return r.Status
+	}
+	return http.StatusOK
+}
+
+// ContentTypeOrDefault devolve o tipo de conteúdo documentado da resposta.
+func (r Route) ContentTypeOrDefault() string {
+	if r.ContentType != "" {
+		return r.ContentType
+	}
+	return "application/json"
+}
@@ -0,0 +1,49 @@
+package route_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/infra/api/web/route"
+)
+
+func TestRegister_ServesVersionedPathAndDeprecatedAlias(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	var guarded []string
+	route.Register(router, []route.Route{{
+		Method:        http.MethodGet,
+		Path:          "/auctions/:auctionId/winner",
+		Legacy:        []string{"/auction/winner/:auctionId"},
+		Authenticated: true,
+		Handler: func(c *gin.Context) {
+			c.String(http.StatusOK, c.Param("auctionId"))
+		},
+	}}, func(r route.Route) []gin.HandlerFunc {
+		return []gin.HandlerFunc{func(c *gin.Context) {
+			guarded = append(guarded, c.FullPath())
+			c.Next()
+		}}
+	})
+
+	current := httptest.NewRecorder()
+	router.ServeHTTP(current, httptest.NewRequest(http.MethodGet, "/v1/auctions/a1/winner", nil))
+	assert.Equal(t, http.StatusOK, current.Code)
+	assert.Equal(t, "a1", current.Body.String())
+	assert.Empty(t, current.Header().Get("Deprecation"))
+
+	legacy := httptest.NewRecorder()
+	router.ServeHTTP(legacy, httptest.NewRequest(http.MethodGet, "/auction/winner/a1?verbose=1", nil))
+	assert.Equal(t, http.StatusOK, legacy.Code)
+	assert.Equal(t, "a1", legacy.Body.String())
+	assert.Equal(t, "true", legacy.Header().Get("Deprecation"))
+	assert.Equal(t, `</v1/auctions/a1/winner?verbose=1>; rel="successor-version"`, legacy.Header().Get("Link"))
+
+	// O alias passa pelos mesmos middlewares da rota atual
+	assert.Equal(t, []string{"/v1/auctions/:auctionId/winner", "/auction/winner/:auctionId"}, guarded)
+}
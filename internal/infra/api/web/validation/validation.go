@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/internal_error"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
@@ -30,7 +31,10 @@ func ValidateErr(validation_err error) *rest_err.RestErr {
 	var jsonValidation validator.ValidationErrors
 
 	if errors.As(validation_err, &jsonErr) {
-		return rest_err.NewNotFoundError("Invalid type error")
+		return rest_err.NewBadRequestError("Invalid type error", rest_err.Causes{
+			Field:   jsonErr.Field,
+			Message: "Must be of type " + jsonErr.Type.String(),
+		}).WithCode(internal_error.CodeValidationFailed)
 	} else if errors.As(validation_err, &jsonValidation) {
 		errorCauses := []rest_err.Causes{}
 
@@ -41,7 +45,8 @@ func ValidateErr(validation_err error) *rest_err.RestErr {
 			})
 		}
 
-		return rest_err.NewBadRequestError("Invalid field values", errorCauses...)
+		return rest_err.NewBadRequestError("Invalid field values", errorCauses...).
+			WithCode(internal_error.CodeValidationFailed)
 	} else {
 		return rest_err.NewBadRequestError("Error trying to convert fields")
 	}
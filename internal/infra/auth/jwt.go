@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token is expired")
+)
+
+// Claims are the JWT claims understood by the API. Subject is the user id.
+type Claims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf,omitempty"`
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// TokenService signs and verifies HS256 JWTs with a locally configured key.
+type TokenService struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokenService(secret string, ttl time.Duration) *TokenService {
+	return &TokenService{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (ts *TokenService) NewToken(userId string) (string, error) {
+	now := ts.now()
+
+	return ts.sign(Claims{
+		Subject:   userId,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ts.ttl).Unix(),
+	})
+}
+
+func (ts *TokenService) ParseToken(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "HS256" {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, ts.signature(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	now := ts.now().Unix()
+	if claims.ExpiresAt == 0 || now >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+func (ts *TokenService) sign(claims Claims) (string, error) {
+	header, err := json.Marshal(tokenHeader{Algorithm: "HS256", Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(ts.signature(unsigned)), nil
+}
+
+func (ts *TokenService) signature(unsigned string) []byte {
+	mac := hmac.New(sha256.New, ts.secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, value interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, value)
+}
+
+func GetJWTSecret() string {
+	return os.Getenv("JWT_SECRET")
+}
+
+func GetJWTTTL() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("JWT_TTL"))
+	if err != nil || duration <= 0 {
+		return 24 * time.Hour
+	}
+	return duration
+}
@@ -0,0 +1,49 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/infra/auth"
+)
+
+func TestTokenService_RoundTrip(t *testing.T) {
+	tokenService := auth.NewTokenService("secret", time.Hour)
+
+	token, err := tokenService.NewToken("user-1")
+	assert.Nil(t, err)
+
+	claims, err := tokenService.ParseToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Greater(t, claims.ExpiresAt, claims.IssuedAt)
+}
+
+func TestTokenService_RejectsTamperedAndForeignTokens(t *testing.T) {
+	tokenService := auth.NewTokenService("secret", time.Hour)
+	token, _ := tokenService.NewToken("user-1")
+
+	parts := strings.Split(token, ".")
+	other, _ := tokenService.NewToken("user-2")
+	tampered := parts[0] + "." + strings.Split(other, ".")[1] + "." + parts[2]
+
+	_, err := tokenService.ParseToken(tampered)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	_, err = auth.NewTokenService("other-secret", time.Hour).ParseToken(token)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	_, err = tokenService.ParseToken("not-a-token")
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestTokenService_RejectsExpiredToken(t *testing.T) {
+	tokenService := auth.NewTokenService("secret", -time.Minute)
+	token, _ := tokenService.NewToken("user-1")
+
+	_, err := tokenService.ParseToken(token)
+	assert.ErrorIs(t, err, auth.ErrExpiredToken)
+}
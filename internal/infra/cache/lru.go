@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"container/list"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+)
+
+// LRU é um cache de tamanho limitado cujas entradas expiram após um TTL.
+// Remover uma chave avança um contador de geração, para que um valor carregado
+// junto com a remoção nunca seja guardado.
+type LRU[V any] struct {
+	mutex      sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	maxEntries int
+	ttl        time.Duration
+	generation uint64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type Stats struct {
+	Hits       uint64
+	Misses     uint64
+	Evictions  uint64
+	Size       int
+	MaxEntries int
+	TTL        time.Duration
+}
+
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+func NewLRU[V any](maxEntries int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: maxEntries,
+		ttl:        ttl,
+	}
+}
+
+// GetOrLoad devolve o valor em cache ou chama load e guarda o resultado.
+func (c *LRU[V]) GetOrLoad(
+	key string, load func() (V, *internal_error.InternalError)) (V, *internal_error.InternalError) {
+	c.mutex.Lock()
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry[V])
+		if time.Now().Before(entry.expiresAt) {
+			c.order.MoveToFront(element)
+			c.hits++
+			c.mutex.Unlock()
+			return entry.value, nil
+		}
+		c.removeElement(element)
+	}
+	c.misses++
+	generation := c.generation
+	c.mutex.Unlock()
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Uma invalidação durante a carga torna o valor potencialmente antigo
+	if generation != c.generation {
+		return value, nil
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{
+		key:       key,
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+
+	for c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+
+	return value, nil
+}
+
+func (c *LRU[V]) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+func (c *LRU[V]) Stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return Stats{
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Evictions:  c.evictions,
+		Size:       c.order.Len(),
+		MaxEntries: c.maxEntries,
+		TTL:        c.ttl,
+	}
+}
+
+func (c *LRU[V]) removeElement(element *list.Element) {
+	delete(c.entries, element.Value.(*lruEntry[V]).key)
+	c.order.Remove(element)
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AdminActionMongo struct {
+	Id         string                  `bson:"_id"`
+	AdminId    string                  `bson:"admin_id"`
+	Action     admin_entity.ActionType `bson:"action"`
+	TargetType admin_entity.TargetType `bson:"target_type"`
+	TargetId   string                  `bson:"target_id"`
+	Reason     string                  `bson:"reason,omitempty"`
+	CreatedAt  int64                   `bson:"created_at"`
+}
+
+type AdminActionRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewAdminActionRepository(database *mongo.Database) *AdminActionRepository {
+	return &AdminActionRepository{
+		Collection: database.Collection("admin_actions"),
+	}
+}
+
+func (ar *AdminActionRepository) SaveAdminAction(
+	ctx context.Context, adminAction admin_entity.AdminAction) *internal_error.InternalError {
+	adminActionMongo := &AdminActionMongo{
+		Id:         adminAction.Id,
+		AdminId:    adminAction.AdminId,
+		Action:     adminAction.Action,
+		TargetType: adminAction.TargetType,
+		TargetId:   adminAction.TargetId,
+		Reason:     adminAction.Reason,
+		CreatedAt:  adminAction.CreatedAt.Unix(),
+	}
+
+	if _, err := ar.Collection.InsertOne(ctx, adminActionMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to save admin action %s", adminAction.Action), err)
+		return internal_error.NewInternalServerError("Error trying to save admin action")
+	}
+
+	return nil
+}
+
+func (ar *AdminActionRepository) FindAdminActions(
+	ctx context.Context, limit int64) ([]admin_entity.AdminAction, *internal_error.InternalError) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := ar.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logger.Error("Error trying to find admin actions", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find admin actions")
+	}
+	defer cursor.Close(ctx)
+
+	var adminActionsMongo []AdminActionMongo
+	if err := cursor.All(ctx, &adminActionsMongo); err != nil {
+		logger.Error("Error trying to decode admin actions", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find admin actions")
+	}
+
+	adminActions := make([]admin_entity.AdminAction, 0, len(adminActionsMongo))
+	for _, adminActionMongo := range adminActionsMongo {
+		adminActions = append(adminActions, admin_entity.AdminAction{
+			Id:         adminActionMongo.Id,
+			AdminId:    adminActionMongo.AdminId,
+			Action:     adminActionMongo.Action,
+			TargetType: adminActionMongo.TargetType,
+			TargetId:   adminActionMongo.TargetId,
+			Reason:     adminActionMongo.Reason,
+			CreatedAt:  time.Unix(adminActionMongo.CreatedAt, 0),
+		})
+	}
+
+	return adminActions, nil
+}
@@ -0,0 +1,117 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/cache"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+)
+
+// AuctionCache is a read-through cache in front of an auction repository.
+// It is bounded (least recently used entries are evicted), entries expire
+// after a TTL and every write that goes through it invalidates the auction,
+// so the bid use case and the bid repository share a consistent view.
+type AuctionCache struct {
+	auction_entity.AuctionRepositoryInterface
+
+	auctions *cache.LRU[auction_entity.Auction]
+}
+
+func NewAuctionCache(
+	repository auction_entity.AuctionRepositoryInterface,
+	maxEntries int,
+	ttl time.Duration) *AuctionCache {
+	return &AuctionCache{
+		AuctionRepositoryInterface: repository,
+		auctions:                   cache.NewLRU[auction_entity.Auction](maxEntries, ttl),
+	}
+}
+
+func (ac *AuctionCache) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := ac.auctions.GetOrLoad(id, func() (auction_entity.Auction, *internal_error.InternalError) {
+		auction, err := ac.AuctionRepositoryInterface.FindAuctionById(ctx, id)
+		if err != nil {
+			return auction_entity.Auction{}, err
+		}
+		return *auction, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+func (ac *AuctionCache) UpdateAuctionStatus(
+	ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	defer ac.Invalidate(id)
+
+	return ac.AuctionRepositoryInterface.UpdateAuctionStatus(ctx, id, status)
+}
+
+func (ac *AuctionCache) IncrementWatcherCount(
+	ctx context.Context, id string, delta int64) *internal_error.InternalError {
+	defer ac.Invalidate(id)
+
+	return ac.AuctionRepositoryInterface.IncrementWatcherCount(ctx, id, delta)
+}
+
+func (ac *AuctionCache) UpdateAuction(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	defer ac.Invalidate(auctionEntity.Id)
+
+	return ac.AuctionRepositoryInterface.UpdateAuction(ctx, auctionEntity)
+}
+
+func (ac *AuctionCache) UpdateBidState(
+	ctx context.Context, id string, state auction_entity.AuctionBidState) *internal_error.InternalError {
+	defer ac.Invalidate(id)
+
+	return ac.AuctionRepositoryInterface.UpdateBidState(ctx, id, state)
+}
+
+func (ac *AuctionCache) ReopenAuction(
+	ctx context.Context, id string, timestamp time.Time) *internal_error.InternalError {
+	defer ac.Invalidate(id)
+
+	return ac.AuctionRepositoryInterface.ReopenAuction(ctx, id, timestamp)
+}
+
+// Invalidate removes the auction from the cache. It must be called whenever
+// the auction status, end time or cancellation changes outside the cache.
+func (ac *AuctionCache) Invalidate(id string) {
+	ac.auctions.Delete(id)
+}
+
+func (ac *AuctionCache) Stats() auction_entity.AuctionCacheStats {
+	stats := ac.auctions.Stats()
+
+	return auction_entity.AuctionCacheStats{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		Evictions:  stats.Evictions,
+		Size:       stats.Size,
+		MaxEntries: stats.MaxEntries,
+		TTL:        stats.TTL,
+	}
+}
+
+func GetAuctionCacheMaxEntries() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_CACHE_MAX_ENTRIES"))
+	if err != nil || value <= 0 {
+		return 10000
+	}
+	return value
+}
+
+func GetAuctionCacheTTL() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("AUCTION_CACHE_TTL"))
+	if err != nil || duration <= 0 {
+		return 30 * time.Second
+	}
+	return duration
+}
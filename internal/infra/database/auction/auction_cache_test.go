@@ -0,0 +1,120 @@
+package auction_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity/auctiontest"
+	"fullcycle-auction_go/internal/infra/database/auction"
+)
+
+func TestAuctionCache_HitsAfterFirstLoad(t *testing.T) {
+	repo := new(auctiontest.MockAuctionRepository)
+	repo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{Id: "a1"}, nil).Once()
+
+	cache := auction.NewAuctionCache(repo, 10, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		auctionEntity, err := cache.FindAuctionById(context.Background(), "a1")
+		assert.Nil(t, err)
+		assert.Equal(t, "a1", auctionEntity.Id)
+	}
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	repo.AssertNumberOfCalls(t, "FindAuctionById", 1)
+}
+
+func TestAuctionCache_ExpiresAfterTTL(t *testing.T) {
+	repo := new(auctiontest.MockAuctionRepository)
+	repo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{Id: "a1"}, nil)
+
+	cache := auction.NewAuctionCache(repo, 10, 10*time.Millisecond)
+
+	cache.FindAuctionById(context.Background(), "a1")
+	time.Sleep(20 * time.Millisecond)
+	cache.FindAuctionById(context.Background(), "a1")
+
+	assert.Equal(t, uint64(2), cache.Stats().Misses)
+	repo.AssertNumberOfCalls(t, "FindAuctionById", 2)
+}
+
+func TestAuctionCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	repo := new(auctiontest.MockAuctionRepository)
+	for _, id := range []string{"a1", "a2", "a3"} {
+		repo.On("FindAuctionById", mock.Anything, id).Return(&auction_entity.Auction{Id: id}, nil)
+	}
+
+	cache := auction.NewAuctionCache(repo, 2, time.Minute)
+
+	cache.FindAuctionById(context.Background(), "a1")
+	cache.FindAuctionById(context.Background(), "a2")
+	cache.FindAuctionById(context.Background(), "a1") // a2 passa a ser o menos usado
+	cache.FindAuctionById(context.Background(), "a3")
+
+	stats := cache.Stats()
+	assert.Equal(t, 2, stats.Size)
+	assert.Equal(t, uint64(1), stats.Evictions)
+
+	cache.FindAuctionById(context.Background(), "a1")
+	repo.AssertNumberOfCalls(t, "FindAuctionById", 3)
+}
+
+func TestAuctionCache_StatusUpdateInvalidates(t *testing.T) {
+	repo := new(auctiontest.MockAuctionRepository)
+	repo.On("FindAuctionById", mock.Anything, "a1").
+		Return(&auction_entity.Auction{Id: "a1", Status: auction_entity.Active}, nil).Once()
+	repo.On("FindAuctionById", mock.Anything, "a1").
+		Return(&auction_entity.Auction{Id: "a1", Status: auction_entity.Completed}, nil).Once()
+	repo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Completed).Return(nil)
+
+	cache := auction.NewAuctionCache(repo, 10, time.Minute)
+
+	auctionEntity, _ := cache.FindAuctionById(context.Background(), "a1")
+	assert.Equal(t, auction_entity.Active, auctionEntity.Status)
+
+	assert.Nil(t, cache.UpdateAuctionStatus(context.Background(), "a1", auction_entity.Completed))
+
+	auctionEntity, _ = cache.FindAuctionById(context.Background(), "a1")
+	assert.Equal(t, auction_entity.Completed, auctionEntity.Status)
+}
+
+func TestAuctionCache_WatcherCountUpdateInvalidates(t *testing.T) {
+	repo := new(auctiontest.MockAuctionRepository)
+	repo.On("FindAuctionById", mock.Anything, "a1").
+		Return(&auction_entity.Auction{Id: "a1"}, nil).Once()
+	repo.On("FindAuctionById", mock.Anything, "a1").
+		Return(&auction_entity.Auction{Id: "a1", WatcherCount: 1}, nil).Once()
+	repo.On("IncrementWatcherCount", mock.Anything, "a1", int64(1)).Return(nil)
+
+	cache := auction.NewAuctionCache(repo, 10, time.Minute)
+
+	cache.FindAuctionById(context.Background(), "a1")
+	assert.Nil(t, cache.IncrementWatcherCount(context.Background(), "a1", 1))
+
+	auctionEntity, _ := cache.FindAuctionById(context.Background(), "a1")
+	assert.Equal(t, int64(1), auctionEntity.WatcherCount)
+}
+
+func TestAuctionCache_UpdateInvalidates(t *testing.T) {
+	repo := new(auctiontest.MockAuctionRepository)
+	repo.On("FindAuctionById", mock.Anything, "a1").
+		Return(&auction_entity.Auction{Id: "a1", ProductName: "Old"}, nil).Once()
+	repo.On("FindAuctionById", mock.Anything, "a1").
+		Return(&auction_entity.Auction{Id: "a1", ProductName: "New"}, nil).Once()
+	repo.On("UpdateAuction", mock.Anything, mock.Anything).Return(nil)
+
+	cache := auction.NewAuctionCache(repo, 10, time.Minute)
+
+	cache.FindAuctionById(context.Background(), "a1")
+	assert.Nil(t, cache.UpdateAuction(context.Background(), &auction_entity.Auction{Id: "a1"}))
+
+	auctionEntity, _ := cache.FindAuctionById(context.Background(), "a1")
+	assert.Equal(t, "New", auctionEntity.ProductName)
+}
@@ -0,0 +1,94 @@
+package auction
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// listSortKey é a ordenação de uma listagem: o campo ordenado e o _id como
+// desempate, para que a paginação por cursor seja estável. Cada ordenação
+// é coberta por um índice criado em CreateIndexes.
+type listSortKey struct {
+	field       string
+	direction   int
+	idDirection int
+}
+
+var listSortKeys = map[auction_entity.AuctionSort]listSortKey{
+	auction_entity.SortEndingSoon: {field: "timestamp", direction: 1, idDirection: 1},
+	auction_entity.SortNewest:     {field: "timestamp", direction: -1, idDirection: -1},
+	auction_entity.SortPriceDesc:  {field: "current_price", direction: -1, idDirection: 1},
+}
+
+// auctionCursor guarda a posição do último leilão da página. É devolvido ao
+// cliente como um token opaco em base64.
+type auctionCursor struct {
+	Sort  auction_entity.AuctionSort `json:"s"`
+	Value float64                    `json:"v"`
+	Id    string                     `json:"id"`
+}
+
+func (key listSortKey) sort() bson.D {
+	return bson.D{{Key: key.field, Value: key.direction}, {Key: "_id", Value: key.idDirection}}
+}
+
+// after filtra os leilões posicionados depois do cursor na ordenação.
+func (key listSortKey) after(cursor auctionCursor) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{key.field: bson.M{comparison(key.direction): key.value(cursor.Value)}},
+		bson.M{
+			key.field: key.value(cursor.Value),
+			"_id":     bson.M{comparison(key.idDirection): cursor.Id},
+		},
+	}}
+}
+
+func (key listSortKey) cursorAfter(
+	sort auction_entity.AuctionSort, auctionMongo AuctionEntityMongo) string {
+	cursor := auctionCursor{Sort: sort, Id: auctionMongo.Id}
+	if key.field == "timestamp" {
+		cursor.Value = float64(auctionMongo.Timestamp)
+	} else {
+		cursor.Value = auctionMongo.CurrentPrice
+	}
+
+	token, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// value converte o valor do cursor para o tipo gravado no campo.
+func (key listSortKey) value(value float64) interface{} {
+	if key.field == "timestamp" {
+		return int64(value)
+	}
+	return value
+}
+
+func comparison(direction int) string {
+	if direction < 0 {
+		return "$lt"
+	}
+	return "$gt"
+}
+
+func decodeAuctionCursor(
+	token string, sort auction_entity.AuctionSort) (auctionCursor, *internal_error.InternalError) {
+	var cursor auctionCursor
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || json.Unmarshal(decoded, &cursor) != nil || cursor.Id == "" {
+		return cursor, internal_error.NewBadRequestError("Invalid cursor").
+			WithCode(internal_error.CodeInvalidCursor)
+	}
+
+	if cursor.Sort != sort {
+		return cursor, internal_error.NewBadRequestError("Cursor does not match the requested sort").
+			WithCode(internal_error.CodeInvalidCursor)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,204 @@
+package auction_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+var baseTime = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+// findFilter busca pelo FindAuctions e devolve o filtro enviado ao Mongo.
+func findFilter(t *testing.T, query auction_entity.AuctionQuery) bson.M {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	var filter bson.M
+	mt.Run("find", func(mt *mtest.T) {
+		query.Sort = auction_entity.SortNewest
+		query.Limit = 100
+
+		var err *internal_error.InternalError
+		_, _, filter, err = findPage(mt, query)
+		assert.Nil(t, err)
+	})
+	return filter
+}
+
+// matches aplica ao valor a regex do campo do filtro, como o Mongo faria.
+func matches(filter bson.M, field, value string) bool {
+	regex := filter[field].(primitive.Regex)
+	return regexp.MustCompile("(?" + regex.Options + ")" + regex.Pattern).MatchString(value)
+}
+
+func price(value float64) *float64 {
+	return &value
+}
+
+func TestAuctionFilter_Status(t *testing.T) {
+	assert.Empty(t, findFilter(t, auction_entity.AuctionQuery{}))
+
+	// Active é o status 0 e precisa filtrar como os demais
+	assert.Equal(t, normalize(bson.M{"status": auction_entity.Active}), findFilter(t, auction_entity.AuctionQuery{
+		Statuses: []auction_entity.AuctionStatus{auction_entity.Active}}))
+
+	assert.Equal(t, normalize(bson.M{"status": bson.M{"$in": bson.A{auction_entity.Completed, auction_entity.Cancelled}}}),
+		findFilter(t, auction_entity.AuctionQuery{
+			Statuses: []auction_entity.AuctionStatus{auction_entity.Completed, auction_entity.Cancelled}}))
+}
+
+func TestAuctionFilter_Category(t *testing.T) {
+	filter := findFilter(t, auction_entity.AuctionQuery{Category: "electronics"})
+	assert.True(t, matches(filter, "category", "electronics"))
+	assert.False(t, matches(filter, "category", "electronics/phones"))
+	assert.False(t, matches(filter, "category", "electronicsx"))
+
+	filter = findFilter(t, auction_entity.AuctionQuery{Category: "electronics", IncludeSubcategories: true})
+	assert.True(t, matches(filter, "category", "electronics"))
+	assert.True(t, matches(filter, "category", "electronics/phones"))
+	assert.False(t, matches(filter, "category", "electronicsx"))
+
+	// Categorias antigas, fora da taxonomia, podem ter sido gravadas com maiúsculas
+	filter = findFilter(t, auction_entity.AuctionQuery{Category: "Electronics/PHONES"})
+	assert.True(t, matches(filter, "category", "electronics/phones"))
+	assert.False(t, matches(filter, "category", "electronics"))
+}
+
+func TestAuctionFilter_Condition(t *testing.T) {
+	assert.Equal(t, normalize(bson.M{"condition": auction_entity.Used}), findFilter(t, auction_entity.AuctionQuery{
+		Condition: auction_entity.Used}))
+}
+
+func TestAuctionFilter_PriceRange(t *testing.T) {
+	assert.Equal(t, normalize(bson.M{"current_price": bson.M{"$gte": 120.0, "$lte": 450.0}}),
+		findFilter(t, auction_entity.AuctionQuery{MinPrice: price(120), MaxPrice: price(450)}))
+
+	// Preço zero também é um limite
+	assert.Equal(t, normalize(bson.M{"current_price": bson.M{"$lte": 0.0}}),
+		findFilter(t, auction_entity.AuctionQuery{MaxPrice: price(0)}))
+}
+
+func TestAuctionFilter_CreatedRange(t *testing.T) {
+	assert.Equal(t, normalize(bson.M{"timestamp": bson.M{
+		"$gte": baseTime.AddDate(0, 0, 1).Unix(), "$lte": baseTime.AddDate(0, 0, 3).Unix()}}),
+		findFilter(t, auction_entity.AuctionQuery{
+			CreatedFrom: baseTime.AddDate(0, 0, 1), CreatedTo: baseTime.AddDate(0, 0, 3)}))
+
+	assert.Equal(t, normalize(bson.M{"timestamp": bson.M{"$gte": baseTime.Unix()}}),
+		findFilter(t, auction_entity.AuctionQuery{CreatedFrom: baseTime}))
+}
+
+func TestAuctionFilter_EndingRangeUsesTheStoredEndTime(t *testing.T) {
+	// Leilões reabertos terminam depois do que o timestamp indicaria, então o
+	// filtro usa o término gravado
+	assert.Equal(t, normalize(bson.M{"ends_at": bson.M{
+		"$gte": baseTime.AddDate(0, 0, 2).Unix(), "$lte": baseTime.AddDate(0, 0, 4).Unix()}}),
+		findFilter(t, auction_entity.AuctionQuery{
+			EndingFrom: baseTime.AddDate(0, 0, 2), EndingTo: baseTime.AddDate(0, 0, 4)}))
+
+	assert.Equal(t, normalize(bson.M{
+		"status":    auction_entity.Active,
+		"timestamp": bson.M{"$lte": baseTime.AddDate(0, 0, 2).Unix()},
+		"ends_at":   bson.M{"$gte": baseTime.AddDate(0, 0, 3).Unix()},
+	}), findFilter(t, auction_entity.AuctionQuery{
+		Statuses:   []auction_entity.AuctionStatus{auction_entity.Active},
+		CreatedTo:  baseTime.AddDate(0, 0, 2),
+		EndingFrom: baseTime.AddDate(0, 0, 3)}))
+}
+
+func TestAuctionFilter_ProductNameIsCaseInsensitive(t *testing.T) {
+	filter := findFilter(t, auction_entity.AuctionQuery{ProductName: "IPHONE"})
+	assert.True(t, matches(filter, "product_name", "iPhone 13"))
+	assert.True(t, matches(filter, "product_name", "Cadeira (vintage) iphone"))
+	assert.False(t, matches(filter, "product_name", "Galaxy S22"))
+
+	// O nome é procurado literalmente, sem interpretar regex
+	filter = findFilter(t, auction_entity.AuctionQuery{ProductName: "(vintage)"})
+	assert.True(t, matches(filter, "product_name", "Cadeira (vintage) iphone"))
+	assert.False(t, matches(filter, "product_name", "Cadeira vintage"))
+}
+
+func TestAuctionFilter_CombinedFilters(t *testing.T) {
+	filter := findFilter(t, auction_entity.AuctionQuery{
+		Statuses:             []auction_entity.AuctionStatus{auction_entity.Active},
+		Category:             "electronics",
+		IncludeSubcategories: true,
+		MinPrice:             price(100),
+	})
+
+	assert.True(t, matches(filter, "category", "electronics/laptops"))
+	delete(filter, "category")
+	assert.Equal(t, normalize(bson.M{
+		"status":        auction_entity.Active,
+		"current_price": bson.M{"$gte": 100.0},
+	}), filter)
+}
+
+func TestAuctionQuery_RejectsInvalidFilters(t *testing.T) {
+	for _, query := range []auction_entity.AuctionQuery{
+		{MinPrice: price(10), MaxPrice: price(5)},
+		{CreatedFrom: baseTime, CreatedTo: baseTime.Add(-time.Hour)},
+		{EndingFrom: baseTime, EndingTo: baseTime.Add(-time.Hour)},
+		{Statuses: []auction_entity.AuctionStatus{7}},
+	} {
+		assert.NotNil(t, query.Validate())
+	}
+}
+
+func TestAuctionRepository_StoresTheEndTime(t *testing.T) {
+	t.Setenv("AUCTION_TIMEOUT_SECONDS", "3600")
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+
+	mt.Run("create", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := auction.NewAuctionRepository(mt.DB).CreateAuction(ctx, &auction_entity.Auction{
+			Id: "a1", Status: auction_entity.Active, Timestamp: baseTime})
+		assert.Nil(t, err)
+
+		inserted := mt.GetStartedEvent().Command.Lookup("documents").Array().Index(0).Value().Document()
+		assert.Equal(t, baseTime.Add(time.Hour).Unix(), inserted.Lookup("ends_at").Int64())
+	})
+
+	mt.Run("reopen", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		reopenedAt := baseTime.AddDate(0, 0, 1)
+		err := auction.NewAuctionRepository(mt.DB).ReopenAuction(ctx, "a1", reopenedAt)
+		assert.Nil(t, err)
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, reopenedAt.Unix(), update.Lookup("u", "$set", "timestamp").Int64())
+		assert.Equal(t, reopenedAt.Add(time.Hour).Unix(), update.Lookup("u", "$set", "ends_at").Int64())
+	})
+
+	mt.Run("backfill", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		backfilled, err := auction.NewAuctionRepository(mt.DB).BackfillEndsAt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), backfilled)
+
+		// Só os leilões sem término recebem o timestamp somado à duração
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		var filter bson.M
+		bson.Unmarshal(update.Lookup("q").Document(), &filter)
+		assert.Equal(t, normalize(bson.M{"ends_at": bson.M{"$exists": false}}), filter)
+
+		endsAt := update.Lookup("u").Array().Index(0).Value().Document().Lookup("$set", "ends_at", "$add").Array()
+		assert.Equal(t, "$timestamp", endsAt.Index(0).Value().StringValue())
+		assert.Equal(t, int64(3600), endsAt.Index(1).Value().Int64())
+	})
+}
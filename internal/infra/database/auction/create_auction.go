@@ -2,43 +2,112 @@ package auction
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/utils"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AuctionEntityMongo struct {
-	Id          string                          `bson:"_id"`
-	ProductName string                          `bson:"product_name"`
-	Category    string                          `bson:"category"`
-	Description string                          `bson:"description"`
-	Condition   auction_entity.ProductCondition `bson:"condition"`
-	Status      auction_entity.AuctionStatus    `bson:"status"`
-	Timestamp   int64                           `bson:"timestamp"`
+	Id              string                          `bson:"_id"`
+	SellerId        string                          `bson:"seller_id"`
+	ProductName     string                          `bson:"product_name"`
+	Category        string                          `bson:"category"`
+	Description     string                          `bson:"description"`
+	Condition       auction_entity.ProductCondition `bson:"condition"`
+	Status          auction_entity.AuctionStatus    `bson:"status"`
+	Timestamp       int64                           `bson:"timestamp"`
+	ReservePrice    float64                         `bson:"reserve_price"`
+	CurrentPrice    float64                         `bson:"current_price"`
+	BidCount        int64                           `bson:"bid_count"`
+	LeadingBidderId string                          `bson:"leading_bidder_id"`
+	WatcherCount    int64                           `bson:"watcher_count"`
+	EndsAt          int64                           `bson:"ends_at"`
 }
 type AuctionRepository struct {
-	Collection *mongo.Collection
+	Collection      *mongo.Collection
+	auctionDuration time.Duration
 }
 
 func NewAuctionRepository(database *mongo.Database) *AuctionRepository {
 	return &AuctionRepository{
-		Collection: database.Collection("auctions"),
+		Collection:      database.Collection("auctions"),
+		auctionDuration: time.Duration(utils.GetAuctionTimeoutSeconds()) * time.Second,
 	}
 }
 
+// CreateIndexes cria os índices da listagem por vendedor, das ordenações da
+// listagem de leilões (ver listSortKeys), com e sem filtro de status, do
+// filtro por término e o índice de texto da busca.
+func (ar *AuctionRepository) CreateIndexes(ctx context.Context) error {
+	_, err := ar.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "seller_id", Value: 1}, {Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("seller_id_timestamp"),
+		},
+		{
+			Keys:    bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("timestamp_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("status_timestamp_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "current_price", Value: -1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("current_price_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "current_price", Value: -1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("status_current_price_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "ends_at", Value: 1}},
+			Options: options.Index().SetName("status_ends_at"),
+		},
+		{
+			// O nome pesa mais que a categoria, que pesa mais que a descrição
+			Keys: bson.D{
+				{Key: "product_name", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "category", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("auction_text").
+				SetDefaultLanguage("portuguese").
+				SetWeights(bson.D{
+					{Key: "product_name", Value: 10},
+					{Key: "category", Value: 5},
+					{Key: "description", Value: 1},
+				}),
+		},
+	})
+	return err
+}
+
 func (ar *AuctionRepository) CreateAuction(
 	ctx context.Context,
 	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
 	auctionEntityMongo := &AuctionEntityMongo{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   auctionEntity.Condition,
-		Status:      auctionEntity.Status,
-		Timestamp:   auctionEntity.Timestamp.Unix(),
+		Id:              auctionEntity.Id,
+		SellerId:        auctionEntity.SellerId,
+		ProductName:     auctionEntity.ProductName,
+		Category:        auctionEntity.Category,
+		Description:     auctionEntity.Description,
+		Condition:       auctionEntity.Condition,
+		Status:          auctionEntity.Status,
+		Timestamp:       auctionEntity.Timestamp.Unix(),
+		ReservePrice:    auctionEntity.ReservePrice,
+		CurrentPrice:    auctionEntity.CurrentPrice,
+		BidCount:        auctionEntity.BidCount,
+		LeadingBidderId: auctionEntity.LeadingBidderId,
+		EndsAt:          auctionEntity.EndsAt(ar.auctionDuration).Unix(),
 	}
 	_, err := ar.Collection.InsertOne(ctx, auctionEntityMongo)
 	if err != nil {
@@ -48,3 +117,110 @@ func (ar *AuctionRepository) CreateAuction(
 
 	return nil
 }
+
+// UpdateAuction grava os dados editáveis do leilão. Só leilões ativos podem
+// ser editados, mesmo que o leilão tenha sido encerrado após a leitura.
+func (ar *AuctionRepository) UpdateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionEntity.Id, "status": auction_entity.Active}
+	update := bson.M{"$set": bson.M{
+		"product_name": auctionEntity.ProductName,
+		"category":     auctionEntity.Category,
+		"description":  auctionEntity.Description,
+		"condition":    auctionEntity.Condition,
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update auction with id = %s", auctionEntity.Id), err)
+		return internal_error.NewInternalServerError("Error trying to update auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewConflictError("Only active auctions can be edited").
+			WithCode(internal_error.CodeAuctionNotActive)
+	}
+
+	return nil
+}
+
+// ReopenAuction reativa o leilão a partir de timestamp, com o término
+// recalculado a partir do novo início.
+func (ar *AuctionRepository) ReopenAuction(
+	ctx context.Context, id string, timestamp time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": id, "status": bson.M{"$ne": auction_entity.Active}}
+	update := bson.M{"$set": bson.M{
+		"status":    auction_entity.Active,
+		"timestamp": timestamp.Unix(),
+		"ends_at":   timestamp.Add(ar.auctionDuration).Unix(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to reopen auction with id = %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to reopen auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewConflictError("Only closed or cancelled auctions can be reopened").
+			WithCode(internal_error.CodeAuctionActive)
+	}
+
+	return nil
+}
+
+// UpdateBidState grava o estado dos lances do leilão, recalculado pelo
+// repositório de lances após cada gravação ou anulação. O estado só é gravado
+// sobre uma revisão mais antiga; um recálculo atrasado é descartado.
+func (ar *AuctionRepository) UpdateBidState(
+	ctx context.Context, id string, state auction_entity.AuctionBidState) *internal_error.InternalError {
+	filter := bson.M{"_id": id, "bid_revision": bson.M{"$not": bson.M{"$gte": state.Revision}}}
+	update := bson.M{"$set": bson.M{
+		"current_price":     state.CurrentPrice,
+		"bid_count":         state.BidCount,
+		"leading_bidder_id": state.LeadingBidderId,
+		"bid_revision":      state.Revision,
+	}}
+
+	if _, err := ar.Collection.UpdateOne(ctx, filter, update); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update bid state of auction %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to update auction bid state")
+	}
+
+	return nil
+}
+
+// IncrementWatcherCount soma delta aos usuários que acompanham o leilão. O
+// repositório da watchlist só chama com +1 ou -1 depois de gravar ou remover
+// de fato um acompanhamento, então gravações concorrentes não perdem contagem.
+func (ar *AuctionRepository) IncrementWatcherCount(
+	ctx context.Context, id string, delta int64) *internal_error.InternalError {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$inc": bson.M{"watcher_count": delta}}
+
+	if _, err := ar.Collection.UpdateOne(ctx, filter, update); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update watcher count of auction %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to update auction watcher count")
+	}
+
+	return nil
+}
+
+func (auctionMongo *AuctionEntityMongo) ToEntity() auction_entity.Auction {
+	return auction_entity.Auction{
+		Id:              auctionMongo.Id,
+		SellerId:        auctionMongo.SellerId,
+		ProductName:     auctionMongo.ProductName,
+		Category:        auctionMongo.Category,
+		Description:     auctionMongo.Description,
+		Condition:       auctionMongo.Condition,
+		Status:          auctionMongo.Status,
+		Timestamp:       time.Unix(auctionMongo.Timestamp, 0),
+		ReservePrice:    auctionMongo.ReservePrice,
+		CurrentPrice:    auctionMongo.CurrentPrice,
+		BidCount:        auctionMongo.BidCount,
+		LeadingBidderId: auctionMongo.LeadingBidderId,
+		WatcherCount:    auctionMongo.WatcherCount,
+	}
+}
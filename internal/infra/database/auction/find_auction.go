@@ -2,15 +2,18 @@ package auction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
-	"log"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (ar *AuctionRepository) FindAuctionById(
@@ -19,44 +22,150 @@ func (ar *AuctionRepository) FindAuctionById(
 
 	var auctionEntityMongo AuctionEntityMongo
 	if err := ar.Collection.FindOne(ctx, filter).Decode(&auctionEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Auction not found with this id = %s", id)).
+				WithCode(internal_error.CodeAuctionNotFound)
+		}
+
 		logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
 		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
 	}
 
-	return &auction_entity.Auction{
-		Id:          auctionEntityMongo.Id,
-		ProductName: auctionEntityMongo.ProductName,
-		Category:    auctionEntityMongo.Category,
-		Description: auctionEntityMongo.Description,
-		Condition:   auctionEntityMongo.Condition,
-		Status:      auctionEntityMongo.Status,
-		Timestamp:   time.Unix(auctionEntityMongo.Timestamp, 0),
-	}, nil
+	auctionEntity := auctionEntityMongo.ToEntity()
+	return &auctionEntity, nil
 }
 
 func (repo *AuctionRepository) FindAuctions(
 	ctx context.Context,
-	status auction_entity.AuctionStatus,
-	category string,
-	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	query auction_entity.AuctionQuery) ([]auction_entity.Auction, string, *internal_error.InternalError) {
+	if err := query.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	filter := auctionFilter(query)
+
+	sortKey, ok := listSortKeys[query.Sort]
+	if !ok {
+		return nil, "", internal_error.NewBadRequestError("Invalid auction sort")
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeAuctionCursor(query.Cursor, query.Sort)
+		if err != nil {
+			return nil, "", err
+		}
+		filter = bson.M{"$and": bson.A{filter, sortKey.after(cursor)}}
+	}
+
+	// Busca um leilão a mais para saber se existe uma próxima página
+	opts := options.Find().SetSort(sortKey.sort()).SetLimit(query.Limit + 1)
+
+	cursor, err := repo.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding auctions", err)
+		return nil, "", internal_error.NewInternalServerError("Error finding auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		logger.Error("Error decoding auctions", err)
+		return nil, "", internal_error.NewInternalServerError("Error decoding auctions")
+	}
+
+	nextCursor := ""
+	if int64(len(auctionsMongo)) > query.Limit {
+		auctionsMongo = auctionsMongo[:query.Limit]
+		nextCursor = sortKey.cursorAfter(query.Sort, auctionsMongo[len(auctionsMongo)-1])
+	}
+
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
+	for _, auction := range auctionsMongo {
+		auctionsEntity = append(auctionsEntity, auction.ToEntity())
+	}
+
+	return auctionsEntity, nextCursor, nil
+}
+
+// auctionFilter traduz os filtros da busca para o filtro do Mongo. Filtros
+// vazios não entram no filtro.
+func auctionFilter(query auction_entity.AuctionQuery) bson.M {
 	filter := bson.M{}
 
-	if status != 0 {
-		filter["status"] = status
+	switch len(query.Statuses) {
+	case 0:
+	case 1:
+		filter["status"] = query.Statuses[0]
+	default:
+		filter["status"] = bson.M{"$in": query.Statuses}
+	}
+
+	// Categorias antigas, anteriores à taxonomia, podem ter maiúsculas; os
+	// caminhos da taxonomia são sempre minúsculos
+	if query.Category != "" {
+		end := "$"
+		if query.IncludeSubcategories {
+			end = "(/|$)"
+		}
+		filter["category"] = primitive.Regex{
+			Pattern: "^" + regexp.QuoteMeta(query.Category) + end, Options: "i"}
 	}
 
-	if category != "" {
-		filter["category"] = category
+	if query.Condition != 0 {
+		filter["condition"] = query.Condition
+	}
+
+	if query.ProductName != "" {
+		filter["product_name"] = primitive.Regex{
+			Pattern: regexp.QuoteMeta(query.ProductName), Options: "i"}
+	}
+
+	price := bson.M{}
+	if query.MinPrice != nil {
+		price["$gte"] = *query.MinPrice
+	}
+	if query.MaxPrice != nil {
+		price["$lte"] = *query.MaxPrice
+	}
+	if len(price) > 0 {
+		filter["current_price"] = price
+	}
+
+	timestamp := bson.M{}
+	if !query.CreatedFrom.IsZero() {
+		timestamp["$gte"] = query.CreatedFrom.Unix()
+	}
+	if !query.CreatedTo.IsZero() {
+		timestamp["$lte"] = query.CreatedTo.Unix()
+	}
+	if len(timestamp) > 0 {
+		filter["timestamp"] = timestamp
 	}
 
-	if productName != "" {
-		filter["productName"] = primitive.Regex{Pattern: productName, Options: "i"}
+	endsAt := bson.M{}
+	if !query.EndingFrom.IsZero() {
+		endsAt["$gte"] = query.EndingFrom.Unix()
+	}
+	if !query.EndingTo.IsZero() {
+		endsAt["$lte"] = query.EndingTo.Unix()
+	}
+	if len(endsAt) > 0 {
+		filter["ends_at"] = endsAt
 	}
 
-	cursor, err := repo.Collection.Find(ctx, filter)
+	return filter
+}
+
+func (ar *AuctionRepository) FindAuctionsBySellerId(
+	ctx context.Context, sellerId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := bson.M{"seller_id": sellerId}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
 	if err != nil {
-		logger.Error("Error finding auctions", err)
-		return nil, internal_error.NewInternalServerError("Error finding auctions")
+		logger.Error(fmt.Sprintf("Error trying to find auctions by seller id = %s", sellerId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auctions by seller")
 	}
 	defer cursor.Close(ctx)
 
@@ -66,32 +175,20 @@ func (repo *AuctionRepository) FindAuctions(
 		return nil, internal_error.NewInternalServerError("Error decoding auctions")
 	}
 
-	var auctionsEntity []auction_entity.Auction
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
 	for _, auction := range auctionsMongo {
-		auctionsEntity = append(auctionsEntity, auction_entity.Auction{
-			Id:          auction.Id,
-			ProductName: auction.ProductName,
-			Category:    auction.Category,
-			Status:      auction.Status,
-			Description: auction.Description,
-			Condition:   auction.Condition,
-			Timestamp:   time.Unix(auction.Timestamp, 0),
-		})
+		auctionsEntity = append(auctionsEntity, auction.ToEntity())
 	}
 
 	return auctionsEntity, nil
 }
 
-func (ar *AuctionRepository) FindExpiredAuctions(ctx context.Context, auctionTimeoutSeconds int64) ([]auction_entity.Auction, *internal_error.InternalError) {
-	// Tempo atual
-	currentTime := time.Now().Unix()
-
-	// Filtro para buscar leilões com status aberto (0) e timestamp expirado
+// FindExpiredAuctions busca os leilões ativos cujo término já passou em now.
+// O filtro usa o término gravado, coberto pelo índice status_ends_at.
+func (ar *AuctionRepository) FindExpiredAuctions(ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
 	filter := bson.M{
-		"status": 0,
-		"timestamp": bson.M{
-			"$lt": currentTime - auctionTimeoutSeconds, // Leilão expirado
-		},
+		"status":  auction_entity.Active,
+		"ends_at": bson.M{"$lte": now.Unix()},
 	}
 
 	// Buscando leilões expirados no banco de dados
@@ -109,17 +206,7 @@ func (ar *AuctionRepository) FindExpiredAuctions(ctx context.Context, auctionTim
 		}
 
 		// Convertendo a entidade MongoDB para a entidade Auction
-		auction := auction_entity.Auction{
-			Id:          auctionEntityMongo.Id,
-			ProductName: auctionEntityMongo.ProductName,
-			Category:    auctionEntityMongo.Category,
-			Description: auctionEntityMongo.Description,
-			Condition:   auctionEntityMongo.Condition,
-			Status:      auctionEntityMongo.Status,
-			Timestamp:   time.Unix(auctionEntityMongo.Timestamp, 0),
-		}
-
-		auctions = append(auctions, auction)
+		auctions = append(auctions, auctionEntityMongo.ToEntity())
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -129,15 +216,23 @@ func (ar *AuctionRepository) FindExpiredAuctions(ctx context.Context, auctionTim
 	return auctions, nil
 }
 
-func (ar *AuctionRepository) UpdateAuctionStatus(ctx context.Context, id string, status int) *internal_error.InternalError {
-	filter := bson.M{"_id": id}
+// UpdateAuctionStatus encerra ou cancela um leilão ativo. O status ativo faz
+// parte do filtro, então de duas transições concorrentes só uma é aplicada e a
+// outra recebe conflito.
+func (ar *AuctionRepository) UpdateAuctionStatus(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	filter := bson.M{"_id": id, "status": auction_entity.Active}
 	update := bson.M{"$set": bson.M{"status": status}}
 
-	_, err := ar.Collection.UpdateOne(ctx, filter, update)
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		log.Printf("Erro ao atualizar status do leilão com ID %s: %v\n", id, err)
+		logger.Error(fmt.Sprintf("Error trying to update status of auction with id = %s", id), err)
 		return internal_error.NewInternalServerError("Erro ao atualizar status do leilão")
 	}
 
+	if result.MatchedCount == 0 {
+		return internal_error.NewConflictError("Only active auctions can be closed").
+			WithCode(internal_error.CodeAuctionNotActive)
+	}
+
 	return nil
 }
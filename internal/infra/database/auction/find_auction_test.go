@@ -0,0 +1,189 @@
+package auction_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// listingAuctions tem empates de timestamp (a1/a2, a4/a5) e de preço
+// (a2/a3/a5), para que só o desempate pelo _id defina a ordem.
+var listingAuctions = map[string]auction.AuctionEntityMongo{
+	"a1": {Id: "a1", Timestamp: 100, CurrentPrice: 30},
+	"a2": {Id: "a2", Timestamp: 100, CurrentPrice: 50},
+	"a3": {Id: "a3", Timestamp: 200, CurrentPrice: 50},
+	"a4": {Id: "a4", Timestamp: 300, CurrentPrice: 10},
+	"a5": {Id: "a5", Timestamp: 300, CurrentPrice: 50},
+}
+
+// document converte v para o documento que o Mongo devolveria.
+func document(v interface{}) bson.D {
+	raw, _ := bson.Marshal(v)
+	var doc bson.D
+	bson.Unmarshal(raw, &doc)
+	return doc
+}
+
+// normalize passa o filtro pelo codec do driver, para compará-lo com o
+// filtro lido do comando enviado.
+func normalize(filter bson.M) bson.M {
+	raw, _ := bson.Marshal(filter)
+	var normalized bson.M
+	bson.Unmarshal(raw, &normalized)
+	return normalized
+}
+
+// findPage responde ao find com os leilões ids e devolve os ids da página, o
+// próximo cursor e o filtro enviado, nil quando nenhum find foi enviado.
+func findPage(
+	mt *mtest.T,
+	query auction_entity.AuctionQuery,
+	ids ...string) ([]string, string, bson.M, *internal_error.InternalError) {
+	docs := make([]bson.D, 0, len(ids))
+	for _, id := range ids {
+		docs = append(docs, document(listingAuctions[id]))
+	}
+	mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.auctions", mtest.FirstBatch, docs...))
+
+	auctions, nextCursor, err := auction.NewAuctionRepository(mt.DB).FindAuctions(context.Background(), query)
+
+	var filter bson.M
+	if event := mt.GetStartedEvent(); event != nil {
+		bson.Unmarshal(event.Command.Lookup("filter").Document(), &filter)
+	}
+
+	pageIds := make([]string, 0, len(auctions))
+	for _, auctionEntity := range auctions {
+		pageIds = append(pageIds, auctionEntity.Id)
+	}
+	return pageIds, nextCursor, filter, err
+}
+
+func TestFindAuctions_CursorPagesAreStable(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	testCases := []struct {
+		sort        auction_entity.AuctionSort
+		order       []string
+		field       string
+		direction   int32
+		idDirection int32
+		after       bson.M
+	}{
+		{
+			sort: auction_entity.SortEndingSoon, order: []string{"a1", "a2", "a3", "a4", "a5"},
+			field: "timestamp", direction: 1, idDirection: 1,
+			after: bson.M{"$or": bson.A{
+				bson.M{"timestamp": bson.M{"$gt": int64(100)}},
+				bson.M{"timestamp": int64(100), "_id": bson.M{"$gt": "a2"}},
+			}},
+		},
+		{
+			sort: auction_entity.SortNewest, order: []string{"a5", "a4", "a3", "a2", "a1"},
+			field: "timestamp", direction: -1, idDirection: -1,
+			after: bson.M{"$or": bson.A{
+				bson.M{"timestamp": bson.M{"$lt": int64(300)}},
+				bson.M{"timestamp": int64(300), "_id": bson.M{"$lt": "a4"}},
+			}},
+		},
+		{
+			sort: auction_entity.SortPriceDesc, order: []string{"a2", "a3", "a5", "a1", "a4"},
+			field: "current_price", direction: -1, idDirection: 1,
+			after: bson.M{"$or": bson.A{
+				bson.M{"current_price": bson.M{"$lt": 50.0}},
+				bson.M{"current_price": 50.0, "_id": bson.M{"$gt": "a3"}},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		mt.Run(string(tc.sort), func(mt *mtest.T) {
+			query := auction_entity.AuctionQuery{Sort: tc.sort, Limit: 2}
+
+			// O leilão a mais indica que existe uma próxima página
+			ids, nextCursor, filter, err := findPage(mt, query, tc.order[:3]...)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.order[:2], ids)
+			assert.Empty(t, filter)
+
+			// O token é opaco para o cliente, mas guarda a ordenação e a posição
+			raw, decodeErr := base64.RawURLEncoding.DecodeString(nextCursor)
+			assert.Nil(t, decodeErr)
+			var token map[string]interface{}
+			assert.Nil(t, json.Unmarshal(raw, &token))
+			assert.Equal(t, string(tc.sort), token["s"])
+			assert.Equal(t, tc.order[1], token["id"])
+
+			// A página seguinte parte da posição do último leilão, não de um
+			// deslocamento, então leilões criados entre as páginas não a alteram
+			query.Cursor = nextCursor
+			ids, nextCursor, filter, err = findPage(mt, query, tc.order[2:4]...)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.order[2:4], ids)
+			assert.Empty(t, nextCursor)
+			assert.Equal(t, normalize(bson.M{"$and": bson.A{bson.M{}, tc.after}}), filter)
+		})
+	}
+
+	for _, tc := range testCases {
+		mt.Run(string(tc.sort)+" sort and limit", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.auctions", mtest.FirstBatch))
+			auction.NewAuctionRepository(mt.DB).FindAuctions(context.Background(),
+				auction_entity.AuctionQuery{Sort: tc.sort, Limit: 2})
+
+			command := mt.GetStartedEvent().Command
+			assert.Equal(t, int64(3), command.Lookup("limit").AsInt64())
+			sortKeys, _ := command.Lookup("sort").Document().Elements()
+			if assert.Len(t, sortKeys, 2) {
+				assert.Equal(t, tc.field, sortKeys[0].Key())
+				assert.Equal(t, tc.direction, sortKeys[0].Value().Int32())
+				assert.Equal(t, "_id", sortKeys[1].Key())
+				assert.Equal(t, tc.idDirection, sortKeys[1].Value().Int32())
+			}
+		})
+	}
+
+	mt.Run("cursor of another sort", func(mt *mtest.T) {
+		_, nextCursor, _, _ := findPage(mt,
+			auction_entity.AuctionQuery{Sort: auction_entity.SortNewest, Limit: 2}, "a5", "a4", "a3")
+
+		_, _, filter, err := findPage(mt,
+			auction_entity.AuctionQuery{Sort: auction_entity.SortPriceDesc, Limit: 2, Cursor: nextCursor})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeInvalidCursor, err.Code)
+		}
+		assert.Nil(t, filter)
+	})
+}
+
+func TestFindExpiredAuctions_FiltersByTheStoredEndTime(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("expired", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.auctions", mtest.FirstBatch,
+			document(auction.AuctionEntityMongo{Id: "a1", Timestamp: baseTime.Unix()})))
+
+		expired, err := auction.NewAuctionRepository(mt.DB).FindExpiredAuctions(context.Background(), baseTime)
+		assert.Nil(t, err)
+		if assert.Len(t, expired, 1) {
+			assert.Equal(t, "a1", expired[0].Id)
+		}
+
+		// Status e término casam com o índice status_ends_at
+		var filter bson.M
+		bson.Unmarshal(mt.GetStartedEvent().Command.Lookup("filter").Document(), &filter)
+		assert.Equal(t, normalize(bson.M{
+			"status":  auction_entity.Active,
+			"ends_at": bson.M{"$lte": baseTime.Unix()},
+		}), filter)
+	})
+}
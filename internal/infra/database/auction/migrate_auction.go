@@ -0,0 +1,24 @@
+package auction
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BackfillEndsAt grava o término dos leilões criados antes de ele ser
+// guardado, somando a duração configurada ao timestamp, e devolve quantos
+// leilões foram preenchidos.
+func (ar *AuctionRepository) BackfillEndsAt(ctx context.Context) (int64, error) {
+	result, err := ar.Collection.UpdateMany(ctx,
+		bson.M{"ends_at": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"ends_at": bson.M{"$add": bson.A{"$timestamp", int64(ar.auctionDuration / time.Second)}},
+		}}}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,75 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auctionSearchResultMongo struct {
+	AuctionEntityMongo `bson:",inline"`
+	Score              float64 `bson:"score"`
+}
+
+// searchFilter combina a busca de texto com os filtros de status e categoria
+// da listagem.
+func searchFilter(query auction_entity.AuctionSearchQuery) bson.M {
+	filter := auctionFilter(auction_entity.AuctionQuery{
+		Statuses:             query.Statuses,
+		Category:             query.Category,
+		IncludeSubcategories: query.IncludeSubcategories,
+	})
+	filter["$text"] = bson.M{"$search": query.Text}
+
+	return filter
+}
+
+func (ar *AuctionRepository) SearchAuctions(
+	ctx context.Context,
+	query auction_entity.AuctionSearchQuery) ([]auction_entity.AuctionSearchResult, int64, *internal_error.InternalError) {
+	if err := query.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	filter := searchFilter(query)
+
+	total, err := ar.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logger.Error("Error trying to count searched auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to search auctions")
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip((query.Page - 1) * query.Limit).
+		SetLimit(query.Limit)
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error trying to search auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to search auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var resultsMongo []auctionSearchResultMongo
+	if err := cursor.All(ctx, &resultsMongo); err != nil {
+		logger.Error("Error decoding searched auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error decoding auctions")
+	}
+
+	results := make([]auction_entity.AuctionSearchResult, 0, len(resultsMongo))
+	for _, result := range resultsMongo {
+		results = append(results, auction_entity.AuctionSearchResult{
+			Auction: result.ToEntity(),
+			Score:   result.Score,
+		})
+	}
+
+	return results, total, nil
+}
@@ -0,0 +1,29 @@
+package auction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+)
+
+func TestSearchFilter_CombinesTextWithStatusAndCategory(t *testing.T) {
+	filter := searchFilter(auction_entity.AuctionSearchQuery{
+		Text:                 "iphone -capa",
+		Statuses:             []auction_entity.AuctionStatus{auction_entity.Active},
+		Category:             "electronics",
+		IncludeSubcategories: true,
+	})
+
+	assert.Equal(t, bson.M{"$search": "iphone -capa"}, filter["$text"])
+
+	// Sem o $text, o restante do filtro é o da listagem, testado em FindAuctions
+	delete(filter, "$text")
+	assert.Equal(t, auctionFilter(auction_entity.AuctionQuery{
+		Statuses:             []auction_entity.AuctionStatus{auction_entity.Active},
+		Category:             "electronics",
+		IncludeSubcategories: true,
+	}), filter)
+}
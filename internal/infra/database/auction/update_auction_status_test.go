@@ -0,0 +1,46 @@
+package auction_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func TestUpdateAuctionStatus_OnlyMovesActiveAuctions(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("active", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		err := auction.NewAuctionRepository(mt.DB).UpdateAuctionStatus(
+			context.Background(), "a1", auction_entity.Cancelled)
+		assert.Nil(t, err)
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		filter := update.Lookup("q").Document()
+		assert.Equal(t, "a1", filter.Lookup("_id").StringValue())
+		assert.Equal(t, int64(auction_entity.Active), filter.Lookup("status").AsInt64())
+		assert.Equal(t, int64(auction_entity.Cancelled),
+			update.Lookup("u", "$set", "status").AsInt64())
+	})
+
+	mt.Run("already closed", func(mt *mtest.T) {
+		// Outra transição venceu: o filtro não encontra um leilão ativo
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+
+		err := auction.NewAuctionRepository(mt.DB).UpdateAuctionStatus(
+			context.Background(), "a1", auction_entity.Completed)
+		assert.NotNil(t, err)
+		assert.Equal(t, "conflict", err.Err)
+		assert.Equal(t, internal_error.CodeAuctionNotActive, err.Code)
+	})
+}
@@ -2,16 +2,17 @@ package bid
 
 import (
 	"context"
+	"errors"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
-	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/internal_error"
-	"os"
-	"sync"
+	"fullcycle-auction_go/internal/utils"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type BidEntityMongo struct {
@@ -20,112 +21,193 @@ type BidEntityMongo struct {
 	AuctionId string  `bson:"auction_id"`
 	Amount    float64 `bson:"amount"`
 	Timestamp int64   `bson:"timestamp"`
+	Voided    bool    `bson:"voided,omitempty"`
 }
 
 type BidRepository struct {
-	Collection            *mongo.Collection
-	AuctionRepository     *auction.AuctionRepository
-	auctionInterval       time.Duration
-	auctionStatusMap      map[string]auction_entity.AuctionStatus
-	auctionEndTimeMap     map[string]time.Time
-	auctionStatusMapMutex *sync.Mutex
-	auctionEndTimeMutex   *sync.Mutex
+	Collection *mongo.Collection
+	// AuctionRepository normalmente é o auction.AuctionCache compartilhado com o caso de uso
+	AuctionRepository auction_entity.AuctionRepositoryInterface
+	auctionDuration   time.Duration
 }
 
-func NewBidRepository(database *mongo.Database, auctionRepository *auction.AuctionRepository) *BidRepository {
+func NewBidRepository(
+	database *mongo.Database,
+	auctionRepository auction_entity.AuctionRepositoryInterface) *BidRepository {
 	return &BidRepository{
-		auctionInterval:       getAuctionInterval(),
-		auctionStatusMap:      make(map[string]auction_entity.AuctionStatus),
-		auctionEndTimeMap:     make(map[string]time.Time),
-		auctionStatusMapMutex: &sync.Mutex{},
-		auctionEndTimeMutex:   &sync.Mutex{},
-		Collection:            database.Collection("bids"),
-		AuctionRepository:     auctionRepository,
+		auctionDuration:   time.Duration(utils.GetAuctionTimeoutSeconds()) * time.Second,
+		Collection:        database.Collection("bids"),
+		AuctionRepository: auctionRepository,
 	}
 }
 
 func (bd *BidRepository) CreateBid(
 	ctx context.Context,
-	bidEntities []bid_entity.Bid) *internal_error.InternalError {
-
-	var wg sync.WaitGroup
-	errChan := make(chan error, len(bidEntities)) // Canal para capturar erros de inserção
-
-	for _, bid := range bidEntities {
-		wg.Add(1)
-		go func(bidValue bid_entity.Bid) {
-			defer wg.Done()
-
-			// Verifica o status e o tempo de encerramento do leilão
-			bd.auctionStatusMapMutex.Lock()
-			_, okStatus := bd.auctionStatusMap[bidValue.AuctionId]
-			bd.auctionStatusMapMutex.Unlock()
-
-			bd.auctionEndTimeMutex.Lock()
-			_, okEndTime := bd.auctionEndTimeMap[bidValue.AuctionId]
-			bd.auctionEndTimeMutex.Unlock()
-
-			// Cria a estrutura para salvar no banco
-			bidEntityMongo := &BidEntityMongo{
-				Id:        bidValue.Id,
-				UserId:    bidValue.UserId,
-				AuctionId: bidValue.AuctionId,
-				Amount:    bidValue.Amount,
-				Timestamp: bidValue.Timestamp.Unix(),
-			}
-
-			// Valida o leilão em cache
-			if okEndTime && okStatus {
-				if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-					errChan <- err // Envia erro para o canal
-					return
-				}
-				return
-			}
-
-			// Se o leilão não estiver em cache, busca do banco
-			auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, bidValue.AuctionId)
-			if err != nil {
-				errChan <- err // Envia erro para o canal
-				return
-			}
-
-			// Atualiza os mapas em cache
-			bd.auctionStatusMapMutex.Lock()
-			bd.auctionStatusMap[bidValue.AuctionId] = auctionEntity.Status
-			bd.auctionStatusMapMutex.Unlock()
-
-			bd.auctionEndTimeMutex.Lock()
-			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.Timestamp.Add(bd.auctionInterval)
-			bd.auctionEndTimeMutex.Unlock()
-
-			// Insere o lance
-			if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-				errChan <- err // Envia erro para o canal
-				return
-			}
-		}(bid)
+	bidEntities []bid_entity.Bid) ([]bid_entity.BidRejection, *internal_error.InternalError) {
+	var rejections []bid_entity.BidRejection
+
+	// Os lances chegam ordenados pelo worker do leilão; a inserção ordenada
+	// mantém essa ordem no banco
+	bidDocuments := make([]interface{}, 0, len(bidEntities))
+	for _, bidValue := range bidEntities {
+		auctionStatus, auctionEndTime, err := bd.loadAuctionState(ctx, bidValue.AuctionId)
+		if err != nil {
+			logger.Error("Error processing bid:", err)
+			return nil, internal_error.NewInternalServerError("Error processing bids")
+		}
+
+		// O leilão pode ter encerrado entre a validação do lance e a gravação
+		if auctionStatus != auction_entity.Active {
+			rejections = append(rejections, bid_entity.BidRejection{
+				BidId: bidValue.Id, Reason: "auction is closed"})
+			continue
+		}
+
+		if !bidValue.Timestamp.Before(auctionEndTime) {
+			rejections = append(rejections, bid_entity.BidRejection{
+				BidId: bidValue.Id, Reason: "bid placed after the auction end"})
+			continue
+		}
+
+		bidDocuments = append(bidDocuments, &BidEntityMongo{
+			Id:        bidValue.Id,
+			UserId:    bidValue.UserId,
+			AuctionId: bidValue.AuctionId,
+			Amount:    bidValue.Amount,
+			Timestamp: bidValue.Timestamp.Unix(),
+		})
 	}
 
-	// Aguarda todas as goroutines terminarem
-	wg.Wait()
-	close(errChan)
+	if len(bidDocuments) == 0 {
+		return rejections, nil
+	}
 
-	// Retorna o primeiro erro encontrado, se existir
-	for err := range errChan {
+	if err := bd.insertOrdered(ctx, bidDocuments); err != nil {
 		logger.Error("Error processing bid:", err)
-		return internal_error.NewInternalServerError("Error processing bids")
+
+		if mongo.IsTimeout(err) || mongo.IsNetworkError(err) {
+			return nil, internal_error.NewServiceUnavailableError("Error processing bids, database unavailable")
+		}
+		return nil, internal_error.NewInternalServerError("Error processing bids")
+	}
+
+	refreshed := make(map[string]bool)
+	for _, bidDocument := range bidDocuments {
+		auctionId := bidDocument.(*BidEntityMongo).AuctionId
+		if !refreshed[auctionId] {
+			refreshed[auctionId] = true
+			bd.refreshBidState(ctx, auctionId)
+		}
+	}
+
+	return rejections, nil
+}
+
+// refreshBidState recalcula preço atual, quantidade de lances e líder do
+// leilão a partir dos lances válidos. Uma falha só é registrada: os lances já
+// estão gravados e o estado é recalculado no próximo lance.
+func (bd *BidRepository) refreshBidState(ctx context.Context, auctionId string) {
+	states, err := bd.findBidStates(ctx, bson.M{"auction_id": auctionId})
+	if err != nil {
+		logger.Error("Error trying to refresh bid state of auction "+auctionId, err)
+		return
+	}
+
+	if err := bd.AuctionRepository.UpdateBidState(ctx, auctionId, states[auctionId]); err != nil {
+		logger.Error("Error trying to refresh bid state of auction "+auctionId, err)
+	}
+}
+
+type bidStateMongo struct {
+	AuctionId       string  `bson:"_id"`
+	CurrentPrice    float64 `bson:"current_price"`
+	BidCount        int64   `bson:"bid_count"`
+	LeadingBidderId string  `bson:"leading_bidder_id"`
+	Revision        int64   `bson:"revision"`
+}
+
+// findBidStates calcula, em uma única agregação, o estado dos lances de cada
+// leilão com lances que passam pelo filtro. A revisão soma um por lance
+// gravado e mais um por lance anulado, então só cresce; leilões sem lances
+// ficam fora do resultado e têm o estado zerado, de revisão zero.
+func (bd *BidRepository) findBidStates(
+	ctx context.Context, filter bson.M) (map[string]auction_entity.AuctionBidState, error) {
+	voided := bson.M{"$eq": bson.A{"$voided", true}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		// Lances válidos primeiro, na ordem do vencedor: maior valor e, no
+		// empate, o mais antigo
+		{{Key: "$addFields", Value: bson.M{"voided": voided}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "voided", Value: 1},
+			{Key: "amount", Value: -1},
+			{Key: "timestamp", Value: 1},
+			{Key: "_id", Value: 1},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               "$auction_id",
+			"current_price":     bson.M{"$first": bson.M{"$cond": bson.A{"$voided", 0, "$amount"}}},
+			"leading_bidder_id": bson.M{"$first": bson.M{"$cond": bson.A{"$voided", "", "$user_id"}}},
+			"bid_count":         bson.M{"$sum": bson.M{"$cond": bson.A{"$voided", 0, 1}}},
+			"revision":          bson.M{"$sum": bson.M{"$cond": bson.A{"$voided", 2, 1}}},
+		}}},
+	}
+
+	cursor, err := bd.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var statesMongo []bidStateMongo
+	if err := cursor.All(ctx, &statesMongo); err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]auction_entity.AuctionBidState, len(statesMongo))
+	for _, stateMongo := range statesMongo {
+		states[stateMongo.AuctionId] = auction_entity.AuctionBidState{
+			CurrentPrice:    stateMongo.CurrentPrice,
+			BidCount:        stateMongo.BidCount,
+			LeadingBidderId: stateMongo.LeadingBidderId,
+			Revision:        stateMongo.Revision,
+		}
+	}
+
+	return states, nil
+}
+
+// insertOrdered insere os documentos na ordem recebida. Lances já gravados em
+// uma tentativa anterior (chave duplicada) são ignorados, o que torna a
+// reexecução de um lote segura.
+func (bd *BidRepository) insertOrdered(ctx context.Context, bidDocuments []interface{}) error {
+	for len(bidDocuments) > 0 {
+		_, err := bd.Collection.InsertMany(ctx, bidDocuments, options.InsertMany().SetOrdered(true))
+		if err == nil {
+			return nil
+		}
+
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) != 1 ||
+			!mongo.IsDuplicateKeyError(bulkErr.WriteErrors[0]) {
+			return err
+		}
+
+		bidDocuments = bidDocuments[bulkErr.WriteErrors[0].Index+1:]
 	}
 
 	return nil
 }
 
-func getAuctionInterval() time.Duration {
-	auctionInterval := os.Getenv("AUCTION_INTERVAL")
-	duration, err := time.ParseDuration(auctionInterval)
+// loadAuctionState devolve o status e o término do leilão a partir do cache de
+// leilões. O término usa a mesma duração do encerramento automático.
+func (bd *BidRepository) loadAuctionState(
+	ctx context.Context,
+	auctionId string) (auction_entity.AuctionStatus, time.Time, *internal_error.InternalError) {
+	auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, auctionId)
 	if err != nil {
-		return time.Minute * 5
+		return 0, time.Time{}, err
 	}
 
-	return duration
+	return auctionEntity.Status, auctionEntity.EndsAt(bd.auctionDuration), nil
 }
@@ -0,0 +1,243 @@
+package bid_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity/auctiontest"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// fakeAuctionRepository devolve os leilões informados e ignora o estado dos lances
+type fakeAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auctions map[string]auction_entity.Auction
+}
+
+func (f *fakeAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, ok := f.auctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("auction not found")
+	}
+	return &auction, nil
+}
+
+func (f *fakeAuctionRepository) UpdateBidState(ctx context.Context, id string, state auction_entity.AuctionBidState) *internal_error.InternalError {
+	return nil
+}
+
+func TestCreateBid_RejectsLateAndClosedBidsBeforeWriting(t *testing.T) {
+	// O término vem da mesma duração usada pelo encerramento automático, não
+	// do intervalo de gravação em lote
+	t.Setenv("AUCTION_TIMEOUT_SECONDS", "600")
+	t.Setenv("AUCTION_INTERVAL", "20s")
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("late bids", func(mt *mtest.T) {
+		startedAt := time.Now().Add(-20 * time.Minute)
+		auctions := &fakeAuctionRepository{auctions: map[string]auction_entity.Auction{
+			"open":   {Id: "open", Status: auction_entity.Active, Timestamp: startedAt},
+			"closed": {Id: "closed", Status: auction_entity.Completed, Timestamp: time.Now()},
+		}}
+		repository := bid.NewBidRepository(mt.DB, auctions)
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch))
+
+		// O leilão aberto encerrou 10 minutos depois de começar
+		rejections, err := repository.CreateBid(context.Background(), []bid_entity.Bid{
+			{Id: "on-time", AuctionId: "open", Amount: 10, Timestamp: startedAt.Add(5 * time.Minute)},
+			{Id: "at-end", AuctionId: "open", Amount: 20, Timestamp: startedAt.Add(10 * time.Minute)},
+			{Id: "late", AuctionId: "open", Amount: 30, Timestamp: startedAt.Add(15 * time.Minute)},
+			{Id: "on-closed", AuctionId: "closed", Amount: 40, Timestamp: time.Now()},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []bid_entity.BidRejection{
+			{BidId: "at-end", Reason: "bid placed after the auction end"},
+			{BidId: "late", Reason: "bid placed after the auction end"},
+			{BidId: "on-closed", Reason: "auction is closed"},
+		}, rejections)
+
+		insert := mt.GetStartedEvent()
+		if assert.NotNil(t, insert) {
+			assert.Equal(t, "insert", insert.CommandName)
+			documents, _ := insert.Command.Lookup("documents").Array().Values()
+			if assert.Len(t, documents, 1) {
+				assert.Equal(t, "on-time", documents[0].Document().Lookup("_id").StringValue())
+			}
+		}
+	})
+
+	mt.Run("nothing to write", func(mt *mtest.T) {
+		auctions := &fakeAuctionRepository{auctions: map[string]auction_entity.Auction{
+			"closed": {Id: "closed", Status: auction_entity.Cancelled, Timestamp: time.Now()},
+		}}
+		repository := bid.NewBidRepository(mt.DB, auctions)
+
+		rejections, err := repository.CreateBid(context.Background(), []bid_entity.Bid{
+			{Id: "b1", AuctionId: "closed", Amount: 10, Timestamp: time.Now()},
+		})
+		assert.Nil(t, err)
+		assert.Len(t, rejections, 1)
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+}
+
+// document converte v para o documento que o Mongo devolveria.
+func document(v interface{}) bson.D {
+	raw, _ := bson.Marshal(v)
+	var doc bson.D
+	bson.Unmarshal(raw, &doc)
+	return doc
+}
+
+// normalize passa o filtro pelo codec do driver, para compará-lo com o
+// filtro lido do comando enviado.
+func normalize(filter bson.M) bson.M {
+	raw, _ := bson.Marshal(filter)
+	var normalized bson.M
+	bson.Unmarshal(raw, &normalized)
+	return normalized
+}
+
+// pipelineStages devolve os estágios da agregação enviada no comando.
+func pipelineStages(command bson.Raw) []bson.Raw {
+	values, _ := command.Lookup("pipeline").Array().Values()
+	stages := make([]bson.Raw, 0, len(values))
+	for _, value := range values {
+		stages = append(stages, value.Document())
+	}
+	return stages
+}
+
+// sortKeys lista as chaves de uma ordenação como "campo:direção".
+func sortKeys(sort bson.Raw) []string {
+	elements, _ := sort.Elements()
+	keys := make([]string, 0, len(elements))
+	for _, element := range elements {
+		keys = append(keys, fmt.Sprintf("%s:%d", element.Key(), element.Value().AsInt64()))
+	}
+	return keys
+}
+
+// bidState é o documento devolvido pela agregação do estado dos lances.
+func bidState(auctionId string, state auction_entity.AuctionBidState) bson.D {
+	return document(bson.M{
+		"_id":               auctionId,
+		"current_price":     state.CurrentPrice,
+		"bid_count":         state.BidCount,
+		"leading_bidder_id": state.LeadingBidderId,
+		"revision":          state.Revision,
+	})
+}
+
+// assertBidStateAggregation confere que o estado foi calculado por uma única
+// agregação, com os lances válidos na ordem do vencedor.
+func assertBidStateAggregation(t *testing.T, command bson.Raw, match bson.M) {
+	stages := pipelineStages(command)
+	if !assert.Len(t, stages, 4) {
+		return
+	}
+
+	var sentMatch bson.M
+	bson.Unmarshal(stages[0].Lookup("$match").Document(), &sentMatch)
+	assert.Equal(t, normalize(match), sentMatch)
+	assert.Equal(t, []string{"voided:1", "amount:-1", "timestamp:1", "_id:1"},
+		sortKeys(stages[2].Lookup("$sort").Document()))
+}
+
+func TestRefreshBidState_WritesOneAggregatedStatePerAuction(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+
+	mt.Run("bids", func(mt *mtest.T) {
+		state := auction_entity.AuctionBidState{CurrentPrice: 30, BidCount: 2, LeadingBidderId: "u2", Revision: 2}
+		auctions := &auctiontest.MockAuctionRepository{}
+		auctions.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+			Id: "a1", Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+		auctions.On("UpdateBidState", mock.Anything, "a1", state).Return(nil).Once()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}),
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, bidState("a1", state)))
+
+		_, err := bid.NewBidRepository(mt.DB, auctions).CreateBid(ctx, []bid_entity.Bid{
+			{Id: "b1", UserId: "u1", AuctionId: "a1", Amount: 10, Timestamp: time.Now()},
+			{Id: "b2", UserId: "u2", AuctionId: "a1", Amount: 30, Timestamp: time.Now()},
+		})
+		assert.Nil(t, err)
+
+		assert.Equal(t, "insert", mt.GetStartedEvent().CommandName)
+		aggregate := mt.GetStartedEvent()
+		if assert.NotNil(t, aggregate) {
+			assertBidStateAggregation(t, aggregate.Command, bson.M{"auction_id": "a1"})
+		}
+		// Os dois lances do mesmo leilão geram um só recálculo
+		assert.Nil(t, mt.GetStartedEvent())
+		auctions.AssertExpectations(t)
+	})
+
+	mt.Run("void", func(mt *mtest.T) {
+		// Anular o líder devolve a liderança ao lance anterior
+		state := auction_entity.AuctionBidState{CurrentPrice: 10, BidCount: 1, LeadingBidderId: "u1", Revision: 3}
+		auctions := &auctiontest.MockAuctionRepository{}
+		auctions.On("UpdateBidState", mock.Anything, "a1", state).Return(nil).Once()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, document(bid.BidEntityMongo{
+				Id: "b2", UserId: "u2", AuctionId: "a1", Amount: 30, Voided: true})),
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, bidState("a1", state)))
+
+		assert.Nil(t, bid.NewBidRepository(mt.DB, auctions).VoidBid(ctx, "b2"))
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, "b2", update.Lookup("q", "_id").StringValue())
+		assert.True(t, update.Lookup("u", "$set", "voided").Boolean())
+		mt.GetStartedEvent()
+		assertBidStateAggregation(t, mt.GetStartedEvent().Command, bson.M{"auction_id": "a1"})
+		auctions.AssertExpectations(t)
+	})
+
+	mt.Run("backfill", func(mt *mtest.T) {
+		// Leilões sem lances ficam fora da agregação e recebem o estado zerado
+		state := auction_entity.AuctionBidState{CurrentPrice: 10, BidCount: 2, LeadingBidderId: "u1", Revision: 3}
+		auctions := &auctiontest.MockAuctionRepository{}
+		auctions.On("UpdateBidState", mock.Anything, "with-bids", state).Return(nil).Once()
+		auctions.On("UpdateBidState", mock.Anything, "without-bids", auction_entity.AuctionBidState{}).Return(nil).Once()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "values", Value: bson.A{"with-bids", "without-bids"}}),
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, bidState("with-bids", state)))
+
+		backfilled, err := bid.NewBidRepository(mt.DB, auctions).BackfillBidState(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), backfilled)
+
+		// Leilões já preenchidos não são recalculados
+		distinct := mt.GetStartedEvent().Command
+		assert.Equal(t, "auctions", distinct.Lookup("distinct").StringValue())
+		assert.False(t, distinct.Lookup("query", "bid_count", "$exists").Boolean())
+
+		assertBidStateAggregation(t, mt.GetStartedEvent().Command,
+			bson.M{"auction_id": bson.M{"$in": bson.A{"with-bids", "without-bids"}}})
+		auctions.AssertExpectations(t)
+	})
+
+	mt.Run("backfill without auctions", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "values", Value: bson.A{}}))
+
+		backfilled, err := bid.NewBidRepository(mt.DB, &auctiontest.MockAuctionRepository{}).BackfillBidState(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), backfilled)
+		mt.GetStartedEvent()
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+}
@@ -0,0 +1,135 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DeadLetterBidMongo struct {
+	Id        string  `bson:"_id"`
+	UserId    string  `bson:"user_id"`
+	AuctionId string  `bson:"auction_id"`
+	Amount    float64 `bson:"amount"`
+	Timestamp int64   `bson:"timestamp"`
+	Error     string  `bson:"error"`
+	Attempts  int     `bson:"attempts"`
+	FailedAt  int64   `bson:"failed_at"`
+}
+
+type DeadLetterRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewDeadLetterRepository(database *mongo.Database) *DeadLetterRepository {
+	return &DeadLetterRepository{
+		Collection: database.Collection("bids_dead_letter"),
+	}
+}
+
+func (dr *DeadLetterRepository) SaveDeadLetter(
+	ctx context.Context,
+	deadLetter bid_entity.DeadLetterBid) *internal_error.InternalError {
+	deadLetterMongo := &DeadLetterBidMongo{
+		Id:        deadLetter.Bid.Id,
+		UserId:    deadLetter.Bid.UserId,
+		AuctionId: deadLetter.Bid.AuctionId,
+		Amount:    deadLetter.Bid.Amount,
+		Timestamp: deadLetter.Bid.Timestamp.Unix(),
+		Error:     deadLetter.Error,
+		Attempts:  deadLetter.Attempts,
+		FailedAt:  deadLetter.FailedAt.Unix(),
+	}
+
+	filter := bson.M{"_id": deadLetterMongo.Id}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := dr.Collection.ReplaceOne(ctx, filter, deadLetterMongo, opts); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to save dead letter bid %s", deadLetterMongo.Id), err)
+		return internal_error.NewInternalServerError("Error trying to save dead letter bid")
+	}
+
+	return nil
+}
+
+func (dr *DeadLetterRepository) FindDeadLetters(
+	ctx context.Context) ([]bid_entity.DeadLetterBid, *internal_error.InternalError) {
+	opts := options.Find().SetSort(bson.D{{Key: "failed_at", Value: 1}})
+
+	cursor, err := dr.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logger.Error("Error trying to find dead letter bids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find dead letter bids")
+	}
+	defer cursor.Close(ctx)
+
+	var deadLettersMongo []DeadLetterBidMongo
+	if err := cursor.All(ctx, &deadLettersMongo); err != nil {
+		logger.Error("Error trying to decode dead letter bids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find dead letter bids")
+	}
+
+	var deadLetters []bid_entity.DeadLetterBid
+	for _, deadLetterMongo := range deadLettersMongo {
+		deadLetters = append(deadLetters, deadLetterMongo.toEntity())
+	}
+
+	return deadLetters, nil
+}
+
+func (dr *DeadLetterRepository) FindDeadLetterByBidId(
+	ctx context.Context, bidId string) (*bid_entity.DeadLetterBid, *internal_error.InternalError) {
+	filter := bson.M{"_id": bidId}
+
+	var deadLetterMongo DeadLetterBidMongo
+	if err := dr.Collection.FindOne(ctx, filter).Decode(&deadLetterMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Dead letter bid not found with this id = %s", bidId))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find dead letter bid %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find dead letter bid")
+	}
+
+	deadLetter := deadLetterMongo.toEntity()
+	return &deadLetter, nil
+}
+
+func (dr *DeadLetterRepository) DeleteDeadLetter(
+	ctx context.Context, bidId string) *internal_error.InternalError {
+	result, err := dr.Collection.DeleteOne(ctx, bson.M{"_id": bidId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to delete dead letter bid %s", bidId), err)
+		return internal_error.NewInternalServerError("Error trying to delete dead letter bid")
+	}
+
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Dead letter bid not found with this id = %s", bidId))
+	}
+
+	return nil
+}
+
+func (dm DeadLetterBidMongo) toEntity() bid_entity.DeadLetterBid {
+	return bid_entity.DeadLetterBid{
+		Bid: bid_entity.Bid{
+			Id:        dm.Id,
+			UserId:    dm.UserId,
+			AuctionId: dm.AuctionId,
+			Amount:    dm.Amount,
+			Timestamp: time.Unix(dm.Timestamp, 0),
+		},
+		Error:    dm.Error,
+		Attempts: dm.Attempts,
+		FailedAt: time.Unix(dm.FailedAt, 0),
+	}
+}
@@ -2,6 +2,7 @@ package bid
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/bid_entity"
@@ -9,59 +10,196 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// winningBidSort ordena os lances do vencedor para o último colocado: maior
+// valor e, no empate, o mais antigo. Lances gravados no mesmo segundo
+// desempatam pelo _id, para que a liderança, a liquidação e as avaliações
+// apontem sempre o mesmo vencedor.
+var winningBidSort = bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}
+
+var bidSorts = map[bid_entity.BidSort]bson.D{
+	bid_entity.BidSortNewest:  {{Key: "timestamp", Value: -1}, {Key: "_id", Value: 1}},
+	bid_entity.BidSortHighest: winningBidSort,
+}
+
 func (bd *BidRepository) FindBidByAuctionId(
-	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+	ctx context.Context,
+	auctionId string,
+	sort bid_entity.BidSort,
+	page, limit int64) ([]bid_entity.Bid, int64, *internal_error.InternalError) {
+	sortKeys, ok := bidSorts[sort]
+	if !ok {
+		return nil, 0, internal_error.NewBadRequestError("Invalid bid sort")
+	}
+
 	filter := bson.M{"auction_id": auctionId}
 
-	cursor, err := bd.Collection.Find(ctx, filter)
+	total, err := bd.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Error trying to count bids by auctionId %s", auctionId), err)
+		return nil, 0, internal_error.NewInternalServerError(
+			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
+	}
+
+	opts := options.Find().SetSort(sortKeys).SetSkip((page - 1) * limit).SetLimit(limit)
+	cursor, err := bd.Collection.Find(ctx, filter, opts)
 	if err != nil {
 		logger.Error(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
-		return nil, internal_error.NewInternalServerError(
+		return nil, 0, internal_error.NewInternalServerError(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
 	}
+	defer cursor.Close(ctx)
 
 	var bidEntitiesMongo []BidEntityMongo
 	if err := cursor.All(ctx, &bidEntitiesMongo); err != nil {
 		logger.Error(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
-		return nil, internal_error.NewInternalServerError(
+		return nil, 0, internal_error.NewInternalServerError(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
 	}
 
-	var bidEntities []bid_entity.Bid
+	bidEntities := make([]bid_entity.Bid, 0, len(bidEntitiesMongo))
 	for _, bidEntityMongo := range bidEntitiesMongo {
-		bidEntities = append(bidEntities, bid_entity.Bid{
-			Id:        bidEntityMongo.Id,
-			UserId:    bidEntityMongo.UserId,
-			AuctionId: bidEntityMongo.AuctionId,
-			Amount:    bidEntityMongo.Amount,
-			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-		})
+		bidEntities = append(bidEntities, bidEntityMongo.toEntity())
+	}
+
+	return bidEntities, total, nil
+}
+
+type bidSummaryMongo struct {
+	Count         int64   `bson:"count"`
+	UniqueBidders int64   `bson:"unique_bidders"`
+	HighBid       float64 `bson:"high_bid"`
+	OpeningBid    float64 `bson:"opening_bid"`
+	LastBidAt     int64   `bson:"last_bid_at"`
+}
+
+func (bd *BidRepository) FindBidSummary(
+	ctx context.Context, auctionId string) (*bid_entity.BidSummary, *internal_error.InternalError) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"auction_id": auctionId, "voided": bson.M{"$ne": true}}}},
+		// Ordenados do mais antigo, o primeiro lance do grupo é o de abertura
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         nil,
+			"count":       bson.M{"$sum": 1},
+			"bidders":     bson.M{"$addToSet": "$user_id"},
+			"high_bid":    bson.M{"$max": "$amount"},
+			"opening_bid": bson.M{"$first": "$amount"},
+			"last_bid_at": bson.M{"$max": "$timestamp"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":            0,
+			"count":          1,
+			"unique_bidders": bson.M{"$size": "$bidders"},
+			"high_bid":       1,
+			"opening_bid":    1,
+			"last_bid_at":    1,
+		}}},
+	}
+
+	cursor, err := bd.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to summarize bids of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to summarize auction bids")
+	}
+	defer cursor.Close(ctx)
+
+	var summaries []bidSummaryMongo
+	if err := cursor.All(ctx, &summaries); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode bid summary of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to summarize auction bids")
+	}
+
+	summary := &bid_entity.BidSummary{}
+	if len(summaries) > 0 {
+		summary.Count = summaries[0].Count
+		summary.UniqueBidders = summaries[0].UniqueBidders
+		summary.HighBid = summaries[0].HighBid
+		summary.OpeningBid = summaries[0].OpeningBid
+		summary.LastBidAt = time.Unix(summaries[0].LastBidAt, 0)
 	}
 
-	return bidEntities, nil
+	return summary, nil
 }
 
 func (bd *BidRepository) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
-	filter := bson.M{"auction_id": auctionId}
+	// Lances anulados por um administrador não podem vencer
+	filter := bson.M{"auction_id": auctionId, "voided": bson.M{"$ne": true}}
 
 	var bidEntityMongo BidEntityMongo
-	opts := options.FindOne().SetSort(bson.D{{"amount", -1}})
+	opts := options.FindOne().SetSort(winningBidSort)
 	if err := bd.Collection.FindOne(ctx, filter, opts).Decode(&bidEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("No valid bids found for auction %s", auctionId))
+		}
+
 		logger.Error("Error trying to find the auction winner", err)
 		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
 	}
 
-	return &bid_entity.Bid{
-		Id:        bidEntityMongo.Id,
-		UserId:    bidEntityMongo.UserId,
-		AuctionId: bidEntityMongo.AuctionId,
-		Amount:    bidEntityMongo.Amount,
-		Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-	}, nil
+	bidEntity := bidEntityMongo.toEntity()
+	return &bidEntity, nil
+}
+
+func (bd *BidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	var bidEntityMongo BidEntityMongo
+	if err := bd.Collection.FindOne(ctx, bson.M{"_id": bidId}).Decode(&bidEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Bid not found with this id = %s", bidId)).
+				WithCode(internal_error.CodeBidNotFound)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find bid by id = %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find bid by id")
+	}
+
+	bidEntity := bidEntityMongo.toEntity()
+	return &bidEntity, nil
+}
+
+func (bd *BidRepository) VoidBid(
+	ctx context.Context, bidId string) *internal_error.InternalError {
+	filter := bson.M{"_id": bidId}
+	update := bson.M{"$set": bson.M{"voided": true}}
+
+	result, err := bd.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to void bid %s", bidId), err)
+		return internal_error.NewInternalServerError("Error trying to void bid")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Bid not found with this id = %s", bidId)).
+			WithCode(internal_error.CodeBidNotFound)
+	}
+
+	// O lance anulado pode ser o maior do leilão
+	var bidEntityMongo BidEntityMongo
+	if err := bd.Collection.FindOne(ctx, filter).Decode(&bidEntityMongo); err == nil {
+		bd.refreshBidState(ctx, bidEntityMongo.AuctionId)
+	}
+
+	return nil
+}
+
+func (bm BidEntityMongo) toEntity() bid_entity.Bid {
+	return bid_entity.Bid{
+		Id:        bm.Id,
+		UserId:    bm.UserId,
+		AuctionId: bm.AuctionId,
+		Amount:    bm.Amount,
+		Timestamp: time.Unix(bm.Timestamp, 0),
+		Voided:    bm.Voided,
+	}
 }
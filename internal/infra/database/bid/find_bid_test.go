@@ -0,0 +1,130 @@
+package bid_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func TestFindWinningBidByAuctionId_SkipsVoidedBidsAndReportsNotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("winner", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, document(bid.BidEntityMongo{
+			Id: "early", UserId: "u1", AuctionId: "a1", Amount: 50, Timestamp: 100})))
+
+		winner, err := bid.NewBidRepository(mt.DB, nil).FindWinningBidByAuctionId(context.Background(), "a1")
+		assert.Nil(t, err)
+		assert.Equal(t, "early", winner.Id)
+		assert.Equal(t, 50.0, winner.Amount)
+
+		// Lances anulados por um administrador não podem vencer, e no empate
+		// vence o lance mais antigo e, no mesmo segundo, o de menor _id
+		command := mt.GetStartedEvent().Command
+		var filter bson.M
+		bson.Unmarshal(command.Lookup("filter").Document(), &filter)
+		assert.Equal(t, normalize(bson.M{"auction_id": "a1", "voided": bson.M{"$ne": true}}), filter)
+		assert.Equal(t, []string{"amount:-1", "timestamp:1", "_id:1"}, sortKeys(command.Lookup("sort").Document()))
+	})
+
+	mt.Run("tie", func(mt *mtest.T) {
+		// Dois lances iguais no mesmo segundo: o líder do histórico e da
+		// listagem por valor é o mesmo que vence o leilão
+		winner := []string{"amount:-1", "timestamp:1", "_id:1"}
+		repository := bid.NewBidRepository(mt.DB, nil)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, document(bid.BidEntityMongo{
+			Id: "b1", UserId: "u1", AuctionId: "a1", Amount: 50, Timestamp: 100})))
+		repository.FindWinningBidByAuctionId(context.Background(), "a1")
+		assert.Equal(t, winner, sortKeys(mt.GetStartedEvent().Command.Lookup("sort").Document()))
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, bson.D{{Key: "n", Value: int32(2)}}),
+			mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch))
+		repository.FindBidByAuctionId(context.Background(), "a1", bid_entity.BidSortHighest, 1, 10)
+		mt.GetStartedEvent()
+		assert.Equal(t, winner, sortKeys(mt.GetStartedEvent().Command.Lookup("sort").Document()))
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, userBidsPage(0)))
+		repository.FindBidsByUserId(context.Background(), "u1", bid_entity.OutcomeActive, 1, 10)
+		var leadingSort []string
+		for _, stage := range pipelineStages(mt.GetStartedEvent().Command) {
+			if from, err := stage.LookupErr("$lookup", "from"); err == nil && from.StringValue() == "bids" {
+				leading, _ := stage.Lookup("$lookup", "pipeline").Array().Values()
+				leadingSort = sortKeys(leading[1].Document().Lookup("$sort").Document())
+			}
+		}
+		assert.Equal(t, winner, leadingSort)
+	})
+
+	mt.Run("no valid bids", func(mt *mtest.T) {
+		// Leilões sem lances válidos não têm vencedor, o que não é uma falha
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch))
+
+		winner, err := bid.NewBidRepository(mt.DB, nil).FindWinningBidByAuctionId(context.Background(), "a2")
+		assert.Nil(t, winner)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, "not_found", err.Err)
+		}
+	})
+}
+
+func TestFindBidById_ReportsUnknownBidsAsNotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("unknown bid", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch))
+
+		_, err := bid.NewBidRepository(mt.DB, nil).FindBidById(context.Background(), "missing")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, "not_found", err.Err)
+			assert.Equal(t, internal_error.CodeBidNotFound, err.Code)
+		}
+	})
+}
+
+func TestFindBidSummary_AggregatesValidBids(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("summary", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, bson.D{
+			{Key: "count", Value: int64(3)},
+			{Key: "unique_bidders", Value: int32(2)},
+			{Key: "high_bid", Value: 35.0},
+			{Key: "opening_bid", Value: 10.0},
+			{Key: "last_bid_at", Value: int64(300)},
+		}))
+
+		summary, err := bid.NewBidRepository(mt.DB, nil).FindBidSummary(context.Background(), "a1")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), summary.Count)
+		assert.Equal(t, int64(2), summary.UniqueBidders)
+		assert.Equal(t, 35.0, summary.HighBid)
+		assert.Equal(t, 10.0, summary.OpeningBid)
+		assert.Equal(t, int64(300), summary.LastBidAt.Unix())
+
+		// O lance anulado não conta, e no empate de horário o lance de
+		// abertura é o de menor _id
+		stages := pipelineStages(mt.GetStartedEvent().Command)
+		var match bson.M
+		bson.Unmarshal(stages[0].Lookup("$match").Document(), &match)
+		assert.Equal(t, normalize(bson.M{"auction_id": "a1", "voided": bson.M{"$ne": true}}), match)
+		assert.Equal(t, []string{"timestamp:1", "_id:1"}, sortKeys(stages[1].Lookup("$sort").Document()))
+	})
+
+	mt.Run("no valid bids", func(mt *mtest.T) {
+		// Sem lances válidos o resumo vem zerado
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch))
+
+		summary, err := bid.NewBidRepository(mt.DB, nil).FindBidSummary(context.Background(), "empty")
+		assert.Nil(t, err)
+		assert.Zero(t, *summary)
+	})
+}
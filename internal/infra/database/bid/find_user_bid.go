@@ -0,0 +1,241 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userBidMongo struct {
+	BidEntityMongo `bson:",inline"`
+	Auction        userBidAuctionMongo      `bson:"auction"`
+	Leading        bool                     `bson:"leading"`
+	Settlement     wallet_entity.HoldStatus `bson:"settlement"`
+}
+
+type userBidAuctionMongo struct {
+	Id           string                       `bson:"_id"`
+	SellerId     string                       `bson:"seller_id"`
+	ProductName  string                       `bson:"product_name"`
+	Category     string                       `bson:"category"`
+	Status       auction_entity.AuctionStatus `bson:"status"`
+	Timestamp    int64                        `bson:"timestamp"`
+	ReservePrice float64                      `bson:"reserve_price"`
+}
+
+type userBidPageMongo struct {
+	Bids  []userBidMongo `bson:"bids"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+// CreateIndexes cria os índices do histórico por usuário, da listagem dos
+// lances de um leilão e da busca do maior lance de cada leilão, usada também
+// na junção do histórico e na ordenação por valor. O índice do maior lance
+// sem o desempate por _id é removido, pois não serve mais à ordenação.
+func (bd *BidRepository) CreateIndexes(ctx context.Context) error {
+	if _, err := bd.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("user_id_timestamp"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "auction_id", Value: 1},
+				{Key: "amount", Value: -1},
+				{Key: "timestamp", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("auction_id_amount_timestamp_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "auction_id", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("auction_id_timestamp"),
+		},
+	}); err != nil {
+		return err
+	}
+
+	_, err := bd.Collection.Indexes().DropOne(ctx, "auction_id_amount")
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Name == "IndexNotFound" {
+		return nil
+	}
+	return err
+}
+
+func (bd *BidRepository) FindBidsByUserId(
+	ctx context.Context,
+	userId string,
+	outcome bid_entity.BidOutcome,
+	page, limit int64) ([]bid_entity.UserBid, int64, *internal_error.InternalError) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId}}},
+	}
+
+	// Antes da paginação entram só as junções de que o filtro da situação
+	// precisa; o restante é feito apenas para os lances da página
+	joinedAuction, joinedLeading := false, false
+	if outcome != "" {
+		if outcome != bid_entity.OutcomeVoided {
+			pipeline = append(pipeline, auctionStages()...)
+			joinedAuction = true
+		}
+		if outcomeNeedsLeading(outcome) {
+			pipeline = append(pipeline, leadingStages()...)
+			joinedLeading = true
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: outcomeFilter(outcome)}})
+	}
+
+	pageStages := bson.A{bson.M{"$skip": (page - 1) * limit}, bson.M{"$limit": limit}}
+	if !joinedAuction {
+		for _, stage := range auctionStages() {
+			pageStages = append(pageStages, stage)
+		}
+	}
+	if !joinedLeading {
+		for _, stage := range leadingStages() {
+			pageStages = append(pageStages, stage)
+		}
+	}
+	pageStages = append(pageStages,
+		bson.M{"$lookup": bson.M{
+			"from":         "wallet_holds",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "hold",
+		}},
+		bson.M{"$addFields": bson.M{"settlement": bson.M{"$first": "$hold.status"}}},
+	)
+
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"bids":  pageStages,
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	)
+
+	cursor, err := bd.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find bids of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find user bids")
+	}
+	defer cursor.Close(ctx)
+
+	var pages []userBidPageMongo
+	if err := cursor.All(ctx, &pages); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode bids of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find user bids")
+	}
+
+	userBids := []bid_entity.UserBid{}
+	var total int64
+	if len(pages) > 0 {
+		if len(pages[0].Total) > 0 {
+			total = pages[0].Total[0].Count
+		}
+
+		for _, userBid := range pages[0].Bids {
+			userBids = append(userBids, bid_entity.UserBid{
+				Bid: userBid.BidEntityMongo.toEntity(),
+				Auction: auction_entity.Auction{
+					Id:           userBid.Auction.Id,
+					SellerId:     userBid.Auction.SellerId,
+					ProductName:  userBid.Auction.ProductName,
+					Category:     userBid.Auction.Category,
+					Status:       userBid.Auction.Status,
+					Timestamp:    time.Unix(userBid.Auction.Timestamp, 0),
+					ReservePrice: userBid.Auction.ReservePrice,
+				},
+				Leading:    userBid.Leading,
+				Settlement: userBid.Settlement,
+			})
+		}
+	}
+
+	return userBids, total, nil
+}
+
+// auctionStages junta o leilão do lance. Lances de leilões removidos são
+// mantidos, para que a página tenha o tamanho que o total indica.
+func auctionStages() []bson.D {
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "auctions",
+			"localField":   "auction_id",
+			"foreignField": "_id",
+			"as":           "auction",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$auction", "preserveNullAndEmptyArrays": true}}},
+	}
+}
+
+// leadingStages marca se o lance é o maior lance válido do leilão, com o
+// mesmo desempate do vencedor.
+func leadingStages() []bson.D {
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.M{
+			"from": "bids",
+			"let":  bson.M{"auctionId": "$auction_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":  bson.M{"$eq": bson.A{"$auction_id", "$$auctionId"}},
+					"voided": bson.M{"$ne": true},
+				}},
+				bson.M{"$sort": winningBidSort},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "leading_bid",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"leading": bson.M{"$eq": bson.A{bson.M{"$first": "$leading_bid._id"}, "$_id"}},
+		}}},
+	}
+}
+
+// outcomeNeedsLeading indica se o filtro da situação depende da liderança.
+func outcomeNeedsLeading(outcome bid_entity.BidOutcome) bool {
+	return outcome != bid_entity.OutcomeVoided && outcome != bid_entity.OutcomeCancelled
+}
+
+// outcomeFilter traduz a situação do lance (ver bid_entity.UserBid.Outcome)
+// em um filtro sobre o status do leilão e a liderança do lance.
+func outcomeFilter(outcome bid_entity.BidOutcome) bson.M {
+	notVoided := bson.M{"$ne": true}
+	// Leilões sem reserva gravada não têm reserva a alcançar
+	reserveMet := bson.M{"$gte": bson.A{"$amount", bson.M{"$ifNull": bson.A{"$auction.reserve_price", 0}}}}
+
+	switch outcome {
+	case bid_entity.OutcomeVoided:
+		return bson.M{"voided": true}
+	case bid_entity.OutcomeCancelled:
+		return bson.M{"voided": notVoided, "auction.status": auction_entity.Cancelled}
+	case bid_entity.OutcomeWon:
+		return bson.M{"voided": notVoided, "auction.status": auction_entity.Completed, "leading": true,
+			"$expr": reserveMet}
+	case bid_entity.OutcomeLost:
+		// O líder abaixo da reserva também perde: o leilão terminou sem venda
+		return bson.M{"voided": notVoided, "auction.status": auction_entity.Completed, "$or": bson.A{
+			bson.M{"leading": false},
+			bson.M{"$expr": bson.M{"$not": reserveMet}},
+		}}
+	case bid_entity.OutcomeActive:
+		return bson.M{"voided": notVoided, "auction.status": auction_entity.Active, "leading": true}
+	default:
+		return bson.M{"voided": notVoided, "auction.status": auction_entity.Active, "leading": false}
+	}
+}
@@ -0,0 +1,173 @@
+package bid_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/infra/database/bid"
+)
+
+// userBid é um lance da página, com o leilão, a liderança e a liquidação
+// juntados pela agregação.
+func userBid(id, auctionId string, status auction_entity.AuctionStatus, leading, voided bool,
+	settlement wallet_entity.HoldStatus) bson.M {
+	doc := bson.M{
+		"_id": id, "user_id": "u1", "auction_id": auctionId, "amount": 10.0, "timestamp": int64(100),
+		"auction": bson.M{"_id": auctionId, "product_name": "product " + auctionId, "status": status},
+		"leading": leading,
+	}
+	if voided {
+		doc["voided"] = true
+	}
+	if settlement != "" {
+		doc["settlement"] = settlement
+	}
+	return doc
+}
+
+// userBidsPage responde à agregação com o resultado do $facet.
+func userBidsPage(total int64, bids ...bson.M) bson.D {
+	page := bson.A{}
+	for _, bidDocument := range bids {
+		page = append(page, bidDocument)
+	}
+	return document(bson.M{"bids": page, "total": bson.A{bson.M{"count": total}}})
+}
+
+// stagesBeforePaging devolve o nome de cada estágio anterior ao $facet, em
+// que a página é recortada.
+func stagesBeforePaging(command bson.Raw) ([]string, bson.Raw) {
+	var names []string
+	var lastMatch bson.Raw
+	for _, stage := range pipelineStages(command) {
+		element, _ := stage.IndexErr(0)
+		if element.Key() == "$facet" {
+			break
+		}
+		names = append(names, element.Key())
+		if element.Key() == "$match" {
+			lastMatch = element.Value().Document()
+		}
+	}
+	return names, lastMatch
+}
+
+func TestFindBidsByUserId_JoinsAuctionLeadershipAndSettlement(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("every bid", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, userBidsPage(4,
+			userBid("voided", "active", auction_entity.Active, false, true, ""),
+			userBid("refunded", "cancelled", auction_entity.Cancelled, true, false, wallet_entity.Released),
+			userBid("won", "completed", auction_entity.Completed, true, false, wallet_entity.Captured),
+			userBid("outbid", "active", auction_entity.Active, false, false, wallet_entity.Released),
+		)))
+
+		userBids, total, err := bid.NewBidRepository(mt.DB, nil).FindBidsByUserId(context.Background(), "u1", "", 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), total)
+		if assert.Len(t, userBids, 4) {
+			assert.Equal(t, "voided", userBids[0].Bid.Id)
+			assert.Equal(t, bid_entity.OutcomeVoided, userBids[0].Outcome())
+			assert.Empty(t, userBids[0].Settlement)
+
+			assert.Equal(t, bid_entity.OutcomeCancelled, userBids[1].Outcome())
+			assert.Equal(t, wallet_entity.Released, userBids[1].Settlement)
+
+			assert.Equal(t, bid_entity.OutcomeWon, userBids[2].Outcome())
+			assert.Equal(t, "product completed", userBids[2].Auction.ProductName)
+			assert.Equal(t, wallet_entity.Captured, userBids[2].Settlement)
+
+			assert.Equal(t, bid_entity.OutcomeOutbid, userBids[3].Outcome())
+			assert.Equal(t, wallet_entity.Released, userBids[3].Settlement)
+		}
+	})
+
+	mt.Run("below the reserve", func(mt *mtest.T) {
+		leading := userBid("unsold", "completed", auction_entity.Completed, true, false, wallet_entity.Released)
+		leading["auction"].(bson.M)["reserve_price"] = 50.0
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, userBidsPage(1, leading)))
+
+		userBids, _, err := bid.NewBidRepository(mt.DB, nil).FindBidsByUserId(context.Background(), "u1", "", 1, 10)
+		assert.Nil(t, err)
+		if assert.Len(t, userBids, 1) {
+			assert.Equal(t, 50.0, userBids[0].Auction.ReservePrice)
+			assert.Equal(t, bid_entity.OutcomeLost, userBids[0].Outcome())
+		}
+	})
+
+	mt.Run("no bids", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch,
+			document(bson.M{"bids": bson.A{}, "total": bson.A{}})))
+
+		userBids, total, err := bid.NewBidRepository(mt.DB, nil).FindBidsByUserId(context.Background(), "u1", "", 1, 10)
+		assert.Nil(t, err)
+		assert.Zero(t, total)
+		assert.NotNil(t, userBids)
+		assert.Empty(t, userBids)
+	})
+
+	mt.Run("pages before joining", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, userBidsPage(4)))
+
+		bid.NewBidRepository(mt.DB, nil).FindBidsByUserId(context.Background(), "u1", "", 2, 1)
+
+		// Sem filtro de situação, nenhuma junção acontece antes do $skip/$limit,
+		// e as mais recentes vêm primeiro
+		command := mt.GetStartedEvent().Command
+		names, _ := stagesBeforePaging(command)
+		assert.Equal(t, []string{"$match", "$sort"}, names)
+		stages := pipelineStages(command)
+		assert.Equal(t, []string{"timestamp:-1", "_id:1"}, sortKeys(stages[1].Lookup("$sort").Document()))
+
+		page, _ := stages[2].Lookup("$facet", "bids").Array().Values()
+		if assert.Greater(t, len(page), 2) {
+			assert.Equal(t, int64(1), page[0].Document().Lookup("$skip").AsInt64())
+			assert.Equal(t, int64(1), page[1].Document().Lookup("$limit").AsInt64())
+		}
+	})
+
+	mt.Run("outcome filter", func(mt *mtest.T) {
+		notVoided := bson.M{"$ne": true}
+		reserveMet := bson.M{"$gte": bson.A{"$amount", bson.M{"$ifNull": bson.A{"$auction.reserve_price", 0}}}}
+		joinsEverything := []string{"$match", "$lookup", "$unwind", "$lookup", "$addFields", "$match", "$sort"}
+
+		for outcome, want := range map[bid_entity.BidOutcome]struct {
+			stages []string
+			match  bson.M
+		}{
+			// O líder abaixo da reserva não venceu: o leilão terminou sem venda
+			bid_entity.OutcomeWon: {joinsEverything, bson.M{
+				"voided": notVoided, "auction.status": auction_entity.Completed, "leading": true,
+				"$expr": reserveMet}},
+			bid_entity.OutcomeLost: {joinsEverything, bson.M{
+				"voided": notVoided, "auction.status": auction_entity.Completed, "$or": bson.A{
+					bson.M{"leading": false}, bson.M{"$expr": bson.M{"$not": reserveMet}}}}},
+			bid_entity.OutcomeActive: {joinsEverything, bson.M{
+				"voided": notVoided, "auction.status": auction_entity.Active, "leading": true}},
+			bid_entity.OutcomeOutbid: {joinsEverything, bson.M{
+				"voided": notVoided, "auction.status": auction_entity.Active, "leading": false}},
+			// A liderança não importa em um leilão cancelado, e o lance anulado
+			// não depende do leilão
+			bid_entity.OutcomeCancelled: {[]string{"$match", "$lookup", "$unwind", "$match", "$sort"}, bson.M{
+				"voided": notVoided, "auction.status": auction_entity.Cancelled}},
+			bid_entity.OutcomeVoided: {[]string{"$match", "$match", "$sort"}, bson.M{"voided": true}},
+		} {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, userBidsPage(0)))
+			bid.NewBidRepository(mt.DB, nil).FindBidsByUserId(context.Background(), "u1", outcome, 1, 10)
+
+			names, lastMatch := stagesBeforePaging(mt.GetStartedEvent().Command)
+			assert.Equal(t, want.stages, names, outcome)
+			var match bson.M
+			bson.Unmarshal(lastMatch, &match)
+			assert.Equal(t, normalize(want.match), match, outcome)
+		}
+	})
+}
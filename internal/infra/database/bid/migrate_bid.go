@@ -0,0 +1,36 @@
+package bid
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BackfillBidState preenche preço atual, quantidade de lances e líder dos
+// leilões criados antes desses campos existirem, com a mesma agregação do
+// recálculo feito a cada lance. Roda pelo comando migrate, não na subida da
+// API, e devolve quantos leilões foram preenchidos; repetir a migração não
+// altera leilões já preenchidos.
+func (bd *BidRepository) BackfillBidState(ctx context.Context) (int64, error) {
+	auctionIds, err := bd.Collection.Database().Collection("auctions").
+		Distinct(ctx, "_id", bson.M{"bid_count": bson.M{"$exists": false}})
+	if err != nil || len(auctionIds) == 0 {
+		return 0, err
+	}
+
+	states, err := bd.findBidStates(ctx, bson.M{"auction_id": bson.M{"$in": auctionIds}})
+	if err != nil {
+		return 0, err
+	}
+
+	var backfilled int64
+	for _, value := range auctionIds {
+		auctionId, _ := value.(string)
+		if err := bd.AuctionRepository.UpdateBidState(ctx, auctionId, states[auctionId]); err != nil {
+			return backfilled, err
+		}
+		backfilled++
+	}
+
+	return backfilled, nil
+}
@@ -0,0 +1,254 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CategoryMongo guarda o caminho completo ao lado do parent_id: é por ele que
+// os leilões referenciam a categoria e que a subárvore é filtrada. ChildCount
+// e AuctionCount contam as subcategorias e os leilões da categoria; a remoção
+// só apaga a categoria com as duas contagens zeradas, no mesmo comando.
+type CategoryMongo struct {
+	Id        string `bson:"_id"`
+	Slug      string `bson:"slug"`
+	Name      string `bson:"name"`
+	ParentId  string `bson:"parent_id,omitempty"`
+	Path      string `bson:"path"`
+	Active    bool   `bson:"active"`
+	CreatedAt int64  `bson:"created_at"`
+	UpdatedAt int64  `bson:"updated_at"`
+
+	ChildCount   int64 `bson:"child_count"`
+	AuctionCount int64 `bson:"auction_count"`
+}
+
+type CategoryRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewCategoryRepository(database *mongo.Database) *CategoryRepository {
+	return &CategoryRepository{
+		Collection: database.Collection("categories"),
+	}
+}
+
+// CreateIndexes garante que cada caminho exista uma única vez; irmãos não
+// podem repetir o slug.
+func (cr *CategoryRepository) CreateIndexes(ctx context.Context) error {
+	_, err := cr.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "path", Value: 1}},
+			Options: options.Index().SetName("path").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}},
+			Options: options.Index().SetName("parent_id"),
+		},
+	})
+	return err
+}
+
+func (cr *CategoryRepository) CreateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	// A subcategoria é contada no pai antes de existir: um pai já removido
+	// não recebe filhos, e um pai com a contagem feita não pode ser removido
+	if category.ParentId != "" {
+		if err := cr.updateCount(ctx, category.ParentId, "child_count", 1); err != nil {
+			return err
+		}
+	}
+
+	if _, err := cr.Collection.InsertOne(ctx, toCategoryMongo(category)); err != nil {
+		if category.ParentId != "" {
+			cr.updateCount(ctx, category.ParentId, "child_count", -1)
+		}
+
+		if mongo.IsDuplicateKeyError(err) {
+			return internal_error.NewConflictError(
+				fmt.Sprintf("Category %s already exists", category.Path)).
+				WithCode(internal_error.CodeCategoryExists)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to create category %s", category.Path), err)
+		return internal_error.NewInternalServerError("Error trying to create category")
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	update := bson.M{"$set": bson.M{
+		"name":       category.Name,
+		"active":     category.Active,
+		"updated_at": category.UpdatedAt.Unix(),
+	}}
+
+	result, err := cr.Collection.UpdateOne(ctx, bson.M{"_id": category.Id}, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update category %s", category.Id), err)
+		return internal_error.NewInternalServerError("Error trying to update category")
+	}
+
+	if result.MatchedCount == 0 {
+		return categoryNotFound(category.Id)
+	}
+
+	return nil
+}
+
+// DeleteCategory apaga a categoria só se as contagens de subcategorias e de
+// leilões estiverem zeradas, no mesmo comando da remoção. Categorias gravadas
+// antes das contagens existirem não são removidas até a migração contá-las.
+func (cr *CategoryRepository) DeleteCategory(
+	ctx context.Context, id string) *internal_error.InternalError {
+	filter := bson.M{"_id": id, "child_count": 0, "auction_count": 0}
+
+	var categoryMongo CategoryMongo
+	err := cr.Collection.FindOneAndDelete(ctx, filter).Decode(&categoryMongo)
+	if err == nil {
+		if categoryMongo.ParentId != "" {
+			cr.updateCount(ctx, categoryMongo.ParentId, "child_count", -1)
+		}
+		return nil
+	}
+
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		logger.Error(fmt.Sprintf("Error trying to delete category %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to delete category")
+	}
+
+	if err := cr.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&categoryMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return categoryNotFound(id)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find category %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to delete category")
+	}
+
+	if categoryMongo.ChildCount > 0 {
+		return internal_error.NewConflictError("Category has subcategories").
+			WithCode(internal_error.CodeCategoryHasChildren)
+	}
+
+	return internal_error.NewConflictError("Category has auctions").
+		WithCode(internal_error.CodeCategoryInUse)
+}
+
+func (cr *CategoryRepository) AddAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	return cr.updateCount(ctx, id, "auction_count", 1)
+}
+
+func (cr *CategoryRepository) RemoveAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	return cr.updateCount(ctx, id, "auction_count", -1)
+}
+
+// updateCount soma delta a uma das contagens da categoria.
+func (cr *CategoryRepository) updateCount(
+	ctx context.Context, id, field string, delta int64) *internal_error.InternalError {
+	result, err := cr.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{field: delta}})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update %s of category %s", field, id), err)
+		return internal_error.NewInternalServerError("Error trying to update category")
+	}
+
+	if result.MatchedCount == 0 {
+		return categoryNotFound(id)
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) FindCategoryById(
+	ctx context.Context, id string) (*category_entity.Category, *internal_error.InternalError) {
+	return cr.findOne(ctx, bson.M{"_id": id}, id)
+}
+
+func (cr *CategoryRepository) FindCategoryByPath(
+	ctx context.Context, path string) (*category_entity.Category, *internal_error.InternalError) {
+	return cr.findOne(ctx, bson.M{"path": path}, path)
+}
+
+func (cr *CategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	opts := options.Find().SetSort(bson.D{{Key: "path", Value: 1}})
+
+	cursor, err := cr.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logger.Error("Error trying to find categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find categories")
+	}
+	defer cursor.Close(ctx)
+
+	var categoriesMongo []CategoryMongo
+	if err := cursor.All(ctx, &categoriesMongo); err != nil {
+		logger.Error("Error trying to decode categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find categories")
+	}
+
+	categories := make([]category_entity.Category, 0, len(categoriesMongo))
+	for _, categoryMongo := range categoriesMongo {
+		categories = append(categories, *toCategoryEntity(categoryMongo))
+	}
+
+	return categories, nil
+}
+
+func (cr *CategoryRepository) findOne(
+	ctx context.Context, filter bson.M, key string) (*category_entity.Category, *internal_error.InternalError) {
+	var categoryMongo CategoryMongo
+	if err := cr.Collection.FindOne(ctx, filter).Decode(&categoryMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, categoryNotFound(key)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find category %s", key), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category")
+	}
+
+	return toCategoryEntity(categoryMongo), nil
+}
+
+func categoryNotFound(key string) *internal_error.InternalError {
+	return internal_error.NewNotFoundError(fmt.Sprintf("Category not found: %s", key)).
+		WithCode(internal_error.CodeCategoryNotFound)
+}
+
+func toCategoryMongo(category *category_entity.Category) *CategoryMongo {
+	return &CategoryMongo{
+		Id:        category.Id,
+		Slug:      category.Slug,
+		Name:      category.Name,
+		ParentId:  category.ParentId,
+		Path:      category.Path,
+		Active:    category.Active,
+		CreatedAt: category.CreatedAt.Unix(),
+		UpdatedAt: category.UpdatedAt.Unix(),
+	}
+}
+
+func toCategoryEntity(categoryMongo CategoryMongo) *category_entity.Category {
+	return &category_entity.Category{
+		Id:        categoryMongo.Id,
+		Slug:      categoryMongo.Slug,
+		Name:      categoryMongo.Name,
+		ParentId:  categoryMongo.ParentId,
+		Path:      categoryMongo.Path,
+		Active:    categoryMongo.Active,
+		CreatedAt: time.Unix(categoryMongo.CreatedAt, 0),
+		UpdatedAt: time.Unix(categoryMongo.UpdatedAt, 0),
+	}
+}
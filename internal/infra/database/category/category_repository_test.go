@@ -0,0 +1,204 @@
+package category_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// document converte v para o documento que o Mongo devolveria.
+func document(v interface{}) bson.D {
+	raw, _ := bson.Marshal(v)
+	var doc bson.D
+	bson.Unmarshal(raw, &doc)
+	return doc
+}
+
+// updated é a resposta de um update que casou matched documentos.
+func updated(matched int32) bson.D {
+	return mtest.CreateSuccessResponse(
+		bson.E{Key: "n", Value: matched}, bson.E{Key: "nModified", Value: matched})
+}
+
+// deleted é a resposta do findAndModify da remoção, nil quando o filtro não
+// casou com nenhuma categoria.
+func deleted(categoryMongo interface{}) bson.D {
+	if categoryMongo == nil {
+		return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: primitive.Null{}})
+	}
+	return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: document(categoryMongo)})
+}
+
+// found é a resposta de uma busca por uma categoria, vazia quando nil.
+func found(categoryMongo interface{}) bson.D {
+	if categoryMongo == nil {
+		return mtest.CreateCursorResponse(0, "db.categories", mtest.FirstBatch)
+	}
+	return mtest.CreateCursorResponse(0, "db.categories", mtest.FirstBatch, document(categoryMongo))
+}
+
+// nextUpdate devolve o filtro e a atualização do próximo update enviado.
+func nextUpdate(mt *mtest.T) (bson.Raw, bson.Raw) {
+	update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+	return update.Lookup("q").Document(), update.Lookup("u").Document()
+}
+
+func TestDeleteCategory_RefusesCategoriesInUse(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+
+	mt.Run("unused", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			deleted(category.CategoryMongo{Id: "c2", Path: "electronics/phones", ParentId: "c1"}),
+			updated(1),
+		)
+
+		assert.Nil(t, category.NewCategoryRepository(mt.DB).DeleteCategory(ctx, "c2"))
+
+		// As contagens zeradas fazem parte do próprio comando de remoção
+		findAndModify := mt.GetStartedEvent().Command
+		assert.True(t, findAndModify.Lookup("remove").Boolean())
+		assert.Equal(t, int64(0), findAndModify.Lookup("query", "child_count").AsInt64())
+		assert.Equal(t, int64(0), findAndModify.Lookup("query", "auction_count").AsInt64())
+
+		// A subcategoria removida deixa de ser contada no pai
+		filter, update := nextUpdate(mt)
+		assert.Equal(t, "c1", filter.Lookup("_id").StringValue())
+		assert.Equal(t, int64(-1), update.Lookup("$inc", "child_count").AsInt64())
+	})
+
+	for name, tc := range map[string]struct {
+		stored bson.D
+		code   string
+	}{
+		"children": {document(category.CategoryMongo{Id: "c1", ChildCount: 1}), internal_error.CodeCategoryHasChildren},
+		"auctions": {document(category.CategoryMongo{Id: "c1", AuctionCount: 2}), internal_error.CodeCategoryInUse},
+		// Categorias anteriores às contagens só saem depois da migração
+		"not counted": {bson.D{{Key: "_id", Value: "c1"}}, internal_error.CodeCategoryInUse},
+	} {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(deleted(nil), found(tc.stored))
+
+			err := category.NewCategoryRepository(mt.DB).DeleteCategory(ctx, "c1")
+			if assert.NotNil(t, err) {
+				assert.Equal(t, "conflict", err.Err)
+				assert.Equal(t, tc.code, err.Code)
+			}
+		})
+	}
+
+	mt.Run("missing", func(mt *mtest.T) {
+		mt.AddMockResponses(deleted(nil), found(nil))
+
+		err := category.NewCategoryRepository(mt.DB).DeleteCategory(ctx, "c1")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeCategoryNotFound, err.Code)
+		}
+	})
+}
+
+func TestCreateCategory_CountsTheChildBeforeInserting(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+	phones := &category_entity.Category{
+		Id: "c2", Slug: "phones", ParentId: "c1", Path: "electronics/phones", Active: true, CreatedAt: time.Now()}
+
+	mt.Run("created", func(mt *mtest.T) {
+		mt.AddMockResponses(updated(1), mtest.CreateSuccessResponse())
+
+		assert.Nil(t, category.NewCategoryRepository(mt.DB).CreateCategory(ctx, phones))
+
+		filter, update := nextUpdate(mt)
+		assert.Equal(t, "c1", filter.Lookup("_id").StringValue())
+		assert.Equal(t, int64(1), update.Lookup("$inc", "child_count").AsInt64())
+		assert.Equal(t, "insert", mt.GetStartedEvent().CommandName)
+	})
+
+	mt.Run("removed parent", func(mt *mtest.T) {
+		// Nada é criado sob uma categoria removida
+		mt.AddMockResponses(updated(0))
+
+		err := category.NewCategoryRepository(mt.DB).CreateCategory(ctx, phones)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeCategoryNotFound, err.Code)
+		}
+		mt.GetStartedEvent()
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("duplicate", func(mt *mtest.T) {
+		// A contagem feita no pai é desfeita
+		mt.AddMockResponses(
+			updated(1),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}),
+			updated(1),
+		)
+
+		err := category.NewCategoryRepository(mt.DB).CreateCategory(ctx, phones)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeCategoryExists, err.Code)
+		}
+		mt.GetStartedEvent()
+		mt.GetStartedEvent()
+		_, update := nextUpdate(mt)
+		assert.Equal(t, int64(-1), update.Lookup("$inc", "child_count").AsInt64())
+	})
+
+	mt.Run("auction in a removed category", func(mt *mtest.T) {
+		mt.AddMockResponses(updated(0))
+
+		err := category.NewCategoryRepository(mt.DB).AddAuction(ctx, "c1")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeCategoryNotFound, err.Code)
+		}
+	})
+}
+
+func TestBackfillCounts_CountsOnlyUncountedCategories(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("backfill", func(mt *mtest.T) {
+		count := func(collection string, n int32) bson.D {
+			return mtest.CreateCursorResponse(0, "db."+collection, mtest.FirstBatch, bson.D{{Key: "n", Value: n}})
+		}
+		mt.AddMockResponses(
+			found(bson.D{{Key: "_id", Value: "c1"}, {Key: "path", Value: "electronics"}}),
+			count("categories", 2),
+			count("auctions", 1),
+			updated(1),
+		)
+
+		backfilled, err := category.NewCategoryRepository(mt.DB).BackfillCounts(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), backfilled)
+
+		find := mt.GetStartedEvent().Command
+		assert.False(t, find.Lookup("filter", "auction_count", "$exists").Boolean())
+
+		children := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document()
+		assert.Equal(t, "c1", children.Lookup("$match", "parent_id").StringValue())
+
+		// Leilões antigos podem ter a categoria gravada com maiúsculas
+		auctions := mt.GetStartedEvent().Command
+		assert.Equal(t, "auctions", auctions.Lookup("aggregate").StringValue())
+		pattern, options := auctions.Lookup("pipeline").Array().Index(0).Value().Document().
+			Lookup("$match", "category").Regex()
+		assert.Equal(t, "^electronics$", pattern)
+		assert.Equal(t, "i", options)
+
+		// Uma migração concorrente que já contou a categoria não é sobrescrita
+		filter, update := nextUpdate(mt)
+		assert.False(t, filter.Lookup("auction_count", "$exists").Boolean())
+		assert.Equal(t, int64(2), update.Lookup("$set", "child_count").AsInt64())
+		assert.Equal(t, int64(1), update.Lookup("$set", "auction_count").AsInt64())
+	})
+}
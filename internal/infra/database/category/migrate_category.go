@@ -0,0 +1,55 @@
+package category
+
+import (
+	"context"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BackfillCounts conta as subcategorias e os leilões das categorias criadas
+// antes das contagens existirem. Roda pelo comando migrate e devolve quantas
+// categorias foram contadas; categorias já contadas não são alteradas.
+func (cr *CategoryRepository) BackfillCounts(ctx context.Context) (int64, error) {
+	missing := bson.M{"auction_count": bson.M{"$exists": false}}
+
+	cursor, err := cr.Collection.Find(ctx, missing)
+	if err != nil {
+		return 0, err
+	}
+
+	var categoriesMongo []CategoryMongo
+	if err := cursor.All(ctx, &categoriesMongo); err != nil {
+		return 0, err
+	}
+
+	auctions := cr.Collection.Database().Collection("auctions")
+
+	var backfilled int64
+	for _, categoryMongo := range categoriesMongo {
+		childCount, err := cr.Collection.CountDocuments(ctx, bson.M{"parent_id": categoryMongo.Id})
+		if err != nil {
+			return backfilled, err
+		}
+
+		// Mesma comparação do filtro de leilões, sem diferenciar maiúsculas
+		auctionCount, err := auctions.CountDocuments(ctx, bson.M{"category": primitive.Regex{
+			Pattern: "^" + regexp.QuoteMeta(categoryMongo.Path) + "$", Options: "i"}})
+		if err != nil {
+			return backfilled, err
+		}
+
+		filter := bson.M{"_id": categoryMongo.Id, "auction_count": bson.M{"$exists": false}}
+		result, err := cr.Collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+			"child_count":   childCount,
+			"auction_count": auctionCount,
+		}})
+		if err != nil {
+			return backfilled, err
+		}
+		backfilled += result.ModifiedCount
+	}
+
+	return backfilled, nil
+}
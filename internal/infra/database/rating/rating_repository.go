@@ -0,0 +1,263 @@
+package rating
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/rating_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RatingEntityMongo struct {
+	Id        string                  `bson:"_id"`
+	AuctionId string                  `bson:"auction_id"`
+	RaterId   string                  `bson:"rater_id"`
+	RateeId   string                  `bson:"ratee_id"`
+	RaterRole rating_entity.RaterRole `bson:"rater_role"`
+	Score     int                     `bson:"score"`
+	Comment   string                  `bson:"comment,omitempty"`
+	CreatedAt int64                   `bson:"created_at"`
+}
+
+type RatingRepository struct {
+	Collection        *mongo.Collection
+	auctionCollection *mongo.Collection
+	bidCollection     *mongo.Collection
+}
+
+func NewRatingRepository(database *mongo.Database) *RatingRepository {
+	return &RatingRepository{
+		Collection:        database.Collection("ratings"),
+		auctionCollection: database.Collection("auctions"),
+		bidCollection:     database.Collection("bids"),
+	}
+}
+
+// CreateIndexes garante uma única avaliação por lado de cada leilão e
+// indexa as avaliações recebidas por usuário.
+func (rr *RatingRepository) CreateIndexes(ctx context.Context) error {
+	_, err := rr.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "auction_id", Value: 1}, {Key: "rater_id", Value: 1}},
+			Options: options.Index().SetName("auction_id_rater_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "ratee_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("ratee_id_created_at"),
+		},
+	})
+	return err
+}
+
+func (rr *RatingRepository) CreateRating(
+	ctx context.Context, rating *rating_entity.Rating) *internal_error.InternalError {
+	ratingEntityMongo := &RatingEntityMongo{
+		Id:        rating.Id,
+		AuctionId: rating.AuctionId,
+		RaterId:   rating.RaterId,
+		RateeId:   rating.RateeId,
+		RaterRole: rating.RaterRole,
+		Score:     rating.Score,
+		Comment:   rating.Comment,
+		CreatedAt: rating.CreatedAt.Unix(),
+	}
+
+	if _, err := rr.Collection.InsertOne(ctx, ratingEntityMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return internal_error.NewConflictError("You have already rated this auction").
+				WithCode(internal_error.CodeAlreadyRated)
+		}
+
+		logger.Error("Error trying to insert rating", err)
+		return internal_error.NewInternalServerError("Error trying to insert rating")
+	}
+
+	return nil
+}
+
+func (rr *RatingRepository) FindRatingsByUserId(
+	ctx context.Context,
+	rateeId string,
+	page, limit int64) ([]rating_entity.Rating, int64, *internal_error.InternalError) {
+	filter := bson.M{"ratee_id": rateeId}
+
+	total, err := rr.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to count ratings of user %s", rateeId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find ratings")
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
+	cursor, err := rr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find ratings of user %s", rateeId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find ratings")
+	}
+	defer cursor.Close(ctx)
+
+	var ratingsMongo []RatingEntityMongo
+	if err := cursor.All(ctx, &ratingsMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode ratings of user %s", rateeId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find ratings")
+	}
+
+	ratings := make([]rating_entity.Rating, 0, len(ratingsMongo))
+	for _, ratingMongo := range ratingsMongo {
+		ratings = append(ratings, rating_entity.Rating{
+			Id:        ratingMongo.Id,
+			AuctionId: ratingMongo.AuctionId,
+			RaterId:   ratingMongo.RaterId,
+			RateeId:   ratingMongo.RateeId,
+			RaterRole: ratingMongo.RaterRole,
+			Score:     ratingMongo.Score,
+			Comment:   ratingMongo.Comment,
+			CreatedAt: time.Unix(ratingMongo.CreatedAt, 0),
+		})
+	}
+
+	return ratings, total, nil
+}
+
+// FindReputation agrega as avaliações recebidas e conta as vendas e compras
+// concluídas: leilões encerrados com um lance válido vencedor.
+func (rr *RatingRepository) FindReputation(
+	ctx context.Context, userId string) (*rating_entity.Reputation, *internal_error.InternalError) {
+	reputation := &rating_entity.Reputation{UserId: userId}
+
+	var summaries []struct {
+		Average float64 `bson:"average"`
+		Count   int64   `bson:"count"`
+	}
+	if err := rr.aggregate(ctx, rr.Collection, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"ratee_id": userId}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$score"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}, &summaries); err != nil {
+		return nil, err
+	}
+	if len(summaries) > 0 {
+		reputation.AverageScore = summaries[0].Average
+		reputation.RatingCount = summaries[0].Count
+	}
+
+	// Só conta como venda o leilão com um lance válido que alcançou a reserva
+	sales, err := rr.count(ctx, rr.auctionCollection, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"seller_id": userId, "status": auction_entity.Completed}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "bids",
+			"let": bson.M{
+				"auctionId":    "$_id",
+				"reservePrice": bson.M{"$ifNull": bson.A{"$reserve_price", 0}},
+			},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr": bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$auction_id", "$$auctionId"}},
+						bson.M{"$gte": bson.A{"$amount", "$$reservePrice"}},
+					}},
+					"voided": bson.M{"$ne": true},
+				}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "bids",
+		}}},
+		{{Key: "$match", Value: bson.M{"bids.0": bson.M{"$exists": true}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	reputation.CompletedSales = sales
+
+	purchases, err := rr.count(ctx, rr.bidCollection, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId, "voided": bson.M{"$ne": true}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$auction_id"}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "auctions",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "auction",
+		}}},
+		{{Key: "$match", Value: bson.M{"auction.status": auction_entity.Completed}}},
+		// O vencedor é o maior lance válido, com o mesmo desempate de FindWinningBidByAuctionId
+		{{Key: "$lookup", Value: bson.M{
+			"from": "bids",
+			"let":  bson.M{"auctionId": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":  bson.M{"$eq": bson.A{"$auction_id", "$$auctionId"}},
+					"voided": bson.M{"$ne": true},
+				}},
+				bson.M{"$sort": bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"user_id": 1, "amount": 1}},
+			},
+			"as": "winning_bid",
+		}}},
+		// Abaixo da reserva não houve compra
+		{{Key: "$match", Value: bson.M{
+			"winning_bid.user_id": userId,
+			"$expr": bson.M{"$gte": bson.A{
+				bson.M{"$first": "$winning_bid.amount"},
+				bson.M{"$ifNull": bson.A{bson.M{"$first": "$auction.reserve_price"}, 0}},
+			}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	reputation.CompletedPurchases = purchases
+
+	return reputation, nil
+}
+
+func (rr *RatingRepository) count(
+	ctx context.Context,
+	collection *mongo.Collection,
+	pipeline mongo.Pipeline) (int64, *internal_error.InternalError) {
+	var counts []struct {
+		Count int64 `bson:"count"`
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$count", Value: "count"}})
+	if err := rr.aggregate(ctx, collection, pipeline, &counts); err != nil {
+		return 0, err
+	}
+
+	if len(counts) == 0 {
+		return 0, nil
+	}
+	return counts[0].Count, nil
+}
+
+func (rr *RatingRepository) aggregate(
+	ctx context.Context,
+	collection *mongo.Collection,
+	pipeline mongo.Pipeline,
+	results interface{}) *internal_error.InternalError {
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error("Error trying to aggregate reputation", err)
+		return internal_error.NewInternalServerError("Error trying to find reputation")
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, results); err != nil {
+		logger.Error("Error trying to decode reputation", err)
+		return internal_error.NewInternalServerError("Error trying to find reputation")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package restriction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/infra/cache"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+)
+
+// BanCache keeps the active ban of recently checked users in memory, so the
+// ban middleware and the bid validation share one lookup per TTL. Users
+// without a ban are cached too. Banning or lifting a ban through it
+// invalidates the user.
+type BanCache struct {
+	restriction_entity.RestrictionRepositoryInterface
+
+	bans *cache.LRU[*restriction_entity.Ban]
+}
+
+func NewBanCache(
+	repository restriction_entity.RestrictionRepositoryInterface,
+	maxEntries int,
+	ttl time.Duration) *BanCache {
+	return &BanCache{
+		RestrictionRepositoryInterface: repository,
+		bans:                           cache.NewLRU[*restriction_entity.Ban](maxEntries, ttl),
+	}
+}
+
+func (bc *BanCache) FindActiveBan(
+	ctx context.Context, userId string) (*restriction_entity.Ban, *internal_error.InternalError) {
+	ban, err := bc.bans.GetOrLoad(userId, func() (*restriction_entity.Ban, *internal_error.InternalError) {
+		return bc.RestrictionRepositoryInterface.FindActiveBan(ctx, userId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A cached ban may expire before the entry does
+	if ban == nil || !ban.ActiveAt(time.Now()) {
+		return nil, nil
+	}
+
+	copied := *ban
+	return &copied, nil
+}
+
+func (bc *BanCache) BanUser(
+	ctx context.Context, ban *restriction_entity.Ban) *internal_error.InternalError {
+	defer bc.bans.Delete(ban.UserId)
+
+	return bc.RestrictionRepositoryInterface.BanUser(ctx, ban)
+}
+
+func (bc *BanCache) LiftBan(
+	ctx context.Context, userId string) *internal_error.InternalError {
+	defer bc.bans.Delete(userId)
+
+	return bc.RestrictionRepositoryInterface.LiftBan(ctx, userId)
+}
+
+func GetBanCacheMaxEntries() int {
+	value, err := strconv.Atoi(os.Getenv("BAN_CACHE_MAX_ENTRIES"))
+	if err != nil || value <= 0 {
+		return 10000
+	}
+	return value
+}
+
+func GetBanCacheTTL() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("BAN_CACHE_TTL"))
+	if err != nil || duration <= 0 {
+		return 30 * time.Second
+	}
+	return duration
+}
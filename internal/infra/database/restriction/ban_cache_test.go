@@ -0,0 +1,83 @@
+package restriction_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/infra/database/restriction"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// countingRestrictionRepository guarda os banimentos em memória e conta as leituras
+type countingRestrictionRepository struct {
+	restriction_entity.RestrictionRepositoryInterface
+	bans  map[string]restriction_entity.Ban
+	reads int
+}
+
+func (r *countingRestrictionRepository) FindActiveBan(ctx context.Context, userId string) (*restriction_entity.Ban, *internal_error.InternalError) {
+	r.reads++
+	ban, ok := r.bans[userId]
+	if !ok || !ban.ActiveAt(time.Now()) {
+		return nil, nil
+	}
+	return &ban, nil
+}
+
+func (r *countingRestrictionRepository) BanUser(ctx context.Context, ban *restriction_entity.Ban) *internal_error.InternalError {
+	r.bans[ban.UserId] = *ban
+	return nil
+}
+
+func (r *countingRestrictionRepository) LiftBan(ctx context.Context, userId string) *internal_error.InternalError {
+	delete(r.bans, userId)
+	return nil
+}
+
+func TestBanCache_BanAndLiftInvalidateTheUser(t *testing.T) {
+	repository := &countingRestrictionRepository{bans: map[string]restriction_entity.Ban{}}
+	banCache := restriction.NewBanCache(repository, 10, time.Minute)
+
+	// Quem não está banido também fica em cache
+	for i := 0; i < 3; i++ {
+		ban, err := banCache.FindActiveBan(context.Background(), "u1")
+		assert.Nil(t, err)
+		assert.Nil(t, ban)
+	}
+	assert.Equal(t, 1, repository.reads)
+
+	// O banimento vale na próxima requisição, sem esperar o TTL
+	assert.Nil(t, banCache.BanUser(context.Background(), &restriction_entity.Ban{UserId: "u1", Reason: "fraud"}))
+	ban, err := banCache.FindActiveBan(context.Background(), "u1")
+	assert.Nil(t, err)
+	if assert.NotNil(t, ban) {
+		assert.Equal(t, "fraud", ban.Reason)
+	}
+	assert.Equal(t, 2, repository.reads)
+
+	assert.Nil(t, banCache.LiftBan(context.Background(), "u1"))
+	ban, err = banCache.FindActiveBan(context.Background(), "u1")
+	assert.Nil(t, err)
+	assert.Nil(t, ban)
+	assert.Equal(t, 3, repository.reads)
+}
+
+func TestBanCache_ExpiredBanIsNotReturned(t *testing.T) {
+	repository := &countingRestrictionRepository{bans: map[string]restriction_entity.Ban{
+		"u1": {UserId: "u1", Reason: "spam", ExpiresAt: time.Now().Add(50 * time.Millisecond)},
+	}}
+	banCache := restriction.NewBanCache(repository, 10, time.Minute)
+
+	ban, _ := banCache.FindActiveBan(context.Background(), "u1")
+	assert.NotNil(t, ban)
+
+	time.Sleep(60 * time.Millisecond)
+	ban, err := banCache.FindActiveBan(context.Background(), "u1")
+	assert.Nil(t, err)
+	assert.Nil(t, ban)
+	assert.Equal(t, 1, repository.reads)
+}
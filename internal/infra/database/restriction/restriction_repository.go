@@ -0,0 +1,196 @@
+package restriction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SellerBlockMongo struct {
+	SellerId  string `bson:"seller_id"`
+	BidderId  string `bson:"bidder_id"`
+	Reason    string `bson:"reason,omitempty"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+// BanMongo usa o id do usuário como _id: cada usuário tem no máximo um banimento.
+// expires_at zero indica banimento permanente.
+type BanMongo struct {
+	UserId    string `bson:"_id"`
+	AdminId   string `bson:"admin_id"`
+	Reason    string `bson:"reason"`
+	ExpiresAt int64  `bson:"expires_at"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+type RestrictionRepository struct {
+	BlockCollection *mongo.Collection
+	BanCollection   *mongo.Collection
+}
+
+func NewRestrictionRepository(database *mongo.Database) *RestrictionRepository {
+	return &RestrictionRepository{
+		BlockCollection: database.Collection("seller_blocks"),
+		BanCollection:   database.Collection("user_bans"),
+	}
+}
+
+func (rr *RestrictionRepository) CreateIndexes(ctx context.Context) error {
+	_, err := rr.BlockCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "seller_id", Value: 1}, {Key: "bidder_id", Value: 1}},
+		Options: options.Index().SetName("seller_id_bidder_id").SetUnique(true),
+	})
+	return err
+}
+
+func (rr *RestrictionRepository) BlockBidder(
+	ctx context.Context, block *restriction_entity.SellerBlock) *internal_error.InternalError {
+	filter := bson.M{"seller_id": block.SellerId, "bidder_id": block.BidderId}
+	update := bson.M{
+		"$set":         bson.M{"reason": block.Reason},
+		"$setOnInsert": bson.M{"created_at": block.CreatedAt.Unix()},
+	}
+
+	if _, err := rr.BlockCollection.UpdateOne(
+		ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to block bidder %s", block.BidderId), err)
+		return internal_error.NewInternalServerError("Error trying to block bidder")
+	}
+
+	return nil
+}
+
+func (rr *RestrictionRepository) UnblockBidder(
+	ctx context.Context, sellerId, bidderId string) *internal_error.InternalError {
+	result, err := rr.BlockCollection.DeleteOne(
+		ctx, bson.M{"seller_id": sellerId, "bidder_id": bidderId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to unblock bidder %s", bidderId), err)
+		return internal_error.NewInternalServerError("Error trying to unblock bidder")
+	}
+
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError("Bidder is not blocked")
+	}
+
+	return nil
+}
+
+func (rr *RestrictionRepository) FindBlockedBidders(
+	ctx context.Context, sellerId string) ([]restriction_entity.SellerBlock, *internal_error.InternalError) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := rr.BlockCollection.Find(ctx, bson.M{"seller_id": sellerId}, opts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find blocked bidders of seller %s", sellerId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find blocked bidders")
+	}
+	defer cursor.Close(ctx)
+
+	var blocksMongo []SellerBlockMongo
+	if err := cursor.All(ctx, &blocksMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode blocked bidders of seller %s", sellerId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find blocked bidders")
+	}
+
+	blocks := make([]restriction_entity.SellerBlock, 0, len(blocksMongo))
+	for _, blockMongo := range blocksMongo {
+		blocks = append(blocks, restriction_entity.SellerBlock{
+			SellerId:  blockMongo.SellerId,
+			BidderId:  blockMongo.BidderId,
+			Reason:    blockMongo.Reason,
+			CreatedAt: time.Unix(blockMongo.CreatedAt, 0),
+		})
+	}
+
+	return blocks, nil
+}
+
+func (rr *RestrictionRepository) IsBidderBlocked(
+	ctx context.Context, sellerId, bidderId string) (bool, *internal_error.InternalError) {
+	count, err := rr.BlockCollection.CountDocuments(
+		ctx, bson.M{"seller_id": sellerId, "bidder_id": bidderId}, options.Count().SetLimit(1))
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to check block of bidder %s", bidderId), err)
+		return false, internal_error.NewInternalServerError("Error trying to check blocked bidders")
+	}
+
+	return count > 0, nil
+}
+
+func (rr *RestrictionRepository) BanUser(
+	ctx context.Context, ban *restriction_entity.Ban) *internal_error.InternalError {
+	banMongo := &BanMongo{
+		UserId:    ban.UserId,
+		AdminId:   ban.AdminId,
+		Reason:    ban.Reason,
+		CreatedAt: ban.CreatedAt.Unix(),
+	}
+	if !ban.Permanent() {
+		banMongo.ExpiresAt = ban.ExpiresAt.Unix()
+	}
+
+	if _, err := rr.BanCollection.ReplaceOne(
+		ctx, bson.M{"_id": ban.UserId}, banMongo, options.Replace().SetUpsert(true)); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to ban user %s", ban.UserId), err)
+		return internal_error.NewInternalServerError("Error trying to ban user")
+	}
+
+	return nil
+}
+
+func (rr *RestrictionRepository) LiftBan(
+	ctx context.Context, userId string) *internal_error.InternalError {
+	result, err := rr.BanCollection.DeleteOne(ctx, bson.M{"_id": userId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to lift ban of user %s", userId), err)
+		return internal_error.NewInternalServerError("Error trying to lift ban")
+	}
+
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError("User is not banned")
+	}
+
+	return nil
+}
+
+func (rr *RestrictionRepository) FindActiveBan(
+	ctx context.Context, userId string) (*restriction_entity.Ban, *internal_error.InternalError) {
+	filter := bson.M{
+		"_id": userId,
+		"$or": bson.A{
+			bson.M{"expires_at": 0},
+			bson.M{"expires_at": bson.M{"$gt": time.Now().Unix()}},
+		},
+	}
+
+	var banMongo BanMongo
+	if err := rr.BanCollection.FindOne(ctx, filter).Decode(&banMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find ban of user %s", userId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find ban")
+	}
+
+	ban := &restriction_entity.Ban{
+		UserId:    banMongo.UserId,
+		AdminId:   banMongo.AdminId,
+		Reason:    banMongo.Reason,
+		CreatedAt: time.Unix(banMongo.CreatedAt, 0),
+	}
+	if banMongo.ExpiresAt != 0 {
+		ban.ExpiresAt = time.Unix(banMongo.ExpiresAt, 0)
+	}
+
+	return ban, nil
+}
@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserEntityMongo struct {
+	Id        string                 `bson:"_id"`
+	Name      string                 `bson:"name"`
+	Email     string                 `bson:"email,omitempty"`
+	Status    user_entity.UserStatus `bson:"status"`
+	Roles     []user_entity.Role     `bson:"roles,omitempty"`
+	CreatedAt int64                  `bson:"created_at"`
+}
+
+type UserRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewUserRepository(database *mongo.Database) *UserRepository {
+	return &UserRepository{
+		Collection: database.Collection("users"),
+	}
+}
+
+// CreateIndexes garante a unicidade do email. Usuários antigos sem email
+// ficam fora do índice por ele ser parcial.
+func (ur *UserRepository) CreateIndexes(ctx context.Context) error {
+	_, err := ur.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().
+			SetName("email_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"email": bson.M{"$type": "string"}}),
+	})
+	return err
+}
+
+func (ur *UserRepository) CreateUser(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	userEntityMongo := &UserEntityMongo{
+		Id:        userEntity.Id,
+		Name:      userEntity.Name,
+		Email:     userEntity.Email,
+		Status:    userEntity.Status,
+		Roles:     userEntity.Roles,
+		CreatedAt: userEntity.CreatedAt.Unix(),
+	}
+
+	if _, err := ur.Collection.InsertOne(ctx, userEntityMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return internal_error.NewConflictError(
+				fmt.Sprintf("Email %s is already registered", userEntity.Email)).
+				WithCode(internal_error.CodeEmailTaken)
+		}
+
+		logger.Error("Error trying to insert user", err)
+		return internal_error.NewInternalServerError("Error trying to insert user")
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) UpdateUser(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	filter := bson.M{"_id": userEntity.Id}
+	fields := bson.M{
+		"name":  userEntity.Name,
+		"roles": userEntity.Roles,
+	}
+	// Um email vazio entraria no índice único parcial; usuários antigos seguem sem email
+	if userEntity.Email != "" {
+		fields["email"] = userEntity.Email
+	}
+	update := bson.M{"$set": fields}
+
+	result, err := ur.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return internal_error.NewConflictError(
+				fmt.Sprintf("Email %s is already registered", userEntity.Email)).
+				WithCode(internal_error.CodeEmailTaken)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to update user %s", userEntity.Id), err)
+		return internal_error.NewInternalServerError("Error trying to update user")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userEntity.Id)).
+			WithCode(internal_error.CodeUserNotFound)
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) UpdateUserStatus(
+	ctx context.Context, userId string, status user_entity.UserStatus) *internal_error.InternalError {
+	filter := bson.M{"_id": userId}
+	update := bson.M{"$set": bson.M{"status": status}}
+
+	result, err := ur.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update status of user %s", userId), err)
+		return internal_error.NewInternalServerError("Error trying to update user status")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userId))
+	}
+
+	return nil
+}
+
+func (um UserEntityMongo) toEntity() user_entity.User {
+	// Usuários cadastrados antes do status existir são considerados ativos
+	status := um.Status
+	if status == "" {
+		status = user_entity.Active
+	}
+
+	// Antes dos papéis existirem qualquer usuário podia comprar e vender
+	roles := um.Roles
+	if len(roles) == 0 {
+		roles = []user_entity.Role{user_entity.RoleBidder, user_entity.RoleSeller}
+	}
+
+	return user_entity.User{
+		Id:        um.Id,
+		Name:      um.Name,
+		Email:     um.Email,
+		Status:    status,
+		Roles:     roles,
+		CreatedAt: time.Unix(um.CreatedAt, 0),
+	}
+}
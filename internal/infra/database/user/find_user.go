@@ -9,23 +9,9 @@ import (
 	"fullcycle-auction_go/internal/internal_error"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type UserEntityMongo struct {
-	Id   string `bson:"_id"`
-	Name string `bson:"name"`
-}
-
-type UserRepository struct {
-	Collection *mongo.Collection
-}
-
-func NewUserRepository(database *mongo.Database) *UserRepository {
-	return &UserRepository{
-		Collection: database.Collection("users"),
-	}
-}
-
 func (ur *UserRepository) FindUserById(
 	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
 	filter := bson.M{"_id": userId}
@@ -34,19 +20,51 @@ func (ur *UserRepository) FindUserById(
 	err := ur.Collection.FindOne(ctx, filter).Decode(&userEntityMongo)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			logger.Error(fmt.Sprintf("User not found with this id = %d", userId), err)
+			logger.Error(fmt.Sprintf("User not found with this id = %s", userId), err)
 			return nil, internal_error.NewNotFoundError(
-				fmt.Sprintf("User not found with this id = %d", userId))
+				fmt.Sprintf("User not found with this id = %s", userId)).
+				WithCode(internal_error.CodeUserNotFound)
 		}
 
 		logger.Error("Error trying to find user by userId", err)
 		return nil, internal_error.NewInternalServerError("Error trying to find user by userId")
 	}
 
-	userEntity := &user_entity.User{
-		Id:   userEntityMongo.Id,
-		Name: userEntityMongo.Name,
+	userEntity := userEntityMongo.toEntity()
+
+	return &userEntity, nil
+}
+
+func (ur *UserRepository) FindUsers(
+	ctx context.Context, page, limit int64) ([]user_entity.User, int64, *internal_error.InternalError) {
+	total, err := ur.Collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		logger.Error("Error trying to count users", err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find users")
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
+	cursor, err := ur.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logger.Error("Error trying to find users", err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find users")
+	}
+	defer cursor.Close(ctx)
+
+	var usersMongo []UserEntityMongo
+	if err := cursor.All(ctx, &usersMongo); err != nil {
+		logger.Error("Error trying to decode users", err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find users")
+	}
+
+	var users []user_entity.User
+	for _, userMongo := range usersMongo {
+		users = append(users, userMongo.toEntity())
 	}
 
-	return userEntity, nil
+	return users, total, nil
 }
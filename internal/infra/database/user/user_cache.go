@@ -0,0 +1,75 @@
+package user
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/cache"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+)
+
+// UserCache keeps recently read users in memory so the bid hot path can check
+// the bidder status without a round trip. Writes through it invalidate the user.
+type UserCache struct {
+	user_entity.UserRepositoryInterface
+
+	users *cache.LRU[user_entity.User]
+}
+
+func NewUserCache(
+	repository user_entity.UserRepositoryInterface,
+	maxEntries int,
+	ttl time.Duration) *UserCache {
+	return &UserCache{
+		UserRepositoryInterface: repository,
+		users:                   cache.NewLRU[user_entity.User](maxEntries, ttl),
+	}
+}
+
+func (uc *UserCache) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	user, err := uc.users.GetOrLoad(userId, func() (user_entity.User, *internal_error.InternalError) {
+		user, err := uc.UserRepositoryInterface.FindUserById(ctx, userId)
+		if err != nil {
+			return user_entity.User{}, err
+		}
+		return *user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (uc *UserCache) UpdateUser(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	defer uc.users.Delete(userEntity.Id)
+
+	return uc.UserRepositoryInterface.UpdateUser(ctx, userEntity)
+}
+
+func (uc *UserCache) UpdateUserStatus(
+	ctx context.Context, userId string, status user_entity.UserStatus) *internal_error.InternalError {
+	defer uc.users.Delete(userId)
+
+	return uc.UserRepositoryInterface.UpdateUserStatus(ctx, userId, status)
+}
+
+func GetUserCacheMaxEntries() int {
+	value, err := strconv.Atoi(os.Getenv("USER_CACHE_MAX_ENTRIES"))
+	if err != nil || value <= 0 {
+		return 10000
+	}
+	return value
+}
+
+func GetUserCacheTTL() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("USER_CACHE_TTL"))
+	if err != nil || duration <= 0 {
+		return 30 * time.Second
+	}
+	return duration
+}
@@ -0,0 +1,70 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// countingUserRepository guarda os usuários em memória e conta as leituras
+type countingUserRepository struct {
+	user_entity.UserRepositoryInterface
+	users map[string]user_entity.User
+	reads int
+}
+
+func (r *countingUserRepository) FindUserById(ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	r.reads++
+	user, ok := r.users[userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("User not found")
+	}
+	return &user, nil
+}
+
+func (r *countingUserRepository) UpdateUserStatus(ctx context.Context, userId string, status user_entity.UserStatus) *internal_error.InternalError {
+	user := r.users[userId]
+	user.Status = status
+	r.users[userId] = user
+	return nil
+}
+
+func TestUserCache_StatusChangeInvalidatesUser(t *testing.T) {
+	repository := &countingUserRepository{users: map[string]user_entity.User{
+		"u1": {Id: "u1", Status: user_entity.Active},
+	}}
+	userCache := user.NewUserCache(repository, 10, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		found, err := userCache.FindUserById(context.Background(), "u1")
+		assert.Nil(t, err)
+		assert.Equal(t, user_entity.Active, found.Status)
+	}
+	assert.Equal(t, 1, repository.reads)
+
+	// A suspensão vale no próximo lance, sem esperar o TTL
+	assert.Nil(t, userCache.UpdateUserStatus(context.Background(), "u1", user_entity.Suspended))
+	found, err := userCache.FindUserById(context.Background(), "u1")
+	assert.Nil(t, err)
+	assert.Equal(t, user_entity.Suspended, found.Status)
+	assert.Equal(t, 2, repository.reads)
+}
+
+func TestUserCache_DoesNotCacheMissingUsers(t *testing.T) {
+	repository := &countingUserRepository{users: map[string]user_entity.User{}}
+	userCache := user.NewUserCache(repository, 10, time.Minute)
+
+	_, err := userCache.FindUserById(context.Background(), "u1")
+	assert.Equal(t, "not_found", err.Err)
+
+	repository.users["u1"] = user_entity.User{Id: "u1", Status: user_entity.Active}
+	found, err := userCache.FindUserById(context.Background(), "u1")
+	assert.Nil(t, err)
+	assert.Equal(t, "u1", found.Id)
+}
@@ -0,0 +1,466 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WalletMongo guarda, além do saldo, os ids das reservas já descontadas em
+// held e os lançamentos do extrato ainda não copiados para a coleção do
+// extrato. Os dois mudam na mesma atualização que o saldo.
+type WalletMongo struct {
+	UserId        string             `bson:"_id"`
+	Balance       float64            `bson:"balance"`
+	Held          float64            `bson:"held"`
+	UpdatedAt     int64              `bson:"updated_at"`
+	ReservedHolds []string           `bson:"reserved_holds,omitempty"`
+	PendingLedger []LedgerEntryMongo `bson:"pending_ledger,omitempty"`
+}
+
+type HoldMongo struct {
+	Id        string                   `bson:"_id"`
+	UserId    string                   `bson:"user_id"`
+	AuctionId string                   `bson:"auction_id"`
+	Amount    float64                  `bson:"amount"`
+	Status    wallet_entity.HoldStatus `bson:"status"`
+	CreatedAt int64                    `bson:"created_at"`
+	UpdatedAt int64                    `bson:"updated_at"`
+}
+
+type LedgerEntryMongo struct {
+	Id        string                        `bson:"_id"`
+	UserId    string                        `bson:"user_id"`
+	Type      wallet_entity.LedgerEntryType `bson:"type"`
+	Amount    float64                       `bson:"amount"`
+	Reference string                        `bson:"reference,omitempty"`
+	CreatedAt int64                         `bson:"created_at"`
+}
+
+// WalletRepository guarda saldos, reservas e extrato em coleções separadas.
+// Toda mudança de saldo é uma única atualização condicional na carteira, então
+// reservas concorrentes nunca reservam mais que o saldo livre e uma reserva
+// passa de held para released ou captured uma única vez. A carteira registra
+// as reservas já descontadas, então o valor é reservado antes de a reserva ser
+// marcada como held e uma reserva repetida nunca desconta duas vezes. Os
+// lançamentos do extrato entram na carteira nessa mesma atualização e depois
+// são copiados para a coleção do extrato, então uma cópia que falhou é
+// repetida em vez de perdida.
+type WalletRepository struct {
+	Wallets *mongo.Collection
+	Holds   *mongo.Collection
+	Ledger  *mongo.Collection
+}
+
+func NewWalletRepository(database *mongo.Database) *WalletRepository {
+	return &WalletRepository{
+		Wallets: database.Collection("wallets"),
+		Holds:   database.Collection("wallet_holds"),
+		Ledger:  database.Collection("wallet_ledger"),
+	}
+}
+
+func (wr *WalletRepository) CreateIndexes(ctx context.Context) error {
+	if _, err := wr.Holds.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "auction_id", Value: 1}, {Key: "status", Value: 1}},
+		Options: options.Index().SetName("auction_id_status"),
+	}); err != nil {
+		return err
+	}
+
+	_, err := wr.Ledger.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("user_id_created_at"),
+	})
+	return err
+}
+
+func (wr *WalletRepository) FindWallet(
+	ctx context.Context, userId string) (*wallet_entity.Wallet, *internal_error.InternalError) {
+	var walletMongo WalletMongo
+	if err := wr.Wallets.FindOne(ctx, bson.M{"_id": userId}).Decode(&walletMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return &wallet_entity.Wallet{UserId: userId}, nil
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find wallet of user %s", userId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find wallet")
+	}
+
+	wallet := walletMongo.toEntity()
+	return &wallet, nil
+}
+
+func (wr *WalletRepository) Deposit(
+	ctx context.Context, userId string, amount float64) (*wallet_entity.Wallet, *internal_error.InternalError) {
+	update := bson.M{
+		"$inc":         bson.M{"balance": amount},
+		"$set":         bson.M{"updated_at": time.Now().Unix()},
+		"$setOnInsert": bson.M{"held": 0.0},
+		"$push":        pushLedger(wallet_entity.NewLedgerEntry(userId, wallet_entity.Deposit, amount, "")),
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var walletMongo WalletMongo
+	if err := wr.Wallets.FindOneAndUpdate(ctx, bson.M{"_id": userId}, update, opts).Decode(&walletMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to deposit into wallet of user %s", userId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to deposit")
+	}
+
+	wr.flushLedger(ctx, userId)
+
+	wallet := walletMongo.toEntity()
+	return &wallet, nil
+}
+
+func (wr *WalletRepository) Withdraw(
+	ctx context.Context, userId string, amount float64) (*wallet_entity.Wallet, *internal_error.InternalError) {
+	update := bson.M{
+		"$inc":  bson.M{"balance": -amount},
+		"$set":  bson.M{"updated_at": time.Now().Unix()},
+		"$push": pushLedger(wallet_entity.NewLedgerEntry(userId, wallet_entity.Withdrawal, amount, "")),
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var walletMongo WalletMongo
+	err := wr.Wallets.FindOneAndUpdate(ctx, availableAtLeast(userId, amount), update, opts).Decode(&walletMongo)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewUnprocessableEntityError("Insufficient available balance").
+				WithCode(internal_error.CodeInsufficientBalance)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to withdraw from wallet of user %s", userId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to withdraw")
+	}
+
+	wr.flushLedger(ctx, userId)
+
+	wallet := walletMongo.toEntity()
+	return &wallet, nil
+}
+
+func (wr *WalletRepository) PlaceHold(
+	ctx context.Context, hold wallet_entity.Hold) *internal_error.InternalError {
+	var existing HoldMongo
+	err := wr.Holds.FindOne(ctx, bson.M{"_id": hold.Id}).Decode(&existing)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		logger.Error(fmt.Sprintf("Error trying to place hold %s", hold.Id), err)
+		return internal_error.NewInternalServerError("Error trying to place hold")
+	}
+
+	// Só uma reserva liberada (lance reprocessado) volta a valer
+	if err == nil && existing.Status != wallet_entity.Released {
+		return nil
+	}
+
+	if err := wr.reserve(ctx, hold); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	_, updateErr := wr.Holds.UpdateOne(ctx,
+		bson.M{"_id": hold.Id, "status": bson.M{"$ne": wallet_entity.Captured}},
+		bson.M{
+			"$set": bson.M{"status": wallet_entity.Held, "updated_at": now},
+			"$setOnInsert": bson.M{
+				"user_id":    hold.UserId,
+				"auction_id": hold.AuctionId,
+				"amount":     hold.Amount,
+				"created_at": hold.CreatedAt.Unix(),
+			},
+		},
+		options.Update().SetUpsert(true))
+	if updateErr != nil && !mongo.IsDuplicateKeyError(updateErr) {
+		// Sem a reserva registrada, o valor volta para o saldo livre
+		wr.unreserve(ctx, hold.UserId, hold.Id, bson.M{"held": -hold.Amount}, wallet_entity.NewLedgerEntry(
+			hold.UserId, wallet_entity.HoldReleased, hold.Amount, hold.Id))
+
+		logger.Error(fmt.Sprintf("Error trying to place hold %s", hold.Id), updateErr)
+		return internal_error.NewInternalServerError("Error trying to place hold")
+	}
+
+	return nil
+}
+
+// reserve desconta o valor do saldo livre uma única vez por reserva.
+func (wr *WalletRepository) reserve(
+	ctx context.Context, hold wallet_entity.Hold) *internal_error.InternalError {
+	filter := availableAtLeast(hold.UserId, hold.Amount)
+	filter["reserved_holds"] = bson.M{"$ne": hold.Id}
+
+	result, err := wr.Wallets.UpdateOne(ctx, filter, bson.M{
+		"$inc":      bson.M{"held": hold.Amount},
+		"$set":      bson.M{"updated_at": time.Now().Unix()},
+		"$addToSet": bson.M{"reserved_holds": hold.Id},
+		"$push": pushLedger(wallet_entity.NewLedgerEntry(
+			hold.UserId, wallet_entity.HoldPlaced, hold.Amount, hold.Id)),
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to reserve funds for hold %s", hold.Id), err)
+		return internal_error.NewInternalServerError("Error trying to place hold")
+	}
+
+	if result.MatchedCount == 0 {
+		// Uma tentativa anterior já reservou o valor desta reserva
+		reserved, err := wr.Wallets.CountDocuments(ctx,
+			bson.M{"_id": hold.UserId, "reserved_holds": hold.Id})
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error trying to reserve funds for hold %s", hold.Id), err)
+			return internal_error.NewInternalServerError("Error trying to place hold")
+		}
+		if reserved == 0 {
+			return internal_error.NewUnprocessableEntityError("Insufficient available balance for this bid").
+				WithCode(internal_error.CodeInsufficientBalance)
+		}
+	}
+
+	wr.flushLedger(ctx, hold.UserId)
+	return nil
+}
+
+// unreserve devolve uma reserva à carteira apenas se ela ainda estiver
+// descontada, o que torna a devolução segura para repetir.
+func (wr *WalletRepository) unreserve(
+	ctx context.Context,
+	userId, holdId string,
+	increments bson.M,
+	entry wallet_entity.LedgerEntry) error {
+	_, err := wr.Wallets.UpdateOne(ctx,
+		bson.M{"_id": userId, "reserved_holds": holdId},
+		bson.M{
+			"$inc":  increments,
+			"$set":  bson.M{"updated_at": time.Now().Unix()},
+			"$pull": bson.M{"reserved_holds": holdId},
+			"$push": pushLedger(entry),
+		})
+	if err == nil {
+		wr.flushLedger(ctx, userId)
+	}
+	return err
+}
+
+func (wr *WalletRepository) ReleaseHold(
+	ctx context.Context, holdId string) *internal_error.InternalError {
+	return wr.settleHold(ctx, holdId, wallet_entity.Released, wallet_entity.HoldReleased)
+}
+
+func (wr *WalletRepository) CaptureHold(
+	ctx context.Context, holdId string) *internal_error.InternalError {
+	return wr.settleHold(ctx, holdId, wallet_entity.Captured, wallet_entity.HoldCaptured)
+}
+
+// settleHold leva uma reserva held ao status final e devolve o valor reservado
+// à carteira; reservas capturadas também saem do saldo.
+func (wr *WalletRepository) settleHold(
+	ctx context.Context,
+	holdId string,
+	status wallet_entity.HoldStatus,
+	entryType wallet_entity.LedgerEntryType) *internal_error.InternalError {
+	now := time.Now().Unix()
+
+	var holdMongo HoldMongo
+	err := wr.Holds.FindOneAndUpdate(ctx,
+		bson.M{"_id": holdId, "status": wallet_entity.Held},
+		bson.M{"$set": bson.M{"status": status, "updated_at": now}}).Decode(&holdMongo)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return wr.checkSettled(ctx, holdId, status)
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to settle hold %s", holdId), err)
+		return internal_error.NewInternalServerError("Error trying to settle hold")
+	}
+
+	increments := bson.M{"held": -holdMongo.Amount}
+	if status == wallet_entity.Captured {
+		increments["balance"] = -holdMongo.Amount
+	}
+
+	if err := wr.unreserve(ctx, holdMongo.UserId, holdId, increments, wallet_entity.NewLedgerEntry(
+		holdMongo.UserId, entryType, holdMongo.Amount, holdId)); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to settle hold %s on wallet", holdId), err)
+		return internal_error.NewInternalServerError("Error trying to settle hold")
+	}
+
+	return nil
+}
+
+// checkSettled explica por que uma reserva não estava held: repetir a mesma
+// liquidação, ou liquidar um lance que nunca reservou saldo, não é uma falha,
+// mas liberar uma reserva capturada (ou o contrário) é.
+func (wr *WalletRepository) checkSettled(
+	ctx context.Context, holdId string, status wallet_entity.HoldStatus) *internal_error.InternalError {
+	var holdMongo HoldMongo
+	if err := wr.Holds.FindOne(ctx, bson.M{"_id": holdId}).Decode(&holdMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find hold %s", holdId), err)
+		return internal_error.NewInternalServerError("Error trying to settle hold")
+	}
+
+	if holdMongo.Status != status {
+		return internal_error.NewConflictError(fmt.Sprintf("Hold was already %s", holdMongo.Status)).
+			WithCode(internal_error.CodeHoldAlreadySettled)
+	}
+	return nil
+}
+
+func (wr *WalletRepository) FindHeldHoldsByAuctionId(
+	ctx context.Context, auctionId string) ([]wallet_entity.Hold, *internal_error.InternalError) {
+	cursor, err := wr.Holds.Find(ctx, bson.M{"auction_id": auctionId, "status": wallet_entity.Held})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find holds of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find holds")
+	}
+	defer cursor.Close(ctx)
+
+	var holdsMongo []HoldMongo
+	if err := cursor.All(ctx, &holdsMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode holds of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find holds")
+	}
+
+	holds := make([]wallet_entity.Hold, 0, len(holdsMongo))
+	for _, holdMongo := range holdsMongo {
+		holds = append(holds, wallet_entity.Hold{
+			Id:        holdMongo.Id,
+			UserId:    holdMongo.UserId,
+			AuctionId: holdMongo.AuctionId,
+			Amount:    holdMongo.Amount,
+			Status:    holdMongo.Status,
+			CreatedAt: time.Unix(holdMongo.CreatedAt, 0),
+		})
+	}
+
+	return holds, nil
+}
+
+func (wr *WalletRepository) HasCapturedHold(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	captured, err := wr.Holds.CountDocuments(ctx,
+		bson.M{"auction_id": auctionId, "status": wallet_entity.Captured},
+		options.Count().SetLimit(1))
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find captured holds of auction %s", auctionId), err)
+		return false, internal_error.NewInternalServerError("Error trying to find holds")
+	}
+
+	return captured > 0, nil
+}
+
+func (wr *WalletRepository) FindLedger(
+	ctx context.Context,
+	userId string,
+	page, limit int64) ([]wallet_entity.LedgerEntry, int64, *internal_error.InternalError) {
+	wr.flushLedger(ctx, userId)
+	filter := bson.M{"user_id": userId}
+
+	total, err := wr.Ledger.CountDocuments(ctx, filter)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to count ledger entries of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find ledger")
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
+	cursor, err := wr.Ledger.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find ledger entries of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find ledger")
+	}
+	defer cursor.Close(ctx)
+
+	var entriesMongo []LedgerEntryMongo
+	if err := cursor.All(ctx, &entriesMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode ledger entries of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find ledger")
+	}
+
+	entries := make([]wallet_entity.LedgerEntry, 0, len(entriesMongo))
+	for _, entryMongo := range entriesMongo {
+		entries = append(entries, wallet_entity.LedgerEntry{
+			Id:        entryMongo.Id,
+			UserId:    entryMongo.UserId,
+			Type:      entryMongo.Type,
+			Amount:    entryMongo.Amount,
+			Reference: entryMongo.Reference,
+			CreatedAt: time.Unix(entryMongo.CreatedAt, 0),
+		})
+	}
+
+	return entries, total, nil
+}
+
+// pushLedger acrescenta o lançamento aos pendentes da carteira, na mesma
+// atualização que altera o saldo.
+func pushLedger(entry wallet_entity.LedgerEntry) bson.M {
+	return bson.M{"pending_ledger": LedgerEntryMongo{
+		Id:        entry.Id,
+		UserId:    entry.UserId,
+		Type:      entry.Type,
+		Amount:    entry.Amount,
+		Reference: entry.Reference,
+		CreatedAt: entry.CreatedAt.Unix(),
+	}}
+}
+
+// flushLedger copia os lançamentos pendentes para o extrato. Os ids são
+// fixos, então uma cópia repetida é ignorada; uma falha só é registrada em
+// log e a próxima operação da carteira tenta de novo.
+func (wr *WalletRepository) flushLedger(ctx context.Context, userId string) {
+	var walletMongo WalletMongo
+	if err := wr.Wallets.FindOne(ctx, bson.M{"_id": userId}).Decode(&walletMongo); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Error(fmt.Sprintf("Error trying to find pending ledger entries of user %s", userId), err)
+		}
+		return
+	}
+
+	for _, entryMongo := range walletMongo.PendingLedger {
+		if _, err := wr.Ledger.InsertOne(ctx, entryMongo); err != nil && !mongo.IsDuplicateKeyError(err) {
+			logger.Error(fmt.Sprintf("Error trying to append %s ledger entry for user %s", entryMongo.Type, userId), err)
+			return
+		}
+
+		if _, err := wr.Wallets.UpdateOne(ctx,
+			bson.M{"_id": userId},
+			bson.M{"$pull": bson.M{"pending_ledger": bson.M{"_id": entryMongo.Id}}}); err != nil {
+			logger.Error(fmt.Sprintf("Error trying to clear ledger entry %s of user %s", entryMongo.Id, userId), err)
+			return
+		}
+	}
+}
+
+// availableAtLeast casa a carteira apenas se o saldo livre cobre o valor.
+func availableAtLeast(userId string, amount float64) bson.M {
+	return bson.M{
+		"_id": userId,
+		"$expr": bson.M{"$gte": bson.A{
+			bson.M{"$subtract": bson.A{"$balance", "$held"}},
+			amount,
+		}},
+	}
+}
+
+func (wm WalletMongo) toEntity() wallet_entity.Wallet {
+	return wallet_entity.Wallet{
+		UserId:    wm.UserId,
+		Balance:   wm.Balance,
+		Held:      wm.Held,
+		UpdatedAt: time.Unix(wm.UpdatedAt, 0),
+	}
+}
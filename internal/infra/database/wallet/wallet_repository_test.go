@@ -0,0 +1,279 @@
+package wallet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/infra/database/wallet"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// document converte v para o documento que o Mongo devolveria.
+func document(v interface{}) bson.D {
+	raw, _ := bson.Marshal(v)
+	var doc bson.D
+	bson.Unmarshal(raw, &doc)
+	return doc
+}
+
+// updated é a resposta de um update que casou matched documentos.
+func updated(matched int32) bson.D {
+	return mtest.CreateSuccessResponse(
+		bson.E{Key: "n", Value: matched}, bson.E{Key: "nModified", Value: matched})
+}
+
+// empty é a resposta de uma busca sem resultados.
+func empty(collection string) bson.D {
+	return mtest.CreateCursorResponse(0, "db."+collection, mtest.FirstBatch)
+}
+
+// found é a resposta de uma busca que encontrou v.
+func found(collection string, v interface{}) bson.D {
+	return mtest.CreateCursorResponse(0, "db."+collection, mtest.FirstBatch, document(v))
+}
+
+// nextUpdate devolve o filtro e a atualização do próximo update enviado.
+func nextUpdate(mt *mtest.T) (bson.Raw, bson.Raw) {
+	event := mt.GetStartedEvent()
+	if event == nil || event.CommandName != "update" {
+		mt.Fatalf("expected an update, got %v", event)
+	}
+	update := event.Command.Lookup("updates").Array().Index(0).Value().Document()
+	return update.Lookup("q").Document(), update.Lookup("u").Document()
+}
+
+func TestPlaceHold_ReservesFundsBeforeMarkingTheHold(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+
+	mt.Run("holds", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			empty("wallet_holds"),
+			updated(1),
+			empty("wallets"),
+			updated(1),
+		)
+
+		err := wallet.NewWalletRepository(mt.DB).PlaceHold(ctx, wallet_entity.NewHold("h1", "u1", "a1", 60))
+		assert.Nil(t, err)
+
+		assert.Equal(t, "find", mt.GetStartedEvent().CommandName)
+
+		// O valor só é reservado se o saldo livre cobre o lance, uma vez por reserva
+		filter, update := nextUpdate(mt)
+		assert.Equal(t, "h1", filter.Lookup("reserved_holds", "$ne").StringValue())
+		assert.Equal(t, 60.0, filter.Lookup("$expr", "$gte").Array().Index(1).Value().Double())
+		assert.Equal(t, 60.0, update.Lookup("$inc", "held").Double())
+		assert.Equal(t, "h1", update.Lookup("$addToSet", "reserved_holds").StringValue())
+		assert.Equal(t, string(wallet_entity.HoldPlaced), update.Lookup("$push", "pending_ledger", "type").StringValue())
+
+		assert.Equal(t, "find", mt.GetStartedEvent().CommandName)
+
+		// Só depois a reserva é marcada como ativa, sem reativar uma capturada
+		filter, update = nextUpdate(mt)
+		assert.Equal(t, "h1", filter.Lookup("_id").StringValue())
+		assert.Equal(t, string(wallet_entity.Captured), filter.Lookup("status", "$ne").StringValue())
+		assert.Equal(t, string(wallet_entity.Held), update.Lookup("$set", "status").StringValue())
+	})
+
+	mt.Run("already held", func(mt *mtest.T) {
+		// Repetir a mesma reserva não desconta o valor de novo
+		mt.AddMockResponses(found("wallet_holds", wallet.HoldMongo{
+			Id: "h1", UserId: "u1", AuctionId: "a1", Amount: 60, Status: wallet_entity.Held}))
+
+		err := wallet.NewWalletRepository(mt.DB).PlaceHold(ctx, wallet_entity.NewHold("h1", "u1", "a1", 60))
+		assert.Nil(t, err)
+
+		mt.GetStartedEvent()
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("reserved by a failed attempt", func(mt *mtest.T) {
+		// A carteira já registra a reserva, então só a marcação é refeita
+		mt.AddMockResponses(
+			empty("wallet_holds"),
+			updated(0),
+			mtest.CreateCursorResponse(0, "db.wallets", mtest.FirstBatch, bson.D{{Key: "n", Value: int32(1)}}),
+			empty("wallets"),
+			updated(1),
+		)
+
+		err := wallet.NewWalletRepository(mt.DB).PlaceHold(ctx, wallet_entity.NewHold("h1", "u1", "a1", 60))
+		assert.Nil(t, err)
+
+		mt.GetStartedEvent()
+		nextUpdate(mt)
+		count := mt.GetStartedEvent().Command
+		assert.Equal(t, "h1", count.Lookup("pipeline").Array().Index(0).Value().Document().
+			Lookup("$match", "reserved_holds").StringValue())
+		mt.GetStartedEvent()
+		_, update := nextUpdate(mt)
+		assert.Equal(t, string(wallet_entity.Held), update.Lookup("$set", "status").StringValue())
+	})
+
+	mt.Run("insufficient balance", func(mt *mtest.T) {
+		// Sem saldo livre, nenhuma reserva é registrada como ativa
+		mt.AddMockResponses(
+			empty("wallet_holds"),
+			updated(0),
+			empty("wallets"),
+		)
+
+		err := wallet.NewWalletRepository(mt.DB).PlaceHold(ctx, wallet_entity.NewHold("h2", "u1", "a1", 50))
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeInsufficientBalance, err.Code)
+		}
+
+		mt.GetStartedEvent()
+		nextUpdate(mt)
+		assert.Equal(t, "aggregate", mt.GetStartedEvent().CommandName)
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+}
+
+func TestSettleHold_ReturnsTheReservedAmountOnce(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+	hold := wallet.HoldMongo{Id: "h1", UserId: "u1", AuctionId: "a1", Amount: 60, Status: wallet_entity.Held}
+
+	mt.Run("release", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: document(hold)}),
+			updated(1),
+			empty("wallets"),
+		)
+
+		assert.Nil(t, wallet.NewWalletRepository(mt.DB).ReleaseHold(ctx, "h1"))
+
+		findAndModify := mt.GetStartedEvent().Command
+		assert.Equal(t, string(wallet_entity.Held), findAndModify.Lookup("query", "status").StringValue())
+		assert.Equal(t, string(wallet_entity.Released), findAndModify.Lookup("update", "$set", "status").StringValue())
+
+		filter, update := nextUpdate(mt)
+		assert.Equal(t, "h1", filter.Lookup("reserved_holds").StringValue())
+		assert.Equal(t, -60.0, update.Lookup("$inc", "held").Double())
+		// Liberar não tira o valor do saldo
+		_, missing := update.Lookup("$inc").Document().LookupErr("balance")
+		assert.NotNil(t, missing)
+		assert.Equal(t, "h1", update.Lookup("$pull", "reserved_holds").StringValue())
+		assert.Equal(t, string(wallet_entity.HoldReleased), update.Lookup("$push", "pending_ledger", "type").StringValue())
+	})
+
+	mt.Run("capture", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: document(hold)}),
+			updated(1),
+			empty("wallets"),
+		)
+
+		assert.Nil(t, wallet.NewWalletRepository(mt.DB).CaptureHold(ctx, "h1"))
+
+		mt.GetStartedEvent()
+		_, update := nextUpdate(mt)
+		assert.Equal(t, -60.0, update.Lookup("$inc", "held").Double())
+		assert.Equal(t, -60.0, update.Lookup("$inc", "balance").Double())
+		assert.Equal(t, string(wallet_entity.HoldCaptured), update.Lookup("$push", "pending_ledger", "type").StringValue())
+	})
+
+	mt.Run("already settled", func(mt *mtest.T) {
+		// Uma reserva que já saiu de held não volta a mexer na carteira
+		captured := hold
+		captured.Status = wallet_entity.Captured
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: primitive.Null{}}),
+			found("wallet_holds", captured),
+		)
+
+		assert.Nil(t, wallet.NewWalletRepository(mt.DB).CaptureHold(ctx, "h1"))
+
+		mt.GetStartedEvent()
+		assert.Equal(t, "find", mt.GetStartedEvent().CommandName)
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("release of a captured hold", func(mt *mtest.T) {
+		// O valor já saiu do saldo, então devolver a reserva é um conflito
+		captured := hold
+		captured.Status = wallet_entity.Captured
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: primitive.Null{}}),
+			found("wallet_holds", captured),
+		)
+
+		err := wallet.NewWalletRepository(mt.DB).ReleaseHold(ctx, "h1")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, "conflict", err.Err)
+			assert.Equal(t, internal_error.CodeHoldAlreadySettled, err.Code)
+		}
+	})
+
+	mt.Run("bid without hold", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: primitive.Null{}}),
+			empty("wallet_holds"),
+		)
+
+		assert.Nil(t, wallet.NewWalletRepository(mt.DB).ReleaseHold(ctx, "h1"))
+	})
+}
+
+func TestFindLedger_CopiesPendingEntriesFirst(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("pending ledger entries", func(mt *mtest.T) {
+		// Um lançamento que não chegou ao extrato continua na carteira
+		entry := wallet.LedgerEntryMongo{Id: "e1", UserId: "u1", Type: wallet_entity.Deposit, Amount: 100, CreatedAt: 1}
+		mt.AddMockResponses(
+			found("wallets", wallet.WalletMongo{UserId: "u1", Balance: 100, PendingLedger: []wallet.LedgerEntryMongo{entry}}),
+			mtest.CreateSuccessResponse(),
+			updated(1),
+			mtest.CreateCursorResponse(0, "db.wallet_ledger", mtest.FirstBatch, bson.D{{Key: "n", Value: int32(1)}}),
+			found("wallet_ledger", entry),
+		)
+
+		entries, total, err := wallet.NewWalletRepository(mt.DB).FindLedger(context.Background(), "u1", 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "e1", entries[0].Id)
+		}
+
+		mt.GetStartedEvent()
+		insert := mt.GetStartedEvent().Command
+		assert.Equal(t, "wallet_ledger", insert.Lookup("insert").StringValue())
+		assert.Equal(t, "e1", insert.Lookup("documents").Array().Index(0).Value().Document().Lookup("_id").StringValue())
+
+		// O lançamento só sai da carteira depois de copiado
+		_, update := nextUpdate(mt)
+		assert.Equal(t, "e1", update.Lookup("$pull", "pending_ledger", "_id").StringValue())
+	})
+}
+
+func TestHasCapturedHold_CountsOnlyCapturedHolds(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	for name, n := range map[string]int32{"charged": 1, "not charged": 0} {
+		mt.Run(name, func(mt *mtest.T) {
+			if n > 0 {
+				mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.wallet_holds", mtest.FirstBatch,
+					bson.D{{Key: "n", Value: n}}))
+			} else {
+				mt.AddMockResponses(empty("wallet_holds"))
+			}
+
+			captured, err := wallet.NewWalletRepository(mt.DB).HasCapturedHold(context.Background(), "a1")
+			assert.Nil(t, err)
+			assert.Equal(t, n > 0, captured)
+
+			match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document()
+			assert.Equal(t, "a1", match.Lookup("$match", "auction_id").StringValue())
+			assert.Equal(t, string(wallet_entity.Captured), match.Lookup("$match", "status").StringValue())
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/watchlist_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WatchMongo struct {
+	UserId    string `bson:"user_id"`
+	AuctionId string `bson:"auction_id"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+// WatchlistRepository mantém também o watcher_count do leilão, somando um a
+// cada acompanhamento gravado e subtraindo um a cada acompanhamento removido.
+type WatchlistRepository struct {
+	Collection        *mongo.Collection
+	AuctionRepository auction_entity.AuctionRepositoryInterface
+}
+
+func NewWatchlistRepository(
+	database *mongo.Database,
+	auctionRepository auction_entity.AuctionRepositoryInterface) *WatchlistRepository {
+	return &WatchlistRepository{
+		Collection:        database.Collection("watchlists"),
+		AuctionRepository: auctionRepository,
+	}
+}
+
+// CreateIndexes garante um único registro por usuário e leilão e cobre a
+// lista do usuário.
+func (wr *WatchlistRepository) CreateIndexes(ctx context.Context) error {
+	_, err := wr.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "auction_id", Value: 1}},
+			Options: options.Index().SetName("user_id_auction_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("user_id_created_at"),
+		},
+	})
+	return err
+}
+
+func (wr *WatchlistRepository) WatchAuction(
+	ctx context.Context, watch *watchlist_entity.Watch) *internal_error.InternalError {
+	filter := bson.M{"user_id": watch.UserId, "auction_id": watch.AuctionId}
+	update := bson.M{"$setOnInsert": bson.M{"created_at": watch.CreatedAt.Unix()}}
+
+	result, err := wr.Collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		// Outro pedido igual gravou o acompanhamento primeiro
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to watch auction %s", watch.AuctionId), err)
+		return internal_error.NewInternalServerError("Error trying to watch auction")
+	}
+
+	// Só o pedido que criou o acompanhamento conta o novo usuário
+	if result.UpsertedCount == 0 {
+		return nil
+	}
+
+	return wr.AuctionRepository.IncrementWatcherCount(ctx, watch.AuctionId, 1)
+}
+
+func (wr *WatchlistRepository) UnwatchAuction(
+	ctx context.Context, userId, auctionId string) *internal_error.InternalError {
+	result, err := wr.Collection.DeleteOne(ctx, bson.M{"user_id": userId, "auction_id": auctionId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to unwatch auction %s", auctionId), err)
+		return internal_error.NewInternalServerError("Error trying to unwatch auction")
+	}
+
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError("Auction is not in the watchlist").
+			WithCode(internal_error.CodeNotWatching)
+	}
+
+	return wr.AuctionRepository.IncrementWatcherCount(ctx, auctionId, -1)
+}
+
+type watchlistPageMongo struct {
+	Watches []watchWithAuctionMongo `bson:"watches"`
+	Total   []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+type watchWithAuctionMongo struct {
+	WatchMongo `bson:",inline"`
+	Auction    auction.AuctionEntityMongo `bson:"auction"`
+}
+
+// FindWatchesByUserId pagina a lista em uma única agregação. Leilões que não
+// existem mais ficam de fora antes da paginação, para que o total e as páginas
+// contem só o que é exibido; o leilão completo só é lido para a página.
+func (wr *WatchlistRepository) FindWatchesByUserId(
+	ctx context.Context,
+	userId string,
+	page, limit int64) ([]watchlist_entity.Watch, int64, *internal_error.InternalError) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "auctions",
+			"let":  bson.M{"auctionId": "$auction_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$auctionId"}}}},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "auction",
+		}}},
+		{{Key: "$match", Value: bson.M{"auction": bson.M{"$ne": bson.A{}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "auction_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"watches": bson.A{
+				bson.M{"$skip": (page - 1) * limit},
+				bson.M{"$limit": limit},
+				bson.M{"$lookup": bson.M{
+					"from":         "auctions",
+					"localField":   "auction_id",
+					"foreignField": "_id",
+					"as":           "auction",
+				}},
+				bson.M{"$unwind": "$auction"},
+			},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	}
+
+	cursor, err := wr.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find watchlist of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find watchlist")
+	}
+	defer cursor.Close(ctx)
+
+	var pages []watchlistPageMongo
+	if err := cursor.All(ctx, &pages); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode watchlist of user %s", userId), err)
+		return nil, 0, internal_error.NewInternalServerError("Error trying to find watchlist")
+	}
+
+	var watchesPage watchlistPageMongo
+	if len(pages) > 0 {
+		watchesPage = pages[0]
+	}
+
+	var total int64
+	if len(watchesPage.Total) > 0 {
+		total = watchesPage.Total[0].Count
+	}
+
+	watches := make([]watchlist_entity.Watch, 0, len(watchesPage.Watches))
+	for _, watchMongo := range watchesPage.Watches {
+		watches = append(watches, watchlist_entity.Watch{
+			UserId:    watchMongo.UserId,
+			AuctionId: watchMongo.AuctionId,
+			CreatedAt: time.Unix(watchMongo.CreatedAt, 0),
+			Auction:   watchMongo.Auction.ToEntity(),
+		})
+	}
+
+	return watches, total, nil
+}
@@ -0,0 +1,148 @@
+package watchlist_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity/auctiontest"
+	"fullcycle-auction_go/internal/entity/watchlist_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/watchlist"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func TestWatchAuction_CountsOnlyRealChanges(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+	watch := &watchlist_entity.Watch{UserId: "u1", AuctionId: "a1", CreatedAt: time.Unix(100, 0)}
+
+	mt.Run("new watch", func(mt *mtest.T) {
+		auctions := &auctiontest.MockAuctionRepository{}
+		auctions.On("IncrementWatcherCount", mock.Anything, "a1", int64(1)).Return(nil).Once()
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1},
+			bson.E{Key: "nModified", Value: 0},
+			bson.E{Key: "upserted", Value: bson.A{bson.D{{Key: "index", Value: 0}, {Key: "_id", Value: "w1"}}}}))
+
+		assert.Nil(t, watchlist.NewWatchlistRepository(mt.DB, auctions).WatchAuction(ctx, watch))
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.True(t, update.Lookup("upsert").Boolean())
+		assert.Equal(t, int64(100), update.Lookup("u", "$setOnInsert", "created_at").Int64())
+		auctions.AssertExpectations(t)
+	})
+
+	mt.Run("already watching", func(mt *mtest.T) {
+		// Acompanhar de novo não conta o usuário outra vez
+		auctions := &auctiontest.MockAuctionRepository{}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 0}))
+
+		assert.Nil(t, watchlist.NewWatchlistRepository(mt.DB, auctions).WatchAuction(ctx, watch))
+		auctions.AssertNotCalled(t, "IncrementWatcherCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	mt.Run("concurrent watch", func(mt *mtest.T) {
+		// Outro pedido igual gravou primeiro e já contou o usuário
+		auctions := &auctiontest.MockAuctionRepository{}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		assert.Nil(t, watchlist.NewWatchlistRepository(mt.DB, auctions).WatchAuction(ctx, watch))
+		auctions.AssertNotCalled(t, "IncrementWatcherCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	mt.Run("unwatch", func(mt *mtest.T) {
+		auctions := &auctiontest.MockAuctionRepository{}
+		auctions.On("IncrementWatcherCount", mock.Anything, "a1", int64(-1)).Return(nil).Once()
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+
+		assert.Nil(t, watchlist.NewWatchlistRepository(mt.DB, auctions).UnwatchAuction(ctx, "u1", "a1"))
+		auctions.AssertExpectations(t)
+	})
+
+	mt.Run("not watching", func(mt *mtest.T) {
+		// Remover o que já não está na lista não desconta nada
+		auctions := &auctiontest.MockAuctionRepository{}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}))
+
+		err := watchlist.NewWatchlistRepository(mt.DB, auctions).UnwatchAuction(ctx, "u1", "a1")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, internal_error.CodeNotWatching, err.Code)
+		}
+		auctions.AssertNotCalled(t, "IncrementWatcherCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFindWatchesByUserId_PagesOnlyExistingAuctions(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("page", func(mt *mtest.T) {
+		watchWithAuction := func(auctionId, productName string, createdAt int64) bson.M {
+			return bson.M{
+				"user_id": "u1", "auction_id": auctionId, "created_at": createdAt,
+				"auction": auction.AuctionEntityMongo{Id: auctionId, ProductName: productName},
+			}
+		}
+		page := bson.M{
+			"watches": bson.A{watchWithAuction("a2", "Guitar", 300), watchWithAuction("a3", "Lamp", 200)},
+			"total":   bson.A{bson.M{"count": int64(3)}},
+		}
+		raw, _ := bson.Marshal(page)
+		var pageDocument bson.D
+		bson.Unmarshal(raw, &pageDocument)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.watchlists", mtest.FirstBatch, pageDocument))
+
+		watches, total, err := watchlist.NewWatchlistRepository(mt.DB, nil).
+			FindWatchesByUserId(context.Background(), "u1", 2, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), total)
+		if assert.Len(t, watches, 2) {
+			assert.Equal(t, "a2", watches[0].AuctionId)
+			assert.Equal(t, "Guitar", watches[0].Auction.ProductName)
+			assert.Equal(t, "a3", watches[1].AuctionId)
+			assert.Equal(t, int64(200), watches[1].CreatedAt.Unix())
+		}
+
+		// A página inteira vem de uma só consulta, sem uma leitura por leilão
+		events := mt.GetAllStartedEvents()
+		if !assert.Len(t, events, 1) {
+			return
+		}
+
+		values, _ := events[0].Command.Lookup("pipeline").Array().Values()
+		var stages []string
+		for _, value := range values {
+			element, _ := value.Document().IndexErr(0)
+			stages = append(stages, element.Key())
+		}
+		assert.Equal(t, []string{"$match", "$lookup", "$match", "$sort", "$facet"}, stages)
+
+		// O leilão removido sai antes da paginação, então não entra no total
+		// nem ocupa lugar na página
+		removed, _ := values[2].Document().Lookup("$match", "auction", "$ne").Array().Values()
+		assert.Empty(t, removed)
+
+		watchesStages, _ := values[4].Document().Lookup("$facet", "watches").Array().Values()
+		if assert.Len(t, watchesStages, 4) {
+			assert.Equal(t, int64(2), watchesStages[0].Document().Lookup("$skip").AsInt64())
+			assert.Equal(t, int64(2), watchesStages[1].Document().Lookup("$limit").AsInt64())
+		}
+	})
+
+	mt.Run("empty", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.watchlists", mtest.FirstBatch))
+
+		watches, total, err := watchlist.NewWatchlistRepository(mt.DB, nil).
+			FindWatchesByUserId(context.Background(), "u1", 1, 2)
+		assert.Nil(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, watches)
+	})
+}
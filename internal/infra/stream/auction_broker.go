@@ -0,0 +1,225 @@
+package stream
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuctionBroker distribui os eventos dos leilões aos assinantes de cada um.
+// Ele vive em memória: os ids são "<época>-<sequência>", em que a época
+// identifica este processo, então um Last-Event-ID de antes de um reinício
+// nunca é retomado. Cada leilão guarda os últimos eventos para a retomada; o
+// assinante que não esvazia o buffer é desconectado em vez de atrasar quem
+// publica.
+type AuctionBroker struct {
+	mutex       sync.Mutex
+	epoch       string
+	sequence    uint64
+	topics      map[string]*auctionTopic
+	historySize int
+	bufferSize  int
+	// forgotten é a última sequência dos tópicos já removidos; não é possível
+	// retomar de antes dela um leilão sem tópico
+	forgotten uint64
+}
+
+type auctionTopic struct {
+	history     []sequencedEvent
+	evicted     uint64
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+type sequencedEvent struct {
+	sequence uint64
+	event    auction_entity.AuctionEvent
+}
+
+type subscriber struct {
+	events chan auction_entity.AuctionEvent
+}
+
+func NewAuctionBroker(historySize, bufferSize int) *AuctionBroker {
+	return &AuctionBroker{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		topics:      make(map[string]*auctionTopic),
+		historySize: historySize,
+		bufferSize:  bufferSize,
+	}
+}
+
+func (b *AuctionBroker) Publish(event auction_entity.AuctionEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	auctionId := event.Auction.Id
+	topic := b.topic(auctionId)
+
+	b.sequence++
+	event.Id = b.eventId(b.sequence)
+
+	topic.history = append(topic.history, sequencedEvent{sequence: b.sequence, event: event})
+	if len(topic.history) > b.historySize {
+		evicted := len(topic.history) - b.historySize
+		topic.evicted = topic.history[evicted-1].sequence
+		topic.history = append([]sequencedEvent(nil), topic.history[evicted:]...)
+	}
+
+	for sub := range topic.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			// Quem não acompanha é desconectado e retoma pelo Last-Event-ID
+			delete(topic.subscribers, sub)
+			close(sub.events)
+		}
+	}
+
+	if event.Type == auction_entity.EventAuctionClosed {
+		topic.closed = true
+		b.removeIfIdle(auctionId, topic)
+	} else if event.Type == auction_entity.EventAuctionExtended {
+		topic.closed = false
+	}
+}
+
+func (b *AuctionBroker) Subscribe(
+	auctionId, lastEventId string) *auction_entity.AuctionEventSubscription {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &subscriber{events: make(chan auction_entity.AuctionEvent, b.bufferSize)}
+	subscription := &auction_entity.AuctionEventSubscription{
+		Cursor: b.eventId(b.sequence),
+		Events: sub.events,
+	}
+
+	topic, exists := b.topics[auctionId]
+	if last, ok := b.parseEventId(lastEventId); ok {
+		switch {
+		case exists && last >= topic.evicted:
+			subscription.Resumed = true
+			for _, stored := range topic.history {
+				if stored.sequence > last {
+					subscription.Missed = append(subscription.Missed, stored.event)
+				}
+			}
+		case !exists && last >= b.forgotten:
+			subscription.Resumed = true
+		}
+	}
+
+	if !exists {
+		topic = b.topic(auctionId)
+	}
+	topic.subscribers[sub] = struct{}{}
+
+	subscription.Cancel = func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		if _, ok := topic.subscribers[sub]; !ok {
+			return
+		}
+		delete(topic.subscribers, sub)
+		close(sub.events)
+		b.removeIfIdle(auctionId, topic)
+	}
+
+	return subscription
+}
+
+// Subscribers conta as conexões abertas de um leilão.
+func (b *AuctionBroker) Subscribers(auctionId string) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if topic, ok := b.topics[auctionId]; ok {
+		return len(topic.subscribers)
+	}
+	return 0
+}
+
+func (b *AuctionBroker) topic(auctionId string) *auctionTopic {
+	topic, ok := b.topics[auctionId]
+	if !ok {
+		topic = &auctionTopic{subscribers: make(map[*subscriber]struct{})}
+		b.topics[auctionId] = topic
+	}
+	return topic
+}
+
+// removeIfIdle descarta um leilão sem assinantes que já foi encerrado ou
+// que ainda não tem eventos.
+func (b *AuctionBroker) removeIfIdle(auctionId string, topic *auctionTopic) {
+	if len(topic.subscribers) > 0 || b.topics[auctionId] != topic {
+		return
+	}
+	if !topic.closed && len(topic.history) > 0 {
+		return
+	}
+
+	delete(b.topics, auctionId)
+	if length := len(topic.history); length > 0 && topic.history[length-1].sequence > b.forgotten {
+		b.forgotten = topic.history[length-1].sequence
+	}
+}
+
+func (b *AuctionBroker) eventId(sequence uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(sequence, 10)
+}
+
+func (b *AuctionBroker) parseEventId(eventId string) (uint64, bool) {
+	epoch, sequence, found := strings.Cut(eventId, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil || value > b.sequence {
+		return 0, false
+	}
+	return value, true
+}
+
+func GetAuctionStreamHistory() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_STREAM_HISTORY"))
+	if err != nil || value <= 0 {
+		return 100
+	}
+	return value
+}
+
+func GetAuctionStreamBuffer() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_STREAM_BUFFER"))
+	if err != nil || value <= 0 {
+		return 64
+	}
+	return value
+}
+
+// GetAuctionStreamHeartbeat é o intervalo dos comentários que mantêm a
+// conexão SSE aberta em proxies que encerram conexões ociosas.
+func GetAuctionStreamHeartbeat() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("AUCTION_STREAM_HEARTBEAT"))
+	if err != nil || duration <= 0 {
+		return 15 * time.Second
+	}
+	return duration
+}
+
+// GetAuctionStreamOrigins lista as origens, como https://app.example.com,
+// que podem abrir o WebSocket além da própria API; "*" libera qualquer uma.
+func GetAuctionStreamOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("AUCTION_STREAM_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, strings.TrimSuffix(origin, "/"))
+		}
+	}
+	return origins
+}
@@ -0,0 +1,103 @@
+package stream_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/stream"
+)
+
+func event(auctionId string, eventType auction_entity.AuctionEventType) auction_entity.AuctionEvent {
+	return auction_entity.NewAuctionEvent(eventType, auction_entity.Auction{Id: auctionId}, nil)
+}
+
+func TestAuctionBroker_FansOutToSubscribersOfTheAuction(t *testing.T) {
+	broker := stream.NewAuctionBroker(10, 10)
+
+	first := broker.Subscribe("a1", "")
+	second := broker.Subscribe("a1", "")
+	other := broker.Subscribe("a2", "")
+
+	broker.Publish(event("a1", auction_entity.EventBidAccepted))
+
+	received := <-first.Events
+	assert.Equal(t, auction_entity.EventBidAccepted, received.Type)
+	assert.NotEmpty(t, received.Id)
+	assert.Equal(t, received.Id, (<-second.Events).Id)
+	assert.Empty(t, other.Events)
+}
+
+func TestAuctionBroker_ResumesFromLastEventId(t *testing.T) {
+	broker := stream.NewAuctionBroker(10, 10)
+
+	subscription := broker.Subscribe("a1", "")
+	assert.False(t, subscription.Resumed)
+	subscription.Cancel()
+
+	broker.Publish(event("a1", auction_entity.EventBidAccepted))
+	broker.Publish(event("a1", auction_entity.EventPriceChanged))
+	broker.Publish(event("a2", auction_entity.EventBidAccepted))
+	broker.Publish(event("a1", auction_entity.EventAuctionExtended))
+
+	resumed := broker.Subscribe("a1", subscription.Cursor)
+	assert.True(t, resumed.Resumed)
+	if assert.Len(t, resumed.Missed, 3) {
+		assert.Equal(t, auction_entity.EventAuctionExtended, resumed.Missed[2].Type)
+	}
+
+	latest := broker.Subscribe("a1", resumed.Missed[2].Id)
+	assert.True(t, latest.Resumed)
+	assert.Empty(t, latest.Missed)
+
+	for _, lastEventId := range []string{"garbage", "0-1", subscription.Cursor + "9"} {
+		assert.False(t, broker.Subscribe("a1", lastEventId).Resumed, lastEventId)
+	}
+}
+
+func TestAuctionBroker_DoesNotResumeBeyondHistory(t *testing.T) {
+	broker := stream.NewAuctionBroker(2, 10)
+
+	cursor := broker.Subscribe("a1", "").Cursor
+	for i := 0; i < 3; i++ {
+		broker.Publish(event("a1", auction_entity.EventBidAccepted))
+	}
+
+	assert.False(t, broker.Subscribe("a1", cursor).Resumed)
+}
+
+func TestAuctionBroker_DropsSlowSubscriber(t *testing.T) {
+	broker := stream.NewAuctionBroker(10, 1)
+
+	slow := broker.Subscribe("a1", "")
+	broker.Publish(event("a1", auction_entity.EventBidAccepted))
+	broker.Publish(event("a1", auction_entity.EventBidAccepted))
+
+	_, ok := <-slow.Events
+	assert.True(t, ok)
+	_, ok = <-slow.Events
+	assert.False(t, ok)
+	assert.Equal(t, 0, broker.Subscribers("a1"))
+
+	// Cancelar depois de descartado não fecha o canal de novo
+	slow.Cancel()
+}
+
+func TestAuctionBroker_ForgetsClosedAuctionWithoutSubscribers(t *testing.T) {
+	broker := stream.NewAuctionBroker(10, 10)
+
+	subscription := broker.Subscribe("a1", "")
+	broker.Publish(event("a1", auction_entity.EventBidAccepted))
+	broker.Publish(event("a1", auction_entity.EventAuctionClosed))
+	accepted := <-subscription.Events
+	closed := <-subscription.Events
+	subscription.Cancel()
+
+	// Sem o histórico só quem já viu o encerramento retoma
+	assert.False(t, broker.Subscribe("a1", accepted.Id).Resumed)
+
+	resumed := broker.Subscribe("a1", closed.Id)
+	assert.True(t, resumed.Resumed)
+	assert.Empty(t, resumed.Missed)
+}
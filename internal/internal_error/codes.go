@@ -0,0 +1,48 @@
+package internal_error
+
+// Códigos estáveis dos erros de domínio, devolvidos em error_code. Os clientes
+// podem depender deles; as mensagens podem mudar.
+const (
+	CodeAuctionNotFound   = "auction_not_found"
+	CodeAuctionClosed     = "auction_closed"
+	CodeAuctionNotActive  = "auction_not_active"
+	CodeAuctionActive     = "auction_active"
+	CodeAuctionNotSettled = "auction_not_settled"
+	CodeAuctionSettled    = "auction_settled"
+	CodeNotAuctionOwner   = "not_auction_owner"
+	CodeNotAuctionParty   = "not_auction_party"
+	CodeOwnAuctionBid     = "own_auction_bid"
+
+	CodeBidNotFound         = "bid_not_found"
+	CodeBidAlreadyVoided    = "bid_already_voided"
+	CodeBidQueueFull        = "bid_queue_full"
+	CodeBidQueueUnavailable = "bid_queue_unavailable"
+
+	CodeUserNotFound        = "user_not_found"
+	CodeEmailTaken          = "email_taken"
+	CodeBidderNotRegistered = "bidder_not_registered"
+	CodeBidderSuspended     = "bidder_suspended"
+	CodeBidderInactive      = "bidder_inactive"
+	CodeBidderBlocked       = "bidder_blocked"
+	CodeUserBanned          = "user_banned"
+	CodeMissingToken        = "missing_token"
+	CodeExpiredToken        = "expired_token"
+	CodeInvalidToken        = "invalid_token"
+	CodeMissingRole         = "missing_role"
+	CodeAccountInactive     = "account_inactive"
+
+	CodeCategoryNotFound    = "category_not_found"
+	CodeCategoryInactive    = "category_inactive"
+	CodeCategoryExists      = "category_exists"
+	CodeCategoryHasChildren = "category_has_children"
+	CodeCategoryInUse       = "category_in_use"
+
+	CodeNotWatching       = "not_watching"
+	CodeNotWatchlistOwner = "not_watchlist_owner"
+
+	CodeInsufficientBalance = "insufficient_balance"
+	CodeHoldAlreadySettled  = "hold_already_settled"
+	CodeAlreadyRated        = "already_rated"
+	CodeInvalidCursor       = "invalid_cursor"
+	CodeValidationFailed    = "validation_failed"
+)
@@ -3,12 +3,32 @@ package internal_error
 type InternalError struct {
 	Message string
 	Err     string
+	// Code identifica o erro de forma estável para os clientes; vazio usa Err
+	Code   string
+	Causes []Cause
+}
+
+type Cause struct {
+	Field   string
+	Message string
 }
 
 func (ie *InternalError) Error() string {
 	return ie.Message
 }
 
+// WithCode define o código estável do erro, como auction_closed.
+func (ie *InternalError) WithCode(code string) *InternalError {
+	ie.Code = code
+	return ie
+}
+
+// WithCauses anexa os campos que causaram o erro.
+func (ie *InternalError) WithCauses(causes ...Cause) *InternalError {
+	ie.Causes = append(ie.Causes, causes...)
+	return ie
+}
+
 func NewNotFoundError(message string) *InternalError {
 	return &InternalError{
 		Message: message,
@@ -29,3 +49,52 @@ func NewBadRequestError(message string) *InternalError {
 		Err:     "bad_request",
 	}
 }
+
+func NewTooManyRequestsError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "too_many_requests",
+	}
+}
+
+func NewServiceUnavailableError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "service_unavailable",
+	}
+}
+
+func NewConflictError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "conflict",
+	}
+}
+
+func NewUnauthorizedError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "unauthorized",
+	}
+}
+
+func NewForbiddenError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "forbidden",
+	}
+}
+
+func NewGoneError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "gone",
+	}
+}
+
+func NewUnprocessableEntityError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "unprocessable_entity",
+	}
+}
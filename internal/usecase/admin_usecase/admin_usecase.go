@@ -0,0 +1,298 @@
+package admin_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"time"
+)
+
+type AdminActionInputDTO struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type UserRolesInputDTO struct {
+	Roles  []user_entity.Role `json:"roles" binding:"required,min=1,dive,oneof=bidder seller admin"`
+	Reason string             `json:"reason" binding:"max=500"`
+}
+
+// BanInputDTO bane um usuário até expires_at; sem expires_at o banimento é permanente.
+type BanInputDTO struct {
+	Reason    string     `json:"reason" binding:"required,max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type AdminActionOutputDTO struct {
+	Id         string                  `json:"id"`
+	AdminId    string                  `json:"admin_id"`
+	Action     admin_entity.ActionType `json:"action"`
+	TargetType admin_entity.TargetType `json:"target_type"`
+	TargetId   string                  `json:"target_id"`
+	Reason     string                  `json:"reason,omitempty"`
+	CreatedAt  time.Time               `json:"created_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type CloseExpiredAuctionsOutputDTO struct {
+	Closed int `json:"closed"`
+}
+
+type AdminUseCase struct {
+	auctionRepository     auction_entity.AuctionRepositoryInterface
+	bidRepository         bid_entity.BidEntityRepository
+	userRepository        user_entity.UserRepositoryInterface
+	adminActionRepository admin_entity.AdminActionRepositoryInterface
+	walletRepository      wallet_entity.WalletRepositoryInterface
+	restrictionRepository restriction_entity.RestrictionRepositoryInterface
+	auctionUseCase        auction_usecase.AuctionUseCaseInterface
+}
+
+type AdminUseCaseInterface interface {
+	CloseAuction(
+		ctx context.Context, adminId, auctionId string,
+		input AdminActionInputDTO) *internal_error.InternalError
+
+	ReopenAuction(
+		ctx context.Context, adminId, auctionId string,
+		input AdminActionInputDTO) *internal_error.InternalError
+
+	CloseExpiredAuctions(
+		ctx context.Context, adminId string) (*CloseExpiredAuctionsOutputDTO, *internal_error.InternalError)
+
+	VoidBid(
+		ctx context.Context, adminId, bidId string,
+		input AdminActionInputDTO) *internal_error.InternalError
+
+	SuspendUser(
+		ctx context.Context, adminId, userId string,
+		input AdminActionInputDTO) *internal_error.InternalError
+
+	BanUser(
+		ctx context.Context, adminId, userId string,
+		input BanInputDTO) *internal_error.InternalError
+
+	LiftBan(
+		ctx context.Context, adminId, userId string,
+		input AdminActionInputDTO) *internal_error.InternalError
+
+	UpdateUserRoles(
+		ctx context.Context, adminId, userId string,
+		input UserRolesInputDTO) *internal_error.InternalError
+
+	FindAdminActions(
+		ctx context.Context, limit int64) ([]AdminActionOutputDTO, *internal_error.InternalError)
+}
+
+func NewAdminUseCase(
+	auctionRepository auction_entity.AuctionRepositoryInterface,
+	bidRepository bid_entity.BidEntityRepository,
+	userRepository user_entity.UserRepositoryInterface,
+	adminActionRepository admin_entity.AdminActionRepositoryInterface,
+	walletRepository wallet_entity.WalletRepositoryInterface,
+	restrictionRepository restriction_entity.RestrictionRepositoryInterface,
+	auctionUseCase auction_usecase.AuctionUseCaseInterface) AdminUseCaseInterface {
+	return &AdminUseCase{
+		auctionRepository:     auctionRepository,
+		bidRepository:         bidRepository,
+		userRepository:        userRepository,
+		adminActionRepository: adminActionRepository,
+		walletRepository:      walletRepository,
+		restrictionRepository: restrictionRepository,
+		auctionUseCase:        auctionUseCase,
+	}
+}
+
+// CloseAuction encerra um leilão ativo antes do prazo.
+func (au *AdminUseCase) CloseAuction(
+	ctx context.Context, adminId, auctionId string,
+	input AdminActionInputDTO) *internal_error.InternalError {
+	if _, err := au.auctionRepository.FindAuctionById(ctx, auctionId); err != nil {
+		return err
+	}
+
+	// O repositório só encerra um leilão ainda ativo e devolve conflito caso contrário
+	if err := au.auctionUseCase.CloseAuction(ctx, auctionId); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.CloseAuction,
+		admin_entity.AuctionTarget, auctionId, input.Reason)
+}
+
+// ReopenAuction reativa um leilão encerrado ou cancelado com um novo prazo.
+func (au *AdminUseCase) ReopenAuction(
+	ctx context.Context, adminId, auctionId string,
+	input AdminActionInputDTO) *internal_error.InternalError {
+	if _, err := au.auctionRepository.FindAuctionById(ctx, auctionId); err != nil {
+		return err
+	}
+
+	if err := au.auctionUseCase.ReopenAuction(ctx, auctionId); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.ReopenAuction,
+		admin_entity.AuctionTarget, auctionId, input.Reason)
+}
+
+func (au *AdminUseCase) CloseExpiredAuctions(
+	ctx context.Context, adminId string) (*CloseExpiredAuctionsOutputDTO, *internal_error.InternalError) {
+	closed, err := au.auctionUseCase.CloseExpiredAuctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := au.record(ctx, adminId, admin_entity.CloseExpiredAuctions,
+		admin_entity.AuctionTarget, "", fmt.Sprintf("%d auctions closed", closed)); err != nil {
+		return nil, err
+	}
+
+	return &CloseExpiredAuctionsOutputDTO{Closed: closed}, nil
+}
+
+// VoidBid anula um lance; lances anulados continuam listados mas não vencem.
+func (au *AdminUseCase) VoidBid(
+	ctx context.Context, adminId, bidId string,
+	input AdminActionInputDTO) *internal_error.InternalError {
+	bid, err := au.bidRepository.FindBidById(ctx, bidId)
+	if err != nil {
+		return err
+	}
+
+	if bid.Voided {
+		return internal_error.NewConflictError("Bid is already voided").
+			WithCode(internal_error.CodeBidAlreadyVoided)
+	}
+
+	if err := au.auctionUseCase.VoidBid(ctx, bid); err != nil {
+		return err
+	}
+
+	// Um lance anulado não pode mais vencer; o saldo reservado volta ao licitante
+	if err := au.walletRepository.ReleaseHold(ctx, bidId); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.VoidBid,
+		admin_entity.BidTarget, bidId, input.Reason)
+}
+
+func (au *AdminUseCase) SuspendUser(
+	ctx context.Context, adminId, userId string,
+	input AdminActionInputDTO) *internal_error.InternalError {
+	if adminId == userId {
+		return internal_error.NewBadRequestError("Admins cannot suspend themselves")
+	}
+
+	if err := au.userRepository.UpdateUserStatus(ctx, userId, user_entity.Suspended); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.SuspendUser,
+		admin_entity.UserTarget, userId, input.Reason)
+}
+
+// BanUser bane o usuário da plataforma; um novo banimento substitui o anterior.
+func (au *AdminUseCase) BanUser(
+	ctx context.Context, adminId, userId string,
+	input BanInputDTO) *internal_error.InternalError {
+	if adminId == userId {
+		return internal_error.NewBadRequestError("Admins cannot ban themselves")
+	}
+
+	if _, err := au.userRepository.FindUserById(ctx, userId); err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+	if input.ExpiresAt != nil {
+		expiresAt = *input.ExpiresAt
+	}
+
+	ban, err := restriction_entity.NewBan(userId, adminId, input.Reason, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if err := au.restrictionRepository.BanUser(ctx, ban); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.BanUser,
+		admin_entity.UserTarget, userId, input.Reason)
+}
+
+func (au *AdminUseCase) LiftBan(
+	ctx context.Context, adminId, userId string,
+	input AdminActionInputDTO) *internal_error.InternalError {
+	if err := au.restrictionRepository.LiftBan(ctx, userId); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.LiftBan,
+		admin_entity.UserTarget, userId, input.Reason)
+}
+
+func (au *AdminUseCase) UpdateUserRoles(
+	ctx context.Context, adminId, userId string,
+	input UserRolesInputDTO) *internal_error.InternalError {
+	user, err := au.userRepository.FindUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	user.Roles = input.Roles
+	if adminId == userId && !user.HasRole(user_entity.RoleAdmin) {
+		return internal_error.NewBadRequestError("Admins cannot remove their own admin role")
+	}
+
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	if err := au.userRepository.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+
+	return au.record(ctx, adminId, admin_entity.UpdateUserRoles,
+		admin_entity.UserTarget, userId, input.Reason)
+}
+
+func (au *AdminUseCase) FindAdminActions(
+	ctx context.Context, limit int64) ([]AdminActionOutputDTO, *internal_error.InternalError) {
+	adminActions, err := au.adminActionRepository.FindAdminActions(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	adminActionOutputs := make([]AdminActionOutputDTO, 0, len(adminActions))
+	for _, adminAction := range adminActions {
+		adminActionOutputs = append(adminActionOutputs, AdminActionOutputDTO{
+			Id:         adminAction.Id,
+			AdminId:    adminAction.AdminId,
+			Action:     adminAction.Action,
+			TargetType: adminAction.TargetType,
+			TargetId:   adminAction.TargetId,
+			Reason:     adminAction.Reason,
+			CreatedAt:  adminAction.CreatedAt,
+		})
+	}
+
+	return adminActionOutputs, nil
+}
+
+func (au *AdminUseCase) record(
+	ctx context.Context,
+	adminId string,
+	action admin_entity.ActionType,
+	targetType admin_entity.TargetType,
+	targetId, reason string) *internal_error.InternalError {
+	return au.adminActionRepository.SaveAdminAction(ctx, admin_entity.NewAdminAction(
+		adminId, action, targetType, targetId, reason))
+}
@@ -0,0 +1,333 @@
+package admin_usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/admin_entity/admintest"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity/wallettest"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/admin_usecase"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+)
+
+// fakeAuctionRepository implementa apenas o que as ações administrativas usam.
+type fakeAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auctions map[string]*auction_entity.Auction
+}
+
+func (f *fakeAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, ok := f.auctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("auction not found")
+	}
+	copied := *auction
+	return &copied, nil
+}
+
+func (f *fakeAuctionRepository) UpdateAuctionStatus(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	if f.auctions[id].Status != auction_entity.Active {
+		return internal_error.NewConflictError("Only active auctions can be closed").
+			WithCode(internal_error.CodeAuctionNotActive)
+	}
+	f.auctions[id].Status = status
+	return nil
+}
+
+func (f *fakeAuctionRepository) ReopenAuction(ctx context.Context, id string, timestamp time.Time) *internal_error.InternalError {
+	f.auctions[id].Status = auction_entity.Active
+	f.auctions[id].Timestamp = timestamp
+	return nil
+}
+
+type fakeBidRepository struct {
+	bid_entity.BidEntityRepository
+	bids map[string]*bid_entity.Bid
+}
+
+func (f *fakeBidRepository) FindBidById(ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	bid, ok := f.bids[bidId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("bid not found")
+	}
+	copied := *bid
+	return &copied, nil
+}
+
+func (f *fakeBidRepository) VoidBid(ctx context.Context, bidId string) *internal_error.InternalError {
+	f.bids[bidId].Voided = true
+	return nil
+}
+
+func (f *fakeBidRepository) FindWinningBidByAuctionId(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	var winningBid *bid_entity.Bid
+	for _, bid := range f.bids {
+		if bid.AuctionId == auctionId && !bid.Voided && (winningBid == nil || bid.Amount > winningBid.Amount) {
+			winningBid = bid
+		}
+	}
+	if winningBid == nil {
+		return nil, internal_error.NewNotFoundError("bid not found")
+	}
+	copied := *winningBid
+	return &copied, nil
+}
+
+type fakeUserRepository struct {
+	user_entity.UserRepositoryInterface
+	users map[string]*user_entity.User
+}
+
+func (f *fakeUserRepository) FindUserById(ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	copied := *f.users[userId]
+	return &copied, nil
+}
+
+func (f *fakeUserRepository) UpdateUser(ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	f.users[userEntity.Id] = userEntity
+	return nil
+}
+
+func (f *fakeUserRepository) UpdateUserStatus(ctx context.Context, userId string, status user_entity.UserStatus) *internal_error.InternalError {
+	f.users[userId].Status = status
+	return nil
+}
+
+// fakeRestrictionRepository guarda os banimentos em memória
+type fakeRestrictionRepository struct {
+	restriction_entity.RestrictionRepositoryInterface
+	bans map[string]*restriction_entity.Ban
+}
+
+func (f *fakeRestrictionRepository) BanUser(ctx context.Context, ban *restriction_entity.Ban) *internal_error.InternalError {
+	f.bans[ban.UserId] = ban
+	return nil
+}
+
+func (f *fakeRestrictionRepository) LiftBan(ctx context.Context, userId string) *internal_error.InternalError {
+	if _, ok := f.bans[userId]; !ok {
+		return internal_error.NewNotFoundError("User is not banned")
+	}
+	delete(f.bans, userId)
+	return nil
+}
+
+// adminFixture reúne os dublês das ações administrativas. newAdminUseCase
+// monta o AuctionUseCase sobre os mesmos repositórios, como o main faz, e
+// troca os campos nil da carteira e da auditoria por dublês vazios.
+type adminFixture struct {
+	auctions     auction_entity.AuctionRepositoryInterface
+	bids         bid_entity.BidEntityRepository
+	users        user_entity.UserRepositoryInterface
+	wallets      wallet_entity.WalletRepositoryInterface
+	restrictions restriction_entity.RestrictionRepositoryInterface
+	adminActions admin_entity.AdminActionRepositoryInterface
+}
+
+func (f adminFixture) newAdminUseCase() admin_usecase.AdminUseCaseInterface {
+	if f.wallets == nil {
+		f.wallets = wallettest.NewFakeWalletRepository()
+	}
+	if f.adminActions == nil {
+		f.adminActions = &admintest.RecordingAdminActionRepository{}
+	}
+	auctionUC := auction_usecase.NewAuctionUseCase(f.auctions, f.bids, f.adminActions, f.wallets, nil, nil)
+	return admin_usecase.NewAdminUseCase(
+		f.auctions, f.bids, f.users, f.adminActions, f.wallets, f.restrictions, auctionUC)
+}
+
+func TestAdminUseCase_CloseAndReopenAuction(t *testing.T) {
+	auctions := &fakeAuctionRepository{auctions: map[string]*auction_entity.Auction{
+		"a1": {Id: "a1", Status: auction_entity.Active, Timestamp: time.Now().Add(-time.Hour)},
+		"a2": {Id: "a2", Status: auction_entity.Active, Timestamp: time.Now().Add(-time.Hour)},
+	}}
+	bids := &fakeBidRepository{bids: map[string]*bid_entity.Bid{
+		"b1": {Id: "b1", UserId: "u1", AuctionId: "a1", Amount: 10},
+		"b2": {Id: "b2", UserId: "u2", AuctionId: "a1", Amount: 20},
+	}}
+	wallets := wallettest.NewFakeWalletRepository(
+		wallet_entity.NewHold("b1", "u1", "a1", 10),
+		wallet_entity.NewHold("b2", "u2", "a1", 20),
+	)
+	wallets.Balances = map[string]float64{"u1": 100, "u2": 100}
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	adminUC := adminFixture{auctions: auctions, bids: bids, wallets: wallets, adminActions: adminActions}.newAdminUseCase()
+
+	input := admin_usecase.AdminActionInputDTO{Reason: "fraud report"}
+	assert.Nil(t, adminUC.CloseAuction(context.Background(), "admin-1", "a1", input))
+	assert.Equal(t, auction_entity.Completed, auctions.auctions["a1"].Status)
+
+	// O lance vencedor é capturado e os demais voltam ao saldo livre
+	assert.Equal(t, wallet_entity.Captured, wallets.HoldStatus("b2"))
+	assert.Equal(t, wallet_entity.Released, wallets.HoldStatus("b1"))
+
+	err := adminUC.CloseAuction(context.Background(), "admin-1", "a1", input)
+	assert.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+
+	// O vencedor já foi cobrado, então o leilão não reabre nem é liquidado de novo
+	err = adminUC.ReopenAuction(context.Background(), "admin-2", "a1", input)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "conflict", err.Err)
+		assert.Equal(t, internal_error.CodeAuctionSettled, err.Code)
+	}
+	assert.Equal(t, auction_entity.Completed, auctions.auctions["a1"].Status)
+	assert.NotNil(t, adminUC.CloseAuction(context.Background(), "admin-1", "a1", input))
+	assert.Equal(t, map[string]float64{"u1": 100, "u2": 80}, wallets.Balances)
+
+	// Um leilão encerrado sem cobrança reabre e pode ser encerrado outra vez
+	assert.Nil(t, adminUC.CloseAuction(context.Background(), "admin-1", "a2", input))
+	assert.Nil(t, adminUC.ReopenAuction(context.Background(), "admin-2", "a2", input))
+	assert.Equal(t, auction_entity.Active, auctions.auctions["a2"].Status)
+	assert.WithinDuration(t, time.Now(), auctions.auctions["a2"].Timestamp, time.Second)
+	assert.Nil(t, adminUC.CloseAuction(context.Background(), "admin-1", "a2", input))
+	assert.Equal(t, map[string]float64{"u1": 100, "u2": 80}, wallets.Balances)
+
+	assert.Len(t, adminActions.Actions, 4)
+	assert.Equal(t, "admin-1", adminActions.Actions[0].AdminId)
+	assert.Equal(t, admin_entity.CloseAuction, adminActions.Actions[0].Action)
+	assert.Equal(t, "fraud report", adminActions.Actions[0].Reason)
+	assert.Equal(t, "admin-2", adminActions.Actions[2].AdminId)
+	assert.Equal(t, admin_entity.ReopenAuction, adminActions.Actions[2].Action)
+}
+
+// staleAuctionRepository simula uma leitura anterior ao encerramento do leilão
+type staleAuctionRepository struct {
+	*fakeAuctionRepository
+}
+
+func (f staleAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := f.fakeAuctionRepository.FindAuctionById(ctx, id)
+	if err == nil {
+		auction.Status = auction_entity.Active
+	}
+	return auction, err
+}
+
+func TestAdminUseCase_CloseAuctionLosesRaceWithExpiry(t *testing.T) {
+	auctions := &fakeAuctionRepository{auctions: map[string]*auction_entity.Auction{
+		"a1": {Id: "a1", Status: auction_entity.Completed},
+	}}
+	wallets := wallettest.NewFakeWalletRepository(wallet_entity.NewHold("b1", "u1", "a1", 10))
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	adminUC := adminFixture{
+		auctions: staleAuctionRepository{auctions}, wallets: wallets, adminActions: adminActions}.newAdminUseCase()
+
+	err := adminUC.CloseAuction(context.Background(), "admin-1", "a1", admin_usecase.AdminActionInputDTO{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+	assert.Equal(t, internal_error.CodeAuctionNotActive, err.Code)
+
+	// Quem perdeu a transição não liquida as reservas nem audita a ação
+	assert.Equal(t, wallet_entity.Held, wallets.HoldStatus("b1"))
+	assert.Empty(t, adminActions.Actions)
+}
+
+func TestAdminUseCase_VoidBid(t *testing.T) {
+	auctions := &fakeAuctionRepository{auctions: map[string]*auction_entity.Auction{
+		"a1": {Id: "a1", Status: auction_entity.Active},
+		"a2": {Id: "a2", Status: auction_entity.Completed},
+	}}
+	bids := &fakeBidRepository{bids: map[string]*bid_entity.Bid{
+		"b1": {Id: "b1", AuctionId: "a1"},
+		"b2": {Id: "b2", AuctionId: "a2"},
+	}}
+	captured := wallet_entity.NewHold("b2", "u2", "a2", 20)
+	captured.Status = wallet_entity.Captured
+	wallets := wallettest.NewFakeWalletRepository(wallet_entity.NewHold("b1", "u1", "a1", 10), captured)
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	adminUC := adminFixture{auctions: auctions, bids: bids, wallets: wallets, adminActions: adminActions}.newAdminUseCase()
+
+	assert.Nil(t, adminUC.VoidBid(context.Background(), "admin-1", "b1", admin_usecase.AdminActionInputDTO{}))
+	assert.True(t, bids.bids["b1"].Voided)
+	assert.Equal(t, wallet_entity.Released, wallets.HoldStatus("b1"))
+
+	err := adminUC.VoidBid(context.Background(), "admin-1", "b1", admin_usecase.AdminActionInputDTO{})
+	assert.Equal(t, "conflict", err.Err)
+
+	err = adminUC.VoidBid(context.Background(), "admin-1", "missing", admin_usecase.AdminActionInputDTO{})
+	assert.Equal(t, "not_found", err.Err)
+
+	// O vencedor de um leilão encerrado já foi cobrado e continua vencedor
+	err = adminUC.VoidBid(context.Background(), "admin-1", "b2", admin_usecase.AdminActionInputDTO{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "conflict", err.Err)
+		assert.Equal(t, internal_error.CodeAuctionSettled, err.Code)
+	}
+	assert.False(t, bids.bids["b2"].Voided)
+	assert.Equal(t, wallet_entity.Captured, wallets.HoldStatus("b2"))
+
+	assert.Len(t, adminActions.Actions, 1)
+	assert.Equal(t, admin_entity.BidTarget, adminActions.Actions[0].TargetType)
+}
+
+func TestAdminUseCase_SuspendUserAndRoles(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*user_entity.User{
+		"admin-1": {Id: "admin-1", Name: "Admin", Email: "admin@example.com",
+			Status: user_entity.Active, Roles: []user_entity.Role{user_entity.RoleAdmin}},
+		"u1": {Id: "u1", Name: "User", Email: "user@example.com",
+			Status: user_entity.Active, Roles: []user_entity.Role{user_entity.RoleBidder}},
+	}}
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	adminUC := adminFixture{users: users, adminActions: adminActions}.newAdminUseCase()
+
+	assert.Nil(t, adminUC.SuspendUser(context.Background(), "admin-1", "u1", admin_usecase.AdminActionInputDTO{}))
+	assert.Equal(t, user_entity.Suspended, users.users["u1"].Status)
+
+	err := adminUC.SuspendUser(context.Background(), "admin-1", "admin-1", admin_usecase.AdminActionInputDTO{})
+	assert.Equal(t, "bad_request", err.Err)
+
+	assert.Nil(t, adminUC.UpdateUserRoles(context.Background(), "admin-1", "u1", admin_usecase.UserRolesInputDTO{
+		Roles: []user_entity.Role{user_entity.RoleBidder, user_entity.RoleSeller},
+	}))
+	assert.True(t, users.users["u1"].HasRole(user_entity.RoleSeller))
+
+	err = adminUC.UpdateUserRoles(context.Background(), "admin-1", "admin-1", admin_usecase.UserRolesInputDTO{
+		Roles: []user_entity.Role{user_entity.RoleBidder},
+	})
+	assert.Equal(t, "bad_request", err.Err)
+
+	assert.Len(t, adminActions.Actions, 2)
+	assert.Equal(t, admin_entity.SuspendUser, adminActions.Actions[0].Action)
+	assert.Equal(t, admin_entity.UpdateUserRoles, adminActions.Actions[1].Action)
+}
+
+func TestAdminUseCase_BanAndLiftBan(t *testing.T) {
+	users := &fakeUserRepository{users: map[string]*user_entity.User{
+		"u1": {Id: "u1", Name: "User", Email: "user@example.com",
+			Status: user_entity.Active, Roles: []user_entity.Role{user_entity.RoleBidder}},
+	}}
+	restrictions := &fakeRestrictionRepository{bans: map[string]*restriction_entity.Ban{}}
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	adminUC := adminFixture{users: users, restrictions: restrictions, adminActions: adminActions}.newAdminUseCase()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	assert.Nil(t, adminUC.BanUser(context.Background(), "admin-1", "u1", admin_usecase.BanInputDTO{
+		Reason: "chargeback", ExpiresAt: &expiresAt,
+	}))
+	assert.Equal(t, "chargeback", restrictions.bans["u1"].Reason)
+	assert.Equal(t, "admin-1", restrictions.bans["u1"].AdminId)
+
+	err := adminUC.BanUser(context.Background(), "admin-1", "admin-1", admin_usecase.BanInputDTO{Reason: "x"})
+	assert.Equal(t, "bad_request", err.Err)
+
+	assert.Nil(t, adminUC.LiftBan(context.Background(), "admin-1", "u1", admin_usecase.AdminActionInputDTO{}))
+	assert.Empty(t, restrictions.bans)
+
+	err = adminUC.LiftBan(context.Background(), "admin-1", "u1", admin_usecase.AdminActionInputDTO{})
+	assert.Equal(t, "not_found", err.Err)
+
+	assert.Len(t, adminActions.Actions, 2)
+	assert.Equal(t, admin_entity.BanUser, adminActions.Actions[0].Action)
+	assert.Equal(t, admin_entity.LiftBan, adminActions.Actions[1].Action)
+}
@@ -0,0 +1,42 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type AuctionCacheStatsOutputDTO struct {
+	Hits       uint64  `json:"hits"`
+	Misses     uint64  `json:"misses"`
+	HitRatio   float64 `json:"hit_ratio"`
+	Evictions  uint64  `json:"evictions"`
+	Size       int     `json:"size"`
+	MaxEntries int     `json:"max_entries"`
+	TTLSeconds float64 `json:"ttl_seconds"`
+}
+
+func (au *AuctionUseCase) FindCacheStats(
+	ctx context.Context) (*AuctionCacheStatsOutputDTO, *internal_error.InternalError) {
+	auctionCache, ok := au.auctionRepositoryInterface.(auction_entity.AuctionCacheInterface)
+	if !ok {
+		return nil, internal_error.NewNotFoundError("Auction cache is not enabled")
+	}
+
+	stats := auctionCache.Stats()
+
+	var hitRatio float64
+	if total := stats.Hits + stats.Misses; total > 0 {
+		hitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	return &AuctionCacheStatsOutputDTO{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		HitRatio:   hitRatio,
+		Evictions:  stats.Evictions,
+		Size:       stats.Size,
+		MaxEntries: stats.MaxEntries,
+		TTLSeconds: stats.TTL.Seconds(),
+	}, nil
+}
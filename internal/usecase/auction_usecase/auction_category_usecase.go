@@ -0,0 +1,68 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+)
+
+// resolveCategory valida a categoria de um leilão contra a taxonomia: ela
+// precisa existir e estar ativa, assim como todas as categorias acima dela.
+func (au *AuctionUseCase) resolveCategory(
+	ctx context.Context, path string) (*category_entity.Category, *internal_error.InternalError) {
+	category, err := au.categoryRepository.FindCategoryByPath(ctx, normalizeCategoryPath(path))
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewUnprocessableEntityError("Category does not exist").
+				WithCode(internal_error.CodeCategoryNotFound).
+				WithCauses(internal_error.Cause{Field: "category", Message: "category is not in the taxonomy"})
+		}
+		return nil, err
+	}
+
+	inactive := !category.Active
+	for _, ancestorPath := range category.AncestorPaths() {
+		if inactive {
+			break
+		}
+
+		ancestor, err := au.categoryRepository.FindCategoryByPath(ctx, ancestorPath)
+		if err != nil {
+			return nil, err
+		}
+		inactive = !ancestor.Active
+	}
+
+	if inactive {
+		return nil, internal_error.NewUnprocessableEntityError("Category is not active").
+			WithCode(internal_error.CodeCategoryInactive).
+			WithCauses(internal_error.Cause{Field: "category", Message: "category is not active"})
+	}
+
+	return category, nil
+}
+
+// categoryFilter traduz o filtro de categoria das buscas. Uma categoria da
+// taxonomia sempre traz também as suas subcategorias; categorias fora dela
+// (leilões anteriores à taxonomia) continuam filtradas pelo texto informado.
+func (au *AuctionUseCase) categoryFilter(
+	ctx context.Context, category string, includeSubcategories bool) (string, bool, *internal_error.InternalError) {
+	if category == "" {
+		return category, includeSubcategories, nil
+	}
+
+	taxonomyCategory, err := au.categoryRepository.FindCategoryByPath(ctx, normalizeCategoryPath(category))
+	if err != nil {
+		if err.Err == "not_found" {
+			return category, includeSubcategories, nil
+		}
+		return "", false, err
+	}
+
+	return taxonomyCategory.Path, true, nil
+}
+
+func normalizeCategoryPath(path string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(path)), category_entity.PathSeparator)
+}
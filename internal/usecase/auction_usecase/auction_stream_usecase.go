@@ -0,0 +1,160 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+)
+
+// AuctionEventOutputDTO é um evento do stream do leilão. Auction é o estado
+// logo após o evento; Bid é o lance aceito ou, no encerramento, o vencedor.
+type AuctionEventOutputDTO struct {
+	Id        string                          `json:"id"`
+	Type      auction_entity.AuctionEventType `json:"type"`
+	AuctionId string                          `json:"auction_id"`
+	Auction   AuctionOutputDTO                `json:"auction"`
+	Bid       *AuctionEventBidOutputDTO       `json:"bid"`
+	Timestamp time.Time                       `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+// AuctionEventBidOutputDTO mascara o licitante como em leading_bidder.
+type AuctionEventBidOutputDTO struct {
+	Id        string    `json:"id"`
+	Bidder    string    `json:"bidder"`
+	Amount    float64   `json:"amount"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+// AuctionEventStream entrega os eventos de um leilão até Close ser chamado
+// ou o assinante ficar para trás, quando Events é fechado.
+type AuctionEventStream struct {
+	Events <-chan AuctionEventOutputDTO
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *AuctionEventStream) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// SubscribeAuctionEvents assina os eventos do leilão. Com um lastEventId
+// ainda no histórico o stream retoma com os eventos perdidos; caso contrário
+// começa por um snapshot do estado atual.
+func (au *AuctionUseCase) SubscribeAuctionEvents(
+	ctx context.Context,
+	auctionId, lastEventId string) (*AuctionEventStream, *internal_error.InternalError) {
+	if au.eventBroker == nil {
+		return nil, internal_error.NewServiceUnavailableError("Auction stream is not available")
+	}
+
+	// Assina antes de ler o leilão, para nenhum evento cair entre o snapshot e o stream
+	subscription := au.eventBroker.Subscribe(auctionId, lastEventId)
+
+	auctionEntity, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		subscription.Cancel()
+		return nil, err
+	}
+
+	events := make(chan AuctionEventOutputDTO)
+	stream := &AuctionEventStream{Events: events, done: make(chan struct{})}
+
+	pending := subscription.Missed
+	if !subscription.Resumed {
+		snapshot := auction_entity.NewAuctionEvent(auction_entity.EventSnapshot, *auctionEntity, nil)
+		snapshot.Id = subscription.Cursor
+		pending = []auction_entity.AuctionEvent{snapshot}
+	}
+
+	go func() {
+		defer close(events)
+		defer subscription.Cancel()
+
+		send := func(event auction_entity.AuctionEvent) bool {
+			select {
+			case events <- au.toAuctionEventOutputDTO(event):
+				return true
+			case <-stream.done:
+				return false
+			}
+		}
+
+		for _, event := range pending {
+			if !send(event) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case event, ok := <-subscription.Events:
+				if !ok || !send(event) {
+					return
+				}
+			case <-stream.done:
+				return
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+// publishEvent relê o leilão, já com o estado gravado, e publica o evento.
+// Sem broker configurado os eventos são descartados.
+func (au *AuctionUseCase) publishEvent(
+	ctx context.Context,
+	auctionId string,
+	eventType auction_entity.AuctionEventType,
+	bid *auction_entity.AuctionEventBid) {
+	if au.eventBroker == nil {
+		return
+	}
+
+	auctionEntity, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		logger.Error("Error trying to publish an event of auction "+auctionId, err)
+		return
+	}
+
+	au.eventBroker.Publish(auction_entity.NewAuctionEvent(eventType, *auctionEntity, bid))
+}
+
+func (au *AuctionUseCase) toAuctionEventOutputDTO(event auction_entity.AuctionEvent) AuctionEventOutputDTO {
+	output := AuctionEventOutputDTO{
+		Id:        event.Id,
+		Type:      event.Type,
+		AuctionId: event.Auction.Id,
+		Auction:   au.toAuctionOutputDTO(event.Auction),
+		Timestamp: event.Timestamp,
+	}
+
+	if event.Bid != nil {
+		output.Bid = &AuctionEventBidOutputDTO{
+			Id:        event.Bid.Id,
+			Bidder:    maskUserId(event.Bid.BidderId),
+			Amount:    event.Bid.Amount,
+			Timestamp: event.Bid.Timestamp,
+		}
+	}
+
+	return output
+}
+
+func toAuctionEventBid(bid *bid_entity.Bid) *auction_entity.AuctionEventBid {
+	if bid == nil {
+		return nil
+	}
+
+	return &auction_entity.AuctionEventBid{
+		Id:        bid.Id,
+		BidderId:  bid.UserId,
+		Amount:    bid.Amount,
+		Timestamp: bid.Timestamp,
+	}
+}
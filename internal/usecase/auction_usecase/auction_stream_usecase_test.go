@@ -0,0 +1,231 @@
+package auction_usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity/auctiontest"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity/wallettest"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type fakeEventBroker struct {
+	published    []auction_entity.AuctionEvent
+	subscription *auction_entity.AuctionEventSubscription
+}
+
+func (b *fakeEventBroker) Publish(event auction_entity.AuctionEvent) {
+	b.published = append(b.published, event)
+}
+
+func (b *fakeEventBroker) Subscribe(auctionId, lastEventId string) *auction_entity.AuctionEventSubscription {
+	return b.subscription
+}
+
+// newSubscription devolve uma assinatura cujo canal cancelled fecha no Cancel.
+func newSubscription(
+	resumed bool, missed ...auction_entity.AuctionEvent) (*auction_entity.AuctionEventSubscription, chan auction_entity.AuctionEvent, chan struct{}) {
+	events := make(chan auction_entity.AuctionEvent, 1)
+	cancelled := make(chan struct{})
+	return &auction_entity.AuctionEventSubscription{
+		Missed:  missed,
+		Resumed: resumed,
+		Cursor:  "epoch-7",
+		Events:  events,
+		Cancel:  func() { close(cancelled) },
+	}, events, cancelled
+}
+
+type fakeBidRepository struct {
+	bid_entity.BidEntityRepository
+	winning *bid_entity.Bid
+	voided  []string
+}
+
+func (f *fakeBidRepository) VoidBid(ctx context.Context, bidId string) *internal_error.InternalError {
+	f.voided = append(f.voided, bidId)
+	return nil
+}
+
+func (f *fakeBidRepository) FindWinningBidByAuctionId(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	if f.winning == nil {
+		return nil, internal_error.NewNotFoundError("no bids")
+	}
+	return f.winning, nil
+}
+
+func TestSubscribeAuctionEvents_StartsWithSnapshotUnlessResumed(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", Status: auction_entity.Active, CurrentPrice: 30, BidCount: 1, LeadingBidderId: "bidder-1",
+	}, (*internal_error.InternalError)(nil))
+
+	subscription, events, cancelled := newSubscription(false)
+	broker := &fakeEventBroker{subscription: subscription}
+	auctionUC := auctionFixture{auctions: mockRepo, broker: broker}.newAuctionUseCase()
+
+	stream, err := auctionUC.SubscribeAuctionEvents(context.Background(), "a1", "")
+	assert.Nil(t, err)
+
+	snapshot := <-stream.Events
+	assert.Equal(t, auction_entity.EventSnapshot, snapshot.Type)
+	assert.Equal(t, "epoch-7", snapshot.Id)
+	assert.Equal(t, 30.0, snapshot.Auction.CurrentPrice)
+	assert.Nil(t, snapshot.Bid)
+
+	events <- auction_entity.AuctionEvent{
+		Id: "epoch-8", Type: auction_entity.EventBidAccepted, Auction: auction_entity.Auction{Id: "a1"},
+		Bid: &auction_entity.AuctionEventBid{Id: "b2", BidderId: "bidder-2", Amount: 40},
+	}
+	accepted := <-stream.Events
+	assert.Equal(t, "epoch-8", accepted.Id)
+	assert.Equal(t, "bidd****", accepted.Bid.Bidder)
+
+	stream.Close()
+	<-cancelled
+	_, ok := <-stream.Events
+	assert.False(t, ok)
+
+	// Retomando, o stream começa pelos eventos perdidos, sem snapshot
+	missed := auction_entity.AuctionEvent{Id: "epoch-6", Type: auction_entity.EventPriceChanged, Auction: auction_entity.Auction{Id: "a1"}}
+	broker.subscription, _, _ = newSubscription(true, missed)
+
+	stream, err = auctionUC.SubscribeAuctionEvents(context.Background(), "a1", "epoch-5")
+	assert.Nil(t, err)
+	assert.Equal(t, "epoch-6", (<-stream.Events).Id)
+	stream.Close()
+}
+
+func TestSubscribeAuctionEvents_UnknownAuction(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	mockRepo.On("FindAuctionById", mock.Anything, "missing").Return(
+		(*auction_entity.Auction)(nil), internal_error.NewNotFoundError("Auction not found"))
+
+	subscription, _, cancelled := newSubscription(false)
+	auctionUC := auctionFixture{auctions: mockRepo, broker: &fakeEventBroker{subscription: subscription}}.newAuctionUseCase()
+
+	_, err := auctionUC.SubscribeAuctionEvents(context.Background(), "missing", "")
+	assert.Equal(t, "not_found", err.Err)
+	<-cancelled
+}
+
+func TestCloseAuction_PublishesWinner(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Completed).Return(nil)
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", Status: auction_entity.Completed,
+	}, (*internal_error.InternalError)(nil))
+
+	broker := &fakeEventBroker{}
+	bids := &fakeBidRepository{winning: &bid_entity.Bid{Id: "b1", UserId: "bidder-1", AuctionId: "a1", Amount: 90}}
+	auctionUC := auctionFixture{auctions: mockRepo, bids: bids, broker: broker}.newAuctionUseCase()
+
+	assert.Nil(t, auctionUC.CloseAuction(context.Background(), "a1"))
+
+	if assert.Len(t, broker.published, 1) {
+		closed := broker.published[0]
+		assert.Equal(t, auction_entity.EventAuctionClosed, closed.Type)
+		assert.Equal(t, auction_entity.Completed, closed.Auction.Status)
+		assert.Equal(t, "bidder-1", closed.Bid.BidderId)
+		assert.Equal(t, 90.0, closed.Bid.Amount)
+	}
+}
+
+func TestCloseAuction_SettlesOnlyWhenTheTransitionMatched(t *testing.T) {
+	newHolds := func() *wallettest.FakeWalletRepository {
+		return wallettest.NewFakeWalletRepository(
+			wallet_entity.NewHold("b1", "bidder-1", "a1", 90),
+			wallet_entity.NewHold("b2", "bidder-2", "a1", 50),
+		)
+	}
+	bids := &fakeBidRepository{winning: &bid_entity.Bid{Id: "b1", UserId: "bidder-1", AuctionId: "a1", Amount: 90}}
+
+	// Outro encerramento (ou o cancelamento) venceu a transição
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Completed).Return(
+		internal_error.NewConflictError("Only active auctions can be closed").
+			WithCode(internal_error.CodeAuctionNotActive))
+	wallets, broker := newHolds(), &fakeEventBroker{}
+	auctionUC := auctionFixture{auctions: mockRepo, bids: bids, wallets: wallets, broker: broker}.newAuctionUseCase()
+
+	err := auctionUC.CloseAuction(context.Background(), "a1")
+	assert.NotNil(t, err)
+	assert.Equal(t, internal_error.CodeAuctionNotActive, err.Code)
+	assert.Equal(t, wallet_entity.Held, wallets.HoldStatus("b1"))
+	assert.Equal(t, wallet_entity.Held, wallets.HoldStatus("b2"))
+	assert.Empty(t, broker.published)
+
+	mockRepo = new(auctiontest.MockAuctionRepository)
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Completed).Return(nil)
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", Status: auction_entity.Completed,
+	}, (*internal_error.InternalError)(nil))
+	wallets = newHolds()
+	auctionUC = auctionFixture{auctions: mockRepo, bids: bids, wallets: wallets, broker: broker}.newAuctionUseCase()
+
+	assert.Nil(t, auctionUC.CloseAuction(context.Background(), "a1"))
+	assert.Equal(t, wallet_entity.Captured, wallets.HoldStatus("b1"))
+	assert.Equal(t, wallet_entity.Released, wallets.HoldStatus("b2"))
+}
+
+func TestCloseAuction_ReleasesEveryHoldBelowTheReserve(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Completed).Return(nil)
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", Status: auction_entity.Completed, ReservePrice: 100, CurrentPrice: 90, BidCount: 2,
+	}, (*internal_error.InternalError)(nil))
+
+	wallets := wallettest.NewFakeWalletRepository(
+		wallet_entity.NewHold("b1", "bidder-1", "a1", 90),
+		wallet_entity.NewHold("b2", "bidder-2", "a1", 50),
+	)
+	bids := &fakeBidRepository{winning: &bid_entity.Bid{Id: "b1", UserId: "bidder-1", AuctionId: "a1", Amount: 90}}
+	broker := &fakeEventBroker{}
+	auctionUC := auctionFixture{auctions: mockRepo, bids: bids, wallets: wallets, broker: broker}.newAuctionUseCase()
+
+	// Sem alcançar a reserva não há venda, então ninguém é cobrado
+	assert.Nil(t, auctionUC.CloseAuction(context.Background(), "a1"))
+	assert.Equal(t, wallet_entity.Released, wallets.HoldStatus("b1"))
+	assert.Equal(t, wallet_entity.Released, wallets.HoldStatus("b2"))
+	if assert.Len(t, broker.published, 1) {
+		assert.Nil(t, broker.published[0].Bid)
+	}
+}
+
+func TestVoidBid_PublishesThePriceOnlyWhenTheLeaderIsVoided(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", Status: auction_entity.Active, CurrentPrice: 90, LeadingBidderId: "bidder-1",
+	}, (*internal_error.InternalError)(nil)).Once()
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", Status: auction_entity.Active, CurrentPrice: 50, LeadingBidderId: "bidder-2",
+	}, (*internal_error.InternalError)(nil))
+
+	broker, bids := &fakeEventBroker{}, &fakeBidRepository{}
+	auctionUC := auctionFixture{auctions: mockRepo, bids: bids, broker: broker}.newAuctionUseCase()
+
+	leading := &bid_entity.Bid{Id: "b1", UserId: "bidder-1", AuctionId: "a1", Amount: 90}
+	assert.Nil(t, auctionUC.VoidBid(context.Background(), leading))
+	assert.Equal(t, []string{"b1"}, bids.voided)
+
+	// O evento traz o preço e o licitante que passaram a liderar
+	if assert.Len(t, broker.published, 1) {
+		changed := broker.published[0]
+		assert.Equal(t, auction_entity.EventPriceChanged, changed.Type)
+		assert.Equal(t, 50.0, changed.Auction.CurrentPrice)
+		assert.Equal(t, "bidder-2", changed.Auction.LeadingBidderId)
+		assert.Nil(t, changed.Bid)
+	}
+
+	// Anular um lance que não liderava não muda o preço
+	outbid := &bid_entity.Bid{Id: "b0", UserId: "bidder-3", AuctionId: "a1", Amount: 20}
+	assert.Nil(t, auctionUC.VoidBid(context.Background(), outbid))
+	assert.Equal(t, []string{"b1", "b0"}, bids.voided)
+	assert.Len(t, broker.published, 1)
+}
@@ -4,32 +4,29 @@ import (
 	"context"
 	"fullcycle-auction_go/internal/internal_error"
 	"log"
-	"time"
 )
 
-func (au *AuctionUseCase) CloseExpiredAuctions(ctx context.Context) *internal_error.InternalError {
-	go func() {
-		for {
-			// Obtém os leilões expirados
-			expiredAuctions, err := au.FindExpiredAuctions(ctx)
-			if err != nil {
-				log.Printf("Erro ao buscar leilões expirados: %v\n", err)
-				return
-			}
+// CloseExpiredAuctions fecha, em uma única passada, os leilões cujo prazo
+// terminou e devolve quantos foram fechados.
+func (au *AuctionUseCase) CloseExpiredAuctions(ctx context.Context) (int, *internal_error.InternalError) {
+	// Obtém os leilões expirados
+	expiredAuctions, err := au.FindExpiredAuctions(ctx)
+	if err != nil {
+		log.Printf("Erro ao buscar leilões expirados: %v\n", err)
+		return 0, err
+	}
 
-			// Fecha os leilões expirados
-			for _, auction := range expiredAuctions {
-				err := au.auctionRepositoryInterface.UpdateAuctionStatus(ctx, auction.Id, 1) // 1 = Fechado
-				if err != nil {
-					log.Printf("Erro ao fechar leilão com ID %s: %v\n", auction.Id, err)
-				} else {
-					log.Printf("Leilão com ID %s fechado com sucesso.\n", auction.Id)
-				}
-			}
-
-			// Espera o intervalo antes de verificar novamente
-			time.Sleep(time.Minute) // intervalo de 1 minuto
+	// Fecha os leilões expirados
+	closed := 0
+	for _, auction := range expiredAuctions {
+		if err := au.CloseAuction(ctx, auction.Id); err != nil {
+			log.Printf("Erro ao fechar leilão com ID %s: %v\n", auction.Id, err)
+			continue
 		}
-	}()
-	return nil
+
+		log.Printf("Leilão com ID %s fechado com sucesso.\n", auction.Id)
+		closed++
+	}
+
+	return closed, nil
 }
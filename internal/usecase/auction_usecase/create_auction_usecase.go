@@ -2,28 +2,76 @@ package auction_usecase
 
 import (
 	"context"
+	"fullcycle-auction_go/internal/entity/admin_entity"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/utils"
 	"time"
 )
 
+// AuctionInputDTO cria um leilão. Category é o caminho de uma categoria ativa
+// da taxonomia ("eletronicos/celulares"). O preço de reserva é opcional e
+// nunca é exibido aos licitantes, que só veem se ele foi atingido.
 type AuctionInputDTO struct {
-	ProductName string           `json:"product_name" binding:"required,min=1"`
-	Category    string           `json:"category" binding:"required,min=2"`
-	Description string           `json:"description" binding:"required,min=10,max=200"`
-	Condition   ProductCondition `json:"condition" binding:"oneof=0 1 2"`
+	// SellerId é sempre o usuário autenticado, nunca o corpo da requisição
+	SellerId     string           `json:"-"`
+	ProductName  string           `json:"product_name" binding:"required,min=1"`
+	Category     string           `json:"category" binding:"required,min=2"`
+	Description  string           `json:"description" binding:"required,min=10,max=200"`
+	Condition    ProductCondition `json:"condition" binding:"oneof=1 2 3"`
+	ReservePrice float64          `json:"reserve_price" binding:"gte=0"`
 }
 
+// AuctionOutputDTO traz, além dos dados do leilão, o seu estado ao vivo.
+// LeadingBidder é o id do licitante líder mascarado e é nulo sem lances.
 type AuctionOutputDTO struct {
-	Id          string           `json:"id"`
-	ProductName string           `json:"product_name"`
-	Category    string           `json:"category"`
-	Description string           `json:"description"`
-	Condition   ProductCondition `json:"condition"`
-	Status      AuctionStatus    `json:"status"`
-	Timestamp   time.Time        `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	Id               string           `json:"id"`
+	SellerId         string           `json:"seller_id"`
+	ProductName      string           `json:"product_name"`
+	Category         string           `json:"category"`
+	Description      string           `json:"description"`
+	Condition        ProductCondition `json:"condition"`
+	Status           AuctionStatus    `json:"status"`
+	CurrentPrice     float64          `json:"current_price"`
+	BidCount         int64            `json:"bid_count"`
+	WatcherCount     int64            `json:"watcher_count"`
+	LeadingBidder    *string          `json:"leading_bidder"`
+	EndsAt           time.Time        `json:"ends_at"`
+	SecondsRemaining int64            `json:"seconds_remaining"`
+	ReserveMet       bool             `json:"reserve_met"`
+	Timestamp        time.Time        `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+// AuctionListInputDTO descreve uma página da busca de leilões, lida da query
+// string. Status aceita um ou mais status, repetidos ou separados por
+// vírgula; sem status a busca traz leilões em qualquer status. Cursor é o
+// next_cursor devolvido pela página anterior e só vale para a mesma ordenação.
+type AuctionListInputDTO struct {
+	Status               []string         `form:"status"`
+	Category             string           `form:"category"`
+	IncludeSubcategories bool             `form:"include_subcategories"`
+	Condition            ProductCondition `form:"condition" binding:"omitempty,oneof=1 2 3"`
+	ProductName          string           `form:"product_name"`
+	MinPrice             *float64         `form:"min_price" binding:"omitempty,gte=0"`
+	MaxPrice             *float64         `form:"max_price" binding:"omitempty,gte=0"`
+	CreatedFrom          time.Time        `form:"created_from"`
+	CreatedTo            time.Time        `form:"created_to"`
+	EndingFrom           time.Time        `form:"ending_from"`
+	EndingTo             time.Time        `form:"ending_to"`
+	Sort                 string           `form:"sort,default=newest" binding:"oneof=ending_soon newest price_desc"`
+	Limit                int64            `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor               string           `form:"cursor"`
+}
+
+// AuctionListOutputDTO traz NextCursor nulo na última página
+type AuctionListOutputDTO struct {
+	Auctions   []AuctionOutputDTO `json:"auctions"`
+	NextCursor *string            `json:"next_cursor"`
 }
 
 type WinningInfoOutputDTO struct {
@@ -33,10 +81,19 @@ type WinningInfoOutputDTO struct {
 
 func NewAuctionUseCase(
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
-	bidRepositoryInterface bid_entity.BidEntityRepository) AuctionUseCaseInterface {
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	adminActionRepository admin_entity.AdminActionRepositoryInterface,
+	walletRepository wallet_entity.WalletRepositoryInterface,
+	categoryRepository category_entity.CategoryRepositoryInterface,
+	eventBroker auction_entity.AuctionEventBroker) AuctionUseCaseInterface {
 	return &AuctionUseCase{
 		auctionRepositoryInterface: auctionRepositoryInterface,
 		bidRepositoryInterface:     bidRepositoryInterface,
+		adminActionRepository:      adminActionRepository,
+		walletRepository:           walletRepository,
+		categoryRepository:         categoryRepository,
+		eventBroker:                eventBroker,
+		auctionDuration:            time.Duration(utils.GetAuctionTimeoutSeconds()) * time.Second,
 	}
 }
 
@@ -48,10 +105,29 @@ type AuctionUseCaseInterface interface {
 	FindAuctionById(
 		ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError)
 
+	// ToAuctionOutput calcula o estado ao vivo de um leilão lido junto com
+	// outros dados, como os leilões da watchlist.
+	ToAuctionOutput(auctionEntity auction_entity.Auction) AuctionOutputDTO
+
 	FindAuctions(
 		ctx context.Context,
-		status AuctionStatus,
-		category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError)
+		listInput AuctionListInputDTO) (*AuctionListOutputDTO, *internal_error.InternalError)
+
+	SearchAuctions(
+		ctx context.Context,
+		searchInput AuctionSearchInputDTO) (*AuctionSearchOutputDTO, *internal_error.InternalError)
+
+	FindAuctionsBySellerId(
+		ctx context.Context, sellerId string) ([]AuctionOutputDTO, *internal_error.InternalError)
+
+	UpdateAuction(
+		ctx context.Context,
+		id string,
+		actor *user_entity.User,
+		auctionInput AuctionUpdateInputDTO) (*AuctionOutputDTO, *internal_error.InternalError)
+
+	CancelAuction(
+		ctx context.Context, id string, actor *user_entity.User) *internal_error.InternalError
 
 	FindWinningBidByAuctionId(
 		ctx context.Context,
@@ -60,31 +136,67 @@ type AuctionUseCaseInterface interface {
 	FindExpiredAuctions(
 		ctx context.Context) ([]AuctionOutputDTO, *internal_error.InternalError)
 
-	CloseExpiredAuctions(ctx context.Context) *internal_error.InternalError
+	CloseAuction(ctx context.Context, id string) *internal_error.InternalError
+
+	// ReopenAuction reabre o leilão a partir de agora e avisa os assinantes do novo prazo.
+	ReopenAuction(ctx context.Context, id string) *internal_error.InternalError
+
+	// VoidBid anula o lance e, se ele liderava o leilão, avisa os assinantes do novo preço.
+	VoidBid(ctx context.Context, bid *bid_entity.Bid) *internal_error.InternalError
+
+	CloseExpiredAuctions(ctx context.Context) (int, *internal_error.InternalError)
+
+	FindCacheStats(
+		ctx context.Context) (*AuctionCacheStatsOutputDTO, *internal_error.InternalError)
+
+	SubscribeAuctionEvents(
+		ctx context.Context,
+		auctionId, lastEventId string) (*AuctionEventStream, *internal_error.InternalError)
 }
 
-type ProductCondition int64
-type AuctionStatus int64
+// ProductCondition e AuctionStatus são os tipos da entidade, que trafegam no
+// JSON pelo nome ("new", "active") e aceitam o número por compatibilidade.
+type ProductCondition = auction_entity.ProductCondition
+type AuctionStatus = auction_entity.AuctionStatus
 
 type AuctionUseCase struct {
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
 	bidRepositoryInterface     bid_entity.BidEntityRepository
+	adminActionRepository      admin_entity.AdminActionRepositoryInterface
+	walletRepository           wallet_entity.WalletRepositoryInterface
+	categoryRepository         category_entity.CategoryRepositoryInterface
+	eventBroker                auction_entity.AuctionEventBroker
+	auctionDuration            time.Duration
 }
 
 func (au *AuctionUseCase) CreateAuction(
 	ctx context.Context,
 	auctionInput AuctionInputDTO) *internal_error.InternalError {
+	category, err := au.resolveCategory(ctx, auctionInput.Category)
+	if err != nil {
+		return err
+	}
+
 	auction, err := auction_entity.CreateAuction(
+		auctionInput.SellerId,
 		auctionInput.ProductName,
-		auctionInput.Category,
+		category.Path,
 		auctionInput.Description,
-		auction_entity.ProductCondition(auctionInput.Condition))
+		auctionInput.Condition)
 	if err != nil {
 		return err
 	}
+	auction.ReservePrice = auctionInput.ReservePrice
+
+	// O leilão é contado na categoria antes de existir, para que ela não
+	// seja removida com leilões
+	if err := au.categoryRepository.AddAuction(ctx, category.Id); err != nil {
+		return err
+	}
 
 	if err := au.auctionRepositoryInterface.CreateAuction(
 		ctx, auction); err != nil {
+		au.categoryRepository.RemoveAuction(ctx, category.Id)
 		return err
 	}
 
@@ -3,54 +3,99 @@ package auction_usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/admin_entity/admintest"
 	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity/auctiontest"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity/wallettest"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 )
 
-type MockAuctionRepository struct {
-	mock.Mock
+// auctionFixture reúne os dublês de um AuctionUseCase. A carteira e a
+// taxonomia vazias recebem um dublê em memória em newAuctionUseCase.
+type auctionFixture struct {
+	auctions     auction_entity.AuctionRepositoryInterface
+	bids         bid_entity.BidEntityRepository
+	adminActions admin_entity.AdminActionRepositoryInterface
+	wallets      wallet_entity.WalletRepositoryInterface
+	categories   category_entity.CategoryRepositoryInterface
+	broker       auction_entity.AuctionEventBroker
 }
 
-func (m *MockAuctionRepository) CreateAuction(ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
-	args := m.Called(ctx, auctionEntity)
-	// Garantir que o erro retornado seja ou *internal_error.InternalError ou nil
-	if args.Get(0) == nil {
-		return nil
+func (f auctionFixture) newAuctionUseCase() auction_usecase.AuctionUseCaseInterface {
+	if f.wallets == nil {
+		f.wallets = wallettest.NewFakeWalletRepository()
 	}
-	return args.Get(0).(*internal_error.InternalError)
+	if f.categories == nil {
+		f.categories = taxonomy()
+	}
+	return auction_usecase.NewAuctionUseCase(
+		f.auctions, f.bids, f.adminActions, f.wallets, f.categories, f.broker)
+}
+
+// fakeCategoryRepository guarda a taxonomia em memória, indexada pelo caminho,
+// e quantos leilões cada categoria tem, pelo id
+type fakeCategoryRepository struct {
+	category_entity.CategoryRepositoryInterface
+	categories    map[string]category_entity.Category
+	auctionCounts map[string]int64
 }
 
-func (m *MockAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
-	args := m.Called(ctx, id)
-	return args.Get(0).(*auction_entity.Auction), args.Get(1).(*internal_error.InternalError)
+func newFakeCategoryRepository(categories ...category_entity.Category) *fakeCategoryRepository {
+	repository := &fakeCategoryRepository{
+		categories:    map[string]category_entity.Category{},
+		auctionCounts: map[string]int64{},
+	}
+	for _, category := range categories {
+		repository.categories[category.Path] = category
+	}
+	return repository
 }
 
-func (m *MockAuctionRepository) FindAuctions(ctx context.Context, status auction_entity.AuctionStatus, category, productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
-	args := m.Called(ctx, status, category, productName)
-	return args.Get(0).([]auction_entity.Auction), args.Get(1).(*internal_error.InternalError)
+func (f *fakeCategoryRepository) FindCategoryByPath(ctx context.Context, path string) (*category_entity.Category, *internal_error.InternalError) {
+	category, ok := f.categories[path]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("Category not found")
+	}
+	return &category, nil
 }
 
-func (m *MockAuctionRepository) FindExpiredAuctions(ctx context.Context, timestamp int64) ([]auction_entity.Auction, *internal_error.InternalError) {
-	args := m.Called(ctx, timestamp)
-	return args.Get(0).([]auction_entity.Auction), args.Get(1).(*internal_error.InternalError)
+func (f *fakeCategoryRepository) AddAuction(ctx context.Context, id string) *internal_error.InternalError {
+	f.auctionCounts[id]++
+	return nil
 }
 
-func (m *MockAuctionRepository) UpdateAuctionStatus(ctx context.Context, id string, status int) *internal_error.InternalError {
-	args := m.Called(ctx, id, status)
-	return args.Get(0).(*internal_error.InternalError)
+func (f *fakeCategoryRepository) RemoveAuction(ctx context.Context, id string) *internal_error.InternalError {
+	f.auctionCounts[id]--
+	return nil
+}
+
+func taxonomy() *fakeCategoryRepository {
+	return newFakeCategoryRepository(
+		category_entity.Category{Id: "c1", Slug: "electronics", Path: "electronics", Active: true},
+		category_entity.Category{Id: "c2", Slug: "phones", ParentId: "c1", Path: "electronics/phones", Active: true},
+		category_entity.Category{Id: "c3", Slug: "toys", Path: "toys"},
+		category_entity.Category{Id: "c4", Slug: "dolls", ParentId: "c3", Path: "toys/dolls", Active: true},
+	)
 }
 
 func TestCreateAuction_Success(t *testing.T) {
-	mockRepo := new(MockAuctionRepository)
-	auctionUC := auction_usecase.NewAuctionUseCase(mockRepo, nil)
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
 
 	// Define test input
 	input := auction_usecase.AuctionInputDTO{
+		SellerId:    "seller-1",
 		ProductName: "Test Product",
 		Category:    "Electronics",
 		Description: "A very nice product.",
@@ -68,11 +113,13 @@ func TestCreateAuction_Success(t *testing.T) {
 }
 
 func TestCreateAuction_Failure(t *testing.T) {
-	mockRepo := new(MockAuctionRepository)
-	auctionUC := auction_usecase.NewAuctionUseCase(mockRepo, nil)
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	categories := taxonomy()
+	auctionUC := auctionFixture{auctions: mockRepo, categories: categories}.newAuctionUseCase()
 
 	// Define test input
 	input := auction_usecase.AuctionInputDTO{
+		SellerId:    "seller-1",
 		ProductName: "Test Product",
 		Category:    "Electronics",
 		Description: "A very nice product.",
@@ -88,4 +135,307 @@ func TestCreateAuction_Failure(t *testing.T) {
 	// Assert that the error returned matches the expected one
 	assert.NotNil(t, err)
 	assert.Equal(t, "error creating auction", err.Message)
+	// O leilão que não foi gravado deixa de ser contado na categoria
+	assert.Equal(t, int64(0), categories.auctionCounts["c1"])
+}
+
+func TestCreateAuction_ValidatesCategoryAgainstTaxonomy(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
+	mockRepo.On("CreateAuction", mock.Anything, mock.Anything).Return(nil)
+
+	input := auction_usecase.AuctionInputDTO{
+		SellerId:    "seller-1",
+		ProductName: "Test Product",
+		Category:    "Eletronicos",
+		Description: "A very nice product.",
+		Condition:   auction_entity.New,
+	}
+
+	err := auctionUC.CreateAuction(context.Background(), input)
+	assert.NotNil(t, err)
+	assert.Equal(t, "unprocessable_entity", err.Err)
+	assert.Equal(t, internal_error.CodeCategoryNotFound, err.Code)
+
+	// A subcategoria ativa de uma categoria inativa também fica indisponível
+	input.Category = "toys/dolls"
+	err = auctionUC.CreateAuction(context.Background(), input)
+	assert.NotNil(t, err)
+	assert.Equal(t, internal_error.CodeCategoryInactive, err.Code)
+	mockRepo.AssertNotCalled(t, "CreateAuction", mock.Anything, mock.Anything)
+
+	input.Category = "/Electronics/Phones"
+	assert.Nil(t, auctionUC.CreateAuction(context.Background(), input))
+	created := mockRepo.Calls[0].Arguments.Get(1).(*auction_entity.Auction)
+	assert.Equal(t, "electronics/phones", created.Category)
+}
+
+func TestUpdateAuction_MovesTheAuctionCountBetweenCategories(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	categories := taxonomy()
+	categories.auctionCounts["c1"] = 1
+	auctionUC := auctionFixture{auctions: mockRepo, categories: categories}.newAuctionUseCase()
+
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id:          "a1",
+		SellerId:    "seller-1",
+		ProductName: "Test Product",
+		Category:    "electronics",
+		Description: "A very nice product.",
+		Condition:   auction_entity.New,
+		Status:      auction_entity.Active,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("UpdateAuction", mock.Anything, mock.Anything).
+		Return((*internal_error.InternalError)(nil)).Once()
+
+	seller := &user_entity.User{Id: "seller-1", Roles: []user_entity.Role{user_entity.RoleSeller}}
+	phones := "electronics/phones"
+	_, err := auctionUC.UpdateAuction(context.Background(), "a1", seller,
+		auction_usecase.AuctionUpdateInputDTO{Category: &phones})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), categories.auctionCounts["c1"])
+	assert.Equal(t, int64(1), categories.auctionCounts["c2"])
+
+	// Se a gravação falha, o leilão continua contado só na categoria atual
+	mockRepo.On("UpdateAuction", mock.Anything, mock.Anything).
+		Return(internal_error.NewConflictError("Only active auctions can be edited"))
+	electronics := "electronics"
+	_, err = auctionUC.UpdateAuction(context.Background(), "a1", seller,
+		auction_usecase.AuctionUpdateInputDTO{Category: &electronics})
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(0), categories.auctionCounts["c1"])
+	assert.Equal(t, int64(1), categories.auctionCounts["c2"])
+}
+
+func TestUpdateAuction_OnlySellerCanEdit(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
+
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id:          "a1",
+		SellerId:    "seller-1",
+		ProductName: "Test Product",
+		Category:    "Electronics",
+		Description: "A very nice product.",
+		Condition:   auction_entity.New,
+		Status:      auction_entity.Active,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("UpdateAuction", mock.Anything, mock.Anything).Return(nil)
+
+	productName := "Renamed Product"
+	input := auction_usecase.AuctionUpdateInputDTO{ProductName: &productName}
+
+	_, err := auctionUC.UpdateAuction(context.Background(), "a1", &user_entity.User{Id: "someone-else"}, input)
+	assert.NotNil(t, err)
+	assert.Equal(t, "forbidden", err.Err)
+	mockRepo.AssertNotCalled(t, "UpdateAuction", mock.Anything, mock.Anything)
+
+	output, err := auctionUC.UpdateAuction(context.Background(), "a1", &user_entity.User{Id: "seller-1"}, input)
+	assert.Nil(t, err)
+	assert.Equal(t, "Renamed Product", output.ProductName)
+	assert.Equal(t, "seller-1", output.SellerId)
+}
+
+func TestCancelAuction_RequiresActiveAuctionOwnedBySeller(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	wallets := wallettest.NewFakeWalletRepository(
+		wallet_entity.NewHold("b1", "bidder-1", "active", 10),
+	)
+	auctionUC := auctionFixture{auctions: mockRepo, wallets: wallets}.newAuctionUseCase()
+
+	mockRepo.On("FindAuctionById", mock.Anything, "active").Return(&auction_entity.Auction{
+		Id: "active", SellerId: "seller-1", Status: auction_entity.Active,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("FindAuctionById", mock.Anything, "closed").Return(&auction_entity.Auction{
+		Id: "closed", SellerId: "seller-1", Status: auction_entity.Completed,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "active", auction_entity.Cancelled).Return(nil)
+
+	err := auctionUC.CancelAuction(context.Background(), "closed", &user_entity.User{Id: "seller-1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+
+	assert.Nil(t, auctionUC.CancelAuction(context.Background(), "active", &user_entity.User{Id: "seller-1"}))
+	mockRepo.AssertCalled(t, "UpdateAuctionStatus", mock.Anything, "active", auction_entity.Cancelled)
+
+	// Cancelar o leilão devolve o saldo reservado pelos lances
+	assert.Equal(t, wallet_entity.Released, wallets.HoldStatus("b1"))
+}
+
+func TestCancelAuction_LosingTheTransitionKeepsHolds(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	wallets := wallettest.NewFakeWalletRepository(
+		wallet_entity.NewHold("b1", "bidder-1", "a1", 10),
+	)
+	auctionUC := auctionFixture{auctions: mockRepo, wallets: wallets}.newAuctionUseCase()
+
+	// O leilão encerrou entre a leitura e o cancelamento
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", SellerId: "seller-1", Status: auction_entity.Active,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Cancelled).Return(
+		internal_error.NewConflictError("Only active auctions can be closed").
+			WithCode(internal_error.CodeAuctionNotActive))
+
+	err := auctionUC.CancelAuction(context.Background(), "a1", &user_entity.User{Id: "seller-1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+	assert.Equal(t, internal_error.CodeAuctionNotActive, err.Code)
+
+	// A reserva fica para a liquidação de quem encerrou o leilão
+	assert.Equal(t, wallet_entity.Held, wallets.HoldStatus("b1"))
+}
+
+func TestCancelAuction_AdminOverrideIsRecorded(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	auctionUC := auctionFixture{auctions: mockRepo, adminActions: adminActions}.newAuctionUseCase()
+
+	mockRepo.On("FindAuctionById", mock.Anything, "a1").Return(&auction_entity.Auction{
+		Id: "a1", SellerId: "seller-1", Status: auction_entity.Active,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("UpdateAuctionStatus", mock.Anything, "a1", auction_entity.Cancelled).Return(nil)
+
+	admin := &user_entity.User{Id: "admin-1", Roles: []user_entity.Role{user_entity.RoleAdmin}}
+	assert.Nil(t, auctionUC.CancelAuction(context.Background(), "a1", admin))
+
+	assert.Len(t, adminActions.Actions, 1)
+	assert.Equal(t, "admin-1", adminActions.Actions[0].AdminId)
+	assert.Equal(t, admin_entity.CancelAuction, adminActions.Actions[0].Action)
+	assert.Equal(t, "a1", adminActions.Actions[0].TargetId)
+}
+
+func TestFindAuctions_ReturnsNextCursorUntilLastPage(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
+
+	firstPage := auction_entity.AuctionQuery{Sort: auction_entity.SortPriceDesc, Limit: 1}
+	lastPage := auction_entity.AuctionQuery{Sort: auction_entity.SortPriceDesc, Limit: 1, Cursor: "next"}
+	mockRepo.On("FindAuctions", mock.Anything, firstPage).Return(
+		[]auction_entity.Auction{{Id: "a1", CurrentPrice: 30}}, "next", (*internal_error.InternalError)(nil))
+	mockRepo.On("FindAuctions", mock.Anything, lastPage).Return(
+		[]auction_entity.Auction{{Id: "a2", CurrentPrice: 10}}, "", (*internal_error.InternalError)(nil))
+
+	output, err := auctionUC.FindAuctions(context.Background(), auction_usecase.AuctionListInputDTO{
+		Sort: "price_desc", Limit: 1,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, output.Auctions, 1)
+	assert.Equal(t, 30.0, output.Auctions[0].CurrentPrice)
+	assert.Equal(t, "next", *output.NextCursor)
+
+	output, err = auctionUC.FindAuctions(context.Background(), auction_usecase.AuctionListInputDTO{
+		Sort: "price_desc", Limit: 1, Cursor: *output.NextCursor,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "a2", output.Auctions[0].Id)
+	assert.Nil(t, output.NextCursor)
+}
+
+func TestFindAuctions_TranslatesSearchFilters(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
+
+	createdFrom := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	endingTo := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("FindAuctions", mock.Anything, auction_entity.AuctionQuery{
+		Statuses: []auction_entity.AuctionStatus{auction_entity.Active, auction_entity.Cancelled, auction_entity.Completed},
+		Category: "electronics",
+		// Categorias da taxonomia sempre trazem as subcategorias
+		IncludeSubcategories: true,
+		Condition:            auction_entity.Used,
+		CreatedFrom:          createdFrom,
+		// O término é filtrado pelo campo gravado, não pela data de criação
+		EndingTo: endingTo,
+		Sort:     auction_entity.SortNewest,
+		Limit:    20,
+	}).Return([]auction_entity.Auction{}, "", (*internal_error.InternalError)(nil))
+
+	_, err := auctionUC.FindAuctions(context.Background(), auction_usecase.AuctionListInputDTO{
+		// Nomes e números continuam aceitos, inclusive misturados
+		Status:      []string{"active,cancelled", "1"},
+		Category:    "electronics",
+		Condition:   2,
+		CreatedFrom: createdFrom,
+		EndingTo:    endingTo,
+		Sort:        "newest",
+		Limit:       20,
+	})
+	assert.Nil(t, err)
+	mockRepo.AssertExpectations(t)
+
+	_, err = auctionUC.FindAuctions(context.Background(), auction_usecase.AuctionListInputDTO{
+		Status: []string{"open"}, Sort: "newest", Limit: 20,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, "status", err.Causes[0].Field)
+}
+
+func TestSearchAuctions_HighlightsMatchedTerms(t *testing.T) {
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
+
+	description := "Aparelho em ótimo estado, sempre usado com película e capinha. " +
+		"Acompanha carregador original, cabo e caixa. Bateria com 90% de saúde, " +
+		"sem riscos na tela. Vendo porque troquei de celular <novo>."
+	mockRepo.On("SearchAuctions", mock.Anything, auction_entity.AuctionSearchQuery{
+		Text:     "Celulares -quebrado",
+		Statuses: []auction_entity.AuctionStatus{auction_entity.Active},
+		Page:     1,
+		Limit:    20,
+	}).Return([]auction_entity.AuctionSearchResult{
+		{Auction: auction_entity.Auction{Id: "a1", ProductName: "Celular Samsung", Description: description}, Score: 11.5},
+		{Auction: auction_entity.Auction{Id: "a2", ProductName: "Capa", Description: "Capa para celular"}, Score: 1.1},
+	}, int64(2), (*internal_error.InternalError)(nil))
+
+	output, err := auctionUC.SearchAuctions(context.Background(), auction_usecase.AuctionSearchInputDTO{
+		Q: "Celulares -quebrado", Status: []string{"active"}, Page: 1, Limit: 20,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), output.Total)
+	assert.Len(t, output.Results, 2)
+
+	first := output.Results[0]
+	assert.Equal(t, 11.5, first.Score)
+	assert.Equal(t, "<mark>Celular</mark> Samsung", first.Highlights["product_name"])
+	// A descrição longa vira um trecho em volta do termo, com o texto escapado
+	assert.Equal(t, "…tela. Vendo porque troquei de <mark>celular</mark> &lt;novo&gt;.", first.Highlights["description"])
+
+	second := output.Results[1]
+	_, nameHighlighted := second.Highlights["product_name"]
+	assert.False(t, nameHighlighted)
+	assert.Equal(t, "Capa para <mark>celular</mark>", second.Highlights["description"])
+}
+
+func TestFindAuctionById_ReturnsLiveState(t *testing.T) {
+	t.Setenv("AUCTION_TIMEOUT_SECONDS", "600")
+	mockRepo := new(auctiontest.MockAuctionRepository)
+	auctionUC := auctionFixture{auctions: mockRepo}.newAuctionUseCase()
+
+	createdAt := time.Now().Add(-4 * time.Minute)
+	mockRepo.On("FindAuctionById", mock.Anything, "live").Return(&auction_entity.Auction{
+		Id: "live", Status: auction_entity.Active, Timestamp: createdAt, ReservePrice: 50,
+		CurrentPrice: 75, BidCount: 3, LeadingBidderId: "8afc6593-e09b-4acb-9c7a-eb3cd094e95b",
+		WatcherCount: 4,
+	}, (*internal_error.InternalError)(nil))
+	mockRepo.On("FindAuctionById", mock.Anything, "closed").Return(&auction_entity.Auction{
+		Id: "closed", Status: auction_entity.Completed, Timestamp: createdAt, ReservePrice: 50,
+	}, (*internal_error.InternalError)(nil))
+
+	live, err := auctionUC.FindAuctionById(context.Background(), "live")
+	assert.Nil(t, err)
+	assert.Equal(t, 75.0, live.CurrentPrice)
+	assert.Equal(t, int64(3), live.BidCount)
+	assert.Equal(t, int64(4), live.WatcherCount)
+	assert.Equal(t, "8afc****", *live.LeadingBidder)
+	assert.Equal(t, createdAt.Add(10*time.Minute), live.EndsAt)
+	assert.InDelta(t, 360, live.SecondsRemaining, 2)
+	assert.True(t, live.ReserveMet)
+
+	closed, err := auctionUC.FindAuctionById(context.Background(), "closed")
+	assert.Nil(t, err)
+	assert.Nil(t, closed.LeadingBidder)
+	assert.Equal(t, int64(0), closed.SecondsRemaining)
+	assert.False(t, closed.ReserveMet)
 }
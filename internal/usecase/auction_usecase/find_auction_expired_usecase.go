@@ -3,14 +3,14 @@ package auction_usecase
 import (
 	"context"
 	"fullcycle-auction_go/internal/internal_error"
-	"fullcycle-auction_go/internal/utils"
+	"time"
 )
 
 func (au *AuctionUseCase) FindExpiredAuctions(
 	ctx context.Context) ([]AuctionOutputDTO, *internal_error.InternalError) {
 
 	// Buscando leilões expirados
-	auctions, err := au.auctionRepositoryInterface.FindExpiredAuctions(ctx, utils.GetAuctionTimeoutSeconds())
+	auctions, err := au.auctionRepositoryInterface.FindExpiredAuctions(ctx, time.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -18,16 +18,7 @@ func (au *AuctionUseCase) FindExpiredAuctions(
 	// Criando a lista de leilões expirados no formato adequado para a saída
 	var expiredAuctions []AuctionOutputDTO
 	for _, auction := range auctions {
-		auctionDTO := AuctionOutputDTO{
-			Id:          auction.Id,
-			ProductName: auction.ProductName,
-			Category:    auction.Category,
-			Description: auction.Description,
-			Condition:   ProductCondition(auction.Condition),
-			Status:      AuctionStatus(auction.Status),
-			Timestamp:   auction.Timestamp,
-		}
-		expiredAuctions = append(expiredAuctions, auctionDTO)
+		expiredAuctions = append(expiredAuctions, au.toAuctionOutputDTO(auction))
 	}
 
 	//fmt.Printf("Leilões expirados: %v\n", expiredAuctions)
@@ -6,6 +6,8 @@ import (
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"strings"
+	"time"
 )
 
 func (au *AuctionUseCase) FindAuctionById(
@@ -15,38 +17,96 @@ func (au *AuctionUseCase) FindAuctionById(
 		return nil, err
 	}
 
-	return &AuctionOutputDTO{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   ProductCondition(auctionEntity.Condition),
-		Status:      AuctionStatus(auctionEntity.Status),
-		Timestamp:   auctionEntity.Timestamp,
-	}, nil
+	auctionOutputDTO := au.toAuctionOutputDTO(*auctionEntity)
+	return &auctionOutputDTO, nil
 }
 
 func (au *AuctionUseCase) FindAuctions(
 	ctx context.Context,
-	status AuctionStatus,
-	category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError) {
-	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(
-		ctx, auction_entity.AuctionStatus(status), category, productName)
+	listInput AuctionListInputDTO) (*AuctionListOutputDTO, *internal_error.InternalError) {
+	query, err := toAuctionQuery(listInput)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Category, query.IncludeSubcategories, err = au.categoryFilter(
+		ctx, query.Category, query.IncludeSubcategories)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionEntities, nextCursor, err := au.auctionRepositoryInterface.FindAuctions(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	var auctionOutputs []AuctionOutputDTO
+	auctionOutputs := make([]AuctionOutputDTO, 0, len(auctionEntities))
 	for _, value := range auctionEntities {
-		auctionOutputs = append(auctionOutputs, AuctionOutputDTO{
-			Id:          value.Id,
-			ProductName: value.ProductName,
-			Category:    value.Category,
-			Description: value.Description,
-			Condition:   ProductCondition(value.Condition),
-			Status:      AuctionStatus(value.Status),
-			Timestamp:   value.Timestamp,
-		})
+		auctionOutputs = append(auctionOutputs, au.toAuctionOutputDTO(value))
+	}
+
+	listOutput := &AuctionListOutputDTO{Auctions: auctionOutputs}
+	if nextCursor != "" {
+		listOutput.NextCursor = &nextCursor
+	}
+
+	return listOutput, nil
+}
+
+func toAuctionQuery(listInput AuctionListInputDTO) (auction_entity.AuctionQuery, *internal_error.InternalError) {
+	query := auction_entity.AuctionQuery{
+		Category:             listInput.Category,
+		IncludeSubcategories: listInput.IncludeSubcategories,
+		Condition:            listInput.Condition,
+		ProductName:          listInput.ProductName,
+		MinPrice:             listInput.MinPrice,
+		MaxPrice:             listInput.MaxPrice,
+		CreatedFrom:          listInput.CreatedFrom,
+		CreatedTo:            listInput.CreatedTo,
+		EndingFrom:           listInput.EndingFrom,
+		EndingTo:             listInput.EndingTo,
+		Sort:                 auction_entity.AuctionSort(listInput.Sort),
+		Limit:                listInput.Limit,
+		Cursor:               listInput.Cursor,
+	}
+
+	statuses, err := toAuctionStatuses(listInput.Status)
+	if err != nil {
+		return query, err
+	}
+	query.Statuses = statuses
+
+	return query, nil
+}
+
+// toAuctionStatuses lê os status da query string, repetidos ou separados por
+// vírgula, pelo nome ("active", "closed") ou pelo número
+func toAuctionStatuses(params []string) ([]auction_entity.AuctionStatus, *internal_error.InternalError) {
+	var statuses []auction_entity.AuctionStatus
+	for _, param := range params {
+		for _, value := range strings.Split(param, ",") {
+			status, errParse := auction_entity.ParseAuctionStatus(value)
+			if errParse != nil || strings.TrimSpace(value) == "" {
+				return nil, internal_error.NewBadRequestError("Invalid auction status").
+					WithCauses(internal_error.Cause{Field: "status", Message: "status is not a valid value"})
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (au *AuctionUseCase) FindAuctionsBySellerId(
+	ctx context.Context, sellerId string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	auctionEntities, err := au.auctionRepositoryInterface.FindAuctionsBySellerId(ctx, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionOutputs := make([]AuctionOutputDTO, 0, len(auctionEntities))
+	for _, value := range auctionEntities {
+		auctionOutputs = append(auctionOutputs, au.toAuctionOutputDTO(value))
 	}
 
 	return auctionOutputs, nil
@@ -60,15 +120,7 @@ func (au *AuctionUseCase) FindWinningBidByAuctionId(
 		return nil, err
 	}
 
-	auctionOutputDTO := AuctionOutputDTO{
-		Id:          auction.Id,
-		ProductName: auction.ProductName,
-		Category:    auction.Category,
-		Description: auction.Description,
-		Condition:   ProductCondition(auction.Condition),
-		Status:      AuctionStatus(auction.Status),
-		Timestamp:   auction.Timestamp,
-	}
+	auctionOutputDTO := au.toAuctionOutputDTO(*auction)
 
 	bidWinning, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
 	if err != nil {
@@ -92,3 +144,53 @@ func (au *AuctionUseCase) FindWinningBidByAuctionId(
 		Bid:     bidOutputDTO,
 	}, nil
 }
+
+func (au *AuctionUseCase) ToAuctionOutput(auctionEntity auction_entity.Auction) AuctionOutputDTO {
+	return au.toAuctionOutputDTO(auctionEntity)
+}
+
+// toAuctionOutputDTO calcula o estado ao vivo do leilão a partir de uma única
+// leitura: preço, lances e líder são gravados juntos (ver AuctionBidState).
+func (au *AuctionUseCase) toAuctionOutputDTO(auctionEntity auction_entity.Auction) AuctionOutputDTO {
+	endsAt := auctionEntity.EndsAt(au.auctionDuration)
+
+	var secondsRemaining int64
+	if auctionEntity.Status == auction_entity.Active {
+		if remaining := time.Until(endsAt); remaining > 0 {
+			secondsRemaining = int64(remaining.Seconds())
+		}
+	}
+
+	var leadingBidder *string
+	if auctionEntity.BidCount > 0 && auctionEntity.LeadingBidderId != "" {
+		masked := maskUserId(auctionEntity.LeadingBidderId)
+		leadingBidder = &masked
+	}
+
+	return AuctionOutputDTO{
+		Id:               auctionEntity.Id,
+		SellerId:         auctionEntity.SellerId,
+		ProductName:      auctionEntity.ProductName,
+		Category:         auctionEntity.Category,
+		Description:      auctionEntity.Description,
+		Condition:        auctionEntity.Condition,
+		Status:           auctionEntity.Status,
+		CurrentPrice:     auctionEntity.CurrentPrice,
+		BidCount:         auctionEntity.BidCount,
+		WatcherCount:     auctionEntity.WatcherCount,
+		LeadingBidder:    leadingBidder,
+		EndsAt:           endsAt,
+		SecondsRemaining: secondsRemaining,
+		ReserveMet:       auctionEntity.ReserveMet(),
+		Timestamp:        auctionEntity.Timestamp,
+	}
+}
+
+// maskUserId mostra só o começo do id, o bastante para o licitante se
+// reconhecer sem expor quem está na frente.
+func maskUserId(userId string) string {
+	if len(userId) <= 4 {
+		return "****"
+	}
+	return userId[:4] + "****"
+}
@@ -0,0 +1,90 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// AuctionSearchInputDTO é a busca de texto de GET /auction/search. Q aceita
+// palavras, "frases entre aspas" e -palavras excluídas.
+type AuctionSearchInputDTO struct {
+	Q                    string   `form:"q" binding:"required,min=2,max=200"`
+	Status               []string `form:"status"`
+	Category             string   `form:"category"`
+	IncludeSubcategories bool     `form:"include_subcategories"`
+	Page                 int64    `form:"page,default=1" binding:"min=1"`
+	Limit                int64    `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+// AuctionSearchResultOutputDTO traz os trechos do nome e da descrição em que
+// a busca foi encontrada, com os termos entre <mark> e </mark>.
+type AuctionSearchResultOutputDTO struct {
+	Auction    AuctionOutputDTO  `json:"auction"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+type AuctionSearchOutputDTO struct {
+	Results []AuctionSearchResultOutputDTO `json:"results"`
+	Page    int64                          `json:"page"`
+	Limit   int64                          `json:"limit"`
+	Total   int64                          `json:"total"`
+}
+
+func (au *AuctionUseCase) SearchAuctions(
+	ctx context.Context,
+	searchInput AuctionSearchInputDTO) (*AuctionSearchOutputDTO, *internal_error.InternalError) {
+	statuses, err := toAuctionStatuses(searchInput.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := searchTerms(searchInput.Q)
+	if len(terms) == 0 {
+		return nil, internal_error.NewBadRequestError("Search has no words to look for")
+	}
+
+	category, includeSubcategories, err := au.categoryFilter(
+		ctx, searchInput.Category, searchInput.IncludeSubcategories)
+	if err != nil {
+		return nil, err
+	}
+
+	query := auction_entity.AuctionSearchQuery{
+		Text:                 searchInput.Q,
+		Statuses:             statuses,
+		Category:             category,
+		IncludeSubcategories: includeSubcategories,
+		Page:                 searchInput.Page,
+		Limit:                searchInput.Limit,
+	}
+	results, total, err := au.auctionRepositoryInterface.SearchAuctions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	resultOutputs := make([]AuctionSearchResultOutputDTO, 0, len(results))
+	for _, result := range results {
+		highlights := make(map[string]string)
+		if snippet, ok := highlight(result.Auction.ProductName, terms, 0); ok {
+			highlights["product_name"] = snippet
+		}
+		if snippet, ok := highlight(result.Auction.Description, terms, descriptionSnippetLength); ok {
+			highlights["description"] = snippet
+		}
+
+		resultOutputs = append(resultOutputs, AuctionSearchResultOutputDTO{
+			Auction:    au.toAuctionOutputDTO(result.Auction),
+			Score:      result.Score,
+			Highlights: highlights,
+		})
+	}
+
+	return &AuctionSearchOutputDTO{
+		Results: resultOutputs,
+		Page:    searchInput.Page,
+		Limit:   searchInput.Limit,
+		Total:   total,
+	}, nil
+}
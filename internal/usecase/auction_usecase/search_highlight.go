@@ -0,0 +1,132 @@
+package auction_usecase
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	descriptionSnippetLength = 160
+	// snippetContextWords é quantas palavras antes do primeiro termo
+	// encontrado entram no trecho
+	snippetContextWords = 5
+)
+
+// searchTerms extrai as palavras da busca, ignorando as excluídas com "-".
+func searchTerms(q string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Fields(q) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(field, isNotWordRune) {
+			word = strings.ToLower(word)
+			if utf8.RuneCountInString(word) < 2 || seen[word] {
+				continue
+			}
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// matchesTerm aproxima o stemming do índice de texto: "celular" e
+// "celulares" se encontram nos dois sentidos.
+func matchesTerm(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) ||
+			(utf8.RuneCountInString(word) >= 4 && strings.HasPrefix(term, word)) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlight marca os termos encontrados em text. Com maxLength > 0, textos
+// maiores são cortados em um trecho a partir de algumas palavras antes do
+// primeiro termo. O texto é escapado, então só as marcações são HTML.
+func highlight(text string, terms []string, maxLength int) (string, bool) {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		if isNotWordRune(r) {
+			if start >= 0 {
+				spans = append(spans, [2]int{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+
+	first := -1
+	matched := make([]bool, len(spans))
+	for i, span := range spans {
+		if matchesTerm(strings.ToLower(text[span[0]:span[1]]), terms) {
+			matched[i] = true
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	from, to := 0, len(text)
+	if maxLength > 0 && utf8.RuneCountInString(text) > maxLength {
+		firstWord := first - snippetContextWords
+		if firstWord < 0 {
+			firstWord = 0
+		}
+		from = spans[firstWord][0]
+
+		to = spans[first][1]
+		for _, span := range spans[first+1:] {
+			if utf8.RuneCountInString(text[from:span[1]]) > maxLength {
+				break
+			}
+			to = span[1]
+		}
+		if utf8.RuneCountInString(text[from:]) <= maxLength {
+			to = len(text)
+		}
+	}
+
+	var snippet strings.Builder
+	if from > 0 {
+		snippet.WriteString("…")
+	}
+
+	position := from
+	for i, span := range spans {
+		if !matched[i] || span[0] < from || span[1] > to {
+			continue
+		}
+		snippet.WriteString(html.EscapeString(text[position:span[0]]))
+		snippet.WriteString("<mark>")
+		snippet.WriteString(html.EscapeString(text[span[0]:span[1]]))
+		snippet.WriteString("</mark>")
+		position = span[1]
+	}
+	snippet.WriteString(html.EscapeString(text[position:to]))
+
+	if to < len(text) {
+		snippet.WriteString("…")
+	}
+
+	return snippet.String(), true
+}
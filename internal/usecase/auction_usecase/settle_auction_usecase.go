@@ -0,0 +1,132 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"log"
+	"time"
+)
+
+// CloseAuction encerra um leilão ativo e liquida as reservas de saldo: o
+// lance vencedor é capturado e os demais são devolvidos aos licitantes. Se o
+// maior lance não alcança o preço de reserva, não há venda e todas as
+// reservas são devolvidas.
+func (au *AuctionUseCase) CloseAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	if err := au.auctionRepositoryInterface.UpdateAuctionStatus(
+		ctx, id, auction_entity.Completed); err != nil {
+		return err
+	}
+
+	winningBid := au.settleHolds(ctx, id)
+	au.publishEvent(ctx, id, auction_entity.EventAuctionClosed, toAuctionEventBid(winningBid))
+	return nil
+}
+
+// ReopenAuction reabre um leilão encerrado. Um leilão cujo vencedor já foi
+// cobrado não volta a receber lances, senão seria liquidado e cobrado de novo.
+func (au *AuctionUseCase) ReopenAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	settled, err := au.walletRepository.HasCapturedHold(ctx, id)
+	if err != nil {
+		return err
+	}
+	if settled {
+		return internal_error.NewConflictError("Auctions whose winner was already charged cannot be reopened").
+			WithCode(internal_error.CodeAuctionSettled)
+	}
+
+	if err := au.auctionRepositoryInterface.ReopenAuction(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	au.publishEvent(ctx, id, auction_entity.EventAuctionExtended, nil)
+	return nil
+}
+
+// VoidBid anula o lance. O estado do leilão é recalculado pelo repositório
+// de lances; o price_changed só sai quando o lance anulado era o líder, já
+// com o preço e o licitante que passaram a liderar. Lances de leilões
+// encerrados já foram liquidados e não são anulados.
+func (au *AuctionUseCase) VoidBid(
+	ctx context.Context, bid *bid_entity.Bid) *internal_error.InternalError {
+	auctionEntity, err := au.auctionRepositoryInterface.FindAuctionById(ctx, bid.AuctionId)
+	if err != nil {
+		return err
+	}
+
+	if auctionEntity.Status == auction_entity.Completed {
+		return internal_error.NewConflictError("Bids of completed auctions cannot be voided").
+			WithCode(internal_error.CodeAuctionSettled)
+	}
+
+	if err := au.bidRepositoryInterface.VoidBid(ctx, bid.Id); err != nil {
+		return err
+	}
+
+	if auctionEntity.LeadingBidderId == bid.UserId && auctionEntity.CurrentPrice == bid.Amount {
+		au.publishEvent(ctx, bid.AuctionId, auction_entity.EventPriceChanged, nil)
+	}
+	return nil
+}
+
+// settleHolds liquida as reservas e devolve o lance vencedor, nulo sem lances
+// ou sem um lance que alcance a reserva.
+func (au *AuctionUseCase) settleHolds(ctx context.Context, auctionId string) *bid_entity.Bid {
+	auctionEntity, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		logger.Error("Error trying to find the reserve price of auction "+auctionId, err)
+		return nil
+	}
+
+	winningBidId := ""
+	winningBid, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil && err.Err != "not_found" {
+		logger.Error("Error trying to find the winning bid of auction "+auctionId, err)
+		return nil
+	}
+	if winningBid != nil && winningBid.Amount < auctionEntity.ReservePrice {
+		winningBid = nil
+	}
+	if winningBid != nil {
+		winningBidId = winningBid.Id
+	}
+
+	holds, err := au.walletRepository.FindHeldHoldsByAuctionId(ctx, auctionId)
+	if err != nil {
+		logger.Error("Error trying to find holds of auction "+auctionId, err)
+		return winningBid
+	}
+
+	for _, hold := range holds {
+		if hold.Id == winningBidId {
+			err = au.walletRepository.CaptureHold(ctx, hold.Id)
+		} else {
+			err = au.walletRepository.ReleaseHold(ctx, hold.Id)
+		}
+		if err != nil {
+			logger.Error("Error trying to settle hold "+hold.Id, err)
+		}
+	}
+
+	log.Printf("Leilão %s liquidado: %d reservas processadas\n", auctionId, len(holds))
+	return winningBid
+}
+
+// releaseHolds devolve todas as reservas de um leilão cancelado.
+func (au *AuctionUseCase) releaseHolds(ctx context.Context, auctionId string) {
+	holds, err := au.walletRepository.FindHeldHoldsByAuctionId(ctx, auctionId)
+	if err != nil {
+		logger.Error("Error trying to find holds of auction "+auctionId, err)
+		return
+	}
+
+	for _, hold := range holds {
+		if err := au.walletRepository.ReleaseHold(ctx, hold.Id); err != nil {
+			logger.Error("Error trying to release hold "+hold.Id, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type AuctionUpdateInputDTO struct {
+	ProductName *string           `json:"product_name" binding:"omitempty,min=1"`
+	Category    *string           `json:"category" binding:"omitempty,min=2"`
+	Description *string           `json:"description" binding:"omitempty,min=10,max=200"`
+	Condition   *ProductCondition `json:"condition" binding:"omitempty,oneof=1 2 3"`
+}
+
+func (au *AuctionUseCase) UpdateAuction(
+	ctx context.Context,
+	id string,
+	actor *user_entity.User,
+	auctionInput AuctionUpdateInputDTO) (*AuctionOutputDTO, *internal_error.InternalError) {
+	auction, err := au.findManagedAuction(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if auctionInput.ProductName != nil {
+		auction.ProductName = *auctionInput.ProductName
+	}
+
+	var previousCategory, category *category_entity.Category
+	if auctionInput.Category != nil {
+		if category, err = au.resolveCategory(ctx, *auctionInput.Category); err != nil {
+			return nil, err
+		}
+
+		if category.Path == auction.Category {
+			category = nil
+		} else {
+			// Categorias antigas, fora da taxonomia, não são contadas
+			previousCategory, _ = au.categoryRepository.FindCategoryByPath(ctx, auction.Category)
+			auction.Category = category.Path
+		}
+	}
+
+	if auctionInput.Description != nil {
+		auction.Description = *auctionInput.Description
+	}
+
+	if auctionInput.Condition != nil {
+		auction.Condition = *auctionInput.Condition
+	}
+
+	if err := auction.Validate(); err != nil {
+		return nil, err
+	}
+
+	if category != nil {
+		if err := au.categoryRepository.AddAuction(ctx, category.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := au.auctionRepositoryInterface.UpdateAuction(ctx, auction); err != nil {
+		if category != nil {
+			au.categoryRepository.RemoveAuction(ctx, category.Id)
+		}
+		return nil, err
+	}
+
+	if previousCategory != nil {
+		au.categoryRepository.RemoveAuction(ctx, previousCategory.Id)
+	}
+
+	if err := au.recordAdminOverride(ctx, auction, actor, admin_entity.UpdateAuction); err != nil {
+		return nil, err
+	}
+
+	auctionOutputDTO := au.toAuctionOutputDTO(*auction)
+	return &auctionOutputDTO, nil
+}
+
+func (au *AuctionUseCase) CancelAuction(
+	ctx context.Context, id string, actor *user_entity.User) *internal_error.InternalError {
+	auction, err := au.findManagedAuction(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+
+	// O leilão pode ter encerrado desde a leitura; só quem fez a transição
+	// devolve as reservas
+	if err := au.auctionRepositoryInterface.UpdateAuctionStatus(
+		ctx, id, auction_entity.Cancelled); err != nil {
+		return err
+	}
+
+	au.releaseHolds(ctx, id)
+	au.publishEvent(ctx, id, auction_entity.EventAuctionClosed, nil)
+
+	return au.recordAdminOverride(ctx, auction, actor, admin_entity.CancelAuction)
+}
+
+// findManagedAuction carrega um leilão ativo que o usuário pode alterar:
+// o vendedor gerencia o próprio leilão e administradores gerenciam todos.
+func (au *AuctionUseCase) findManagedAuction(
+	ctx context.Context,
+	id string,
+	actor *user_entity.User) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	isSeller := auction.SellerId != "" && auction.SellerId == actor.Id
+	if !isSeller && !actor.HasRole(user_entity.RoleAdmin) {
+		return nil, internal_error.NewForbiddenError("Only the seller or an admin can change this auction").
+			WithCode(internal_error.CodeNotAuctionOwner)
+	}
+
+	if auction.Status != auction_entity.Active {
+		return nil, internal_error.NewConflictError("Only active auctions can be changed").
+			WithCode(internal_error.CodeAuctionNotActive)
+	}
+
+	return auction, nil
+}
+
+// recordAdminOverride audita alterações feitas por um administrador em
+// leilões de outros vendedores.
+func (au *AuctionUseCase) recordAdminOverride(
+	ctx context.Context,
+	auction *auction_entity.Auction,
+	actor *user_entity.User,
+	action admin_entity.ActionType) *internal_error.InternalError {
+	if auction.SellerId == actor.Id {
+		return nil
+	}
+
+	return au.adminActionRepository.SaveAdminAction(ctx, admin_entity.NewAdminAction(
+		actor.Id, action, admin_entity.AuctionTarget, auction.Id, ""))
+}
@@ -0,0 +1,150 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"math"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// AdmissionPolicy decide o que acontece com um lance quando a fila do shard está cheia.
+type AdmissionPolicy string
+
+const (
+	// AdmissionReject recusa o lance imediatamente quando a fila está cheia.
+	AdmissionReject AdmissionPolicy = "reject"
+	// AdmissionWait espera por espaço na fila até o timeout de admissão.
+	AdmissionWait AdmissionPolicy = "wait"
+	// AdmissionShed descarta lances de baixa prioridade a partir do limite de
+	// descarte e faz os de alta prioridade esperarem como em AdmissionWait.
+	AdmissionShed AdmissionPolicy = "shed"
+)
+
+type QueueStatsOutputDTO struct {
+	Policy            AdmissionPolicy `json:"policy"`
+	Workers           int             `json:"workers"`
+	CapacityPerWorker int             `json:"capacity_per_worker"`
+	Depth             int             `json:"depth"`
+	WorkerDepths      []int           `json:"worker_depths"`
+	Admitted          uint64          `json:"admitted"`
+	Rejected          uint64          `json:"rejected"`
+	Shed              uint64          `json:"shed"`
+	TimedOut          uint64          `json:"timed_out"`
+	RetryAfterSeconds int             `json:"retry_after_seconds"`
+}
+
+type admissionConfig struct {
+	policy         AdmissionPolicy
+	queueCapacity  int
+	timeout        time.Duration
+	shedThreshold  float64
+	priorityWindow time.Duration
+}
+
+type admissionCounters struct {
+	admitted atomic.Uint64
+	rejected atomic.Uint64
+	shed     atomic.Uint64
+	timedOut atomic.Uint64
+}
+
+// admit coloca o lance na fila do worker respeitando a política configurada.
+// highPriority indica lances de leilões prestes a encerrar, que nunca são descartados.
+func (bu *BidUseCase) admit(
+	ctx context.Context,
+	worker *bidWorker,
+	bidEntity bid_entity.Bid,
+	highPriority bool) *internal_error.InternalError {
+	switch bu.admission.policy {
+	case AdmissionReject:
+		select {
+		case worker.bidChannel <- bidEntity:
+			bu.counters.admitted.Add(1)
+			return nil
+		default:
+			bu.counters.rejected.Add(1)
+			return internal_error.NewTooManyRequestsError("Bid queue is full, try again later").
+				WithCode(internal_error.CodeBidQueueFull)
+		}
+
+	case AdmissionShed:
+		if !highPriority && float64(len(worker.bidChannel)) >= bu.admission.shedThreshold*float64(cap(worker.bidChannel)) {
+			bu.counters.shed.Add(1)
+			return internal_error.NewTooManyRequestsError("Bid queue is overloaded, try again later").
+				WithCode(internal_error.CodeBidQueueFull)
+		}
+	}
+
+	timer := time.NewTimer(bu.admission.timeout)
+	defer timer.Stop()
+
+	select {
+	case worker.bidChannel <- bidEntity:
+		bu.counters.admitted.Add(1)
+		return nil
+	case <-timer.C:
+		bu.counters.timedOut.Add(1)
+		return internal_error.NewServiceUnavailableError("Bid queue is unavailable, try again later").
+			WithCode(internal_error.CodeBidQueueUnavailable)
+	case <-ctx.Done():
+		return internal_error.NewInternalServerError("Error trying to enqueue bid")
+	}
+}
+
+func (bu *BidUseCase) QueueStats() QueueStatsOutputDTO {
+	stats := QueueStatsOutputDTO{
+		Policy:            bu.admission.policy,
+		Workers:           len(bu.workers),
+		CapacityPerWorker: bu.admission.queueCapacity,
+		Admitted:          bu.counters.admitted.Load(),
+		Rejected:          bu.counters.rejected.Load(),
+		Shed:              bu.counters.shed.Load(),
+		TimedOut:          bu.counters.timedOut.Load(),
+		RetryAfterSeconds: int(math.Ceil(bu.batchInsertInterval.Seconds())),
+	}
+
+	for _, worker := range bu.workers {
+		depth := len(worker.bidChannel)
+		stats.WorkerDepths = append(stats.WorkerDepths, depth)
+		stats.Depth += depth
+	}
+
+	return stats
+}
+
+func getAdmissionConfig() admissionConfig {
+	config := admissionConfig{
+		policy:         AdmissionWait,
+		queueCapacity:  100,
+		timeout:        2 * time.Second,
+		shedThreshold:  0.8,
+		priorityWindow: time.Minute,
+	}
+
+	switch policy := AdmissionPolicy(os.Getenv("BID_ADMISSION_POLICY")); policy {
+	case AdmissionReject, AdmissionWait, AdmissionShed:
+		config.policy = policy
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("BID_QUEUE_CAPACITY")); err == nil && value > 0 {
+		config.queueCapacity = value
+	}
+
+	if value, err := time.ParseDuration(os.Getenv("BID_ADMISSION_TIMEOUT")); err == nil && value > 0 {
+		config.timeout = value
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("BID_SHED_THRESHOLD"), 64); err == nil && value > 0 && value <= 1 {
+		config.shedThreshold = value
+	}
+
+	if value, err := time.ParseDuration(os.Getenv("BID_PRIORITY_WINDOW")); err == nil && value > 0 {
+		config.priorityWindow = value
+	}
+
+	return config
+}
@@ -0,0 +1,75 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+)
+
+// bidEvents publica no stream dos leilões os lances gravados por um lote. O
+// leilão é lido uma vez por lote, então todos os eventos do lote trazem o
+// estado gravado ao final dele.
+type bidEvents struct {
+	publisher         auction_entity.AuctionEventPublisher
+	auctionRepository auction_entity.AuctionRepositoryInterface
+}
+
+// afterPersist publica bid_accepted para cada lance aceito e price_changed
+// quando um lance do lote passou a liderar o leilão.
+func (e *bidEvents) afterPersist(
+	ctx context.Context, bidBatch []bid_entity.Bid, rejections []bid_entity.BidRejection) {
+	if e.publisher == nil {
+		return
+	}
+
+	rejected := make(map[string]bool, len(rejections))
+	for _, rejection := range rejections {
+		rejected[rejection.BidId] = true
+	}
+
+	var auctionIds []string
+	accepted := make(map[string][]bid_entity.Bid)
+	for _, bidEntity := range bidBatch {
+		if rejected[bidEntity.Id] {
+			continue
+		}
+		if _, ok := accepted[bidEntity.AuctionId]; !ok {
+			auctionIds = append(auctionIds, bidEntity.AuctionId)
+		}
+		accepted[bidEntity.AuctionId] = append(accepted[bidEntity.AuctionId], bidEntity)
+	}
+
+	for _, auctionId := range auctionIds {
+		auction, err := e.auctionRepository.FindAuctionById(ctx, auctionId)
+		if err != nil {
+			logger.Error("Error trying to publish the bids of auction "+auctionId, err)
+			continue
+		}
+
+		var leadingBid *auction_entity.AuctionEventBid
+		for _, bidEntity := range accepted[auctionId] {
+			bid := toAuctionEventBid(bidEntity)
+			e.publisher.Publish(auction_entity.NewAuctionEvent(
+				auction_entity.EventBidAccepted, *auction, bid))
+
+			if bidEntity.UserId == auction.LeadingBidderId && bidEntity.Amount == auction.CurrentPrice {
+				leadingBid = bid
+			}
+		}
+
+		if leadingBid != nil {
+			e.publisher.Publish(auction_entity.NewAuctionEvent(
+				auction_entity.EventPriceChanged, *auction, leadingBid))
+		}
+	}
+}
+
+func toAuctionEventBid(bidEntity bid_entity.Bid) *auction_entity.AuctionEventBid {
+	return &auction_entity.AuctionEventBid{
+		Id:        bidEntity.Id,
+		BidderId:  bidEntity.UserId,
+		Amount:    bidEntity.Amount,
+		Timestamp: bidEntity.Timestamp,
+	}
+}
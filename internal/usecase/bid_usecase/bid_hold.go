@@ -0,0 +1,71 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// bidHolds mantém as reservas de saldo alinhadas com o pipeline de lances:
+// a reserva é feita na admissão do lance e liberada quando o lance é
+// rejeitado, falha ou é superado. A captura acontece no encerramento do leilão.
+type bidHolds struct {
+	walletRepository wallet_entity.WalletRepositoryInterface
+	bidRepository    bid_entity.BidEntityRepository
+}
+
+func (h *bidHolds) place(ctx context.Context, bidEntity bid_entity.Bid) *internal_error.InternalError {
+	return h.walletRepository.PlaceHold(ctx, wallet_entity.NewHold(
+		bidEntity.Id, bidEntity.UserId, bidEntity.AuctionId, bidEntity.Amount))
+}
+
+func (h *bidHolds) release(ctx context.Context, bidId string) {
+	if err := h.walletRepository.ReleaseHold(ctx, bidId); err != nil {
+		logger.Error("Error trying to release hold of bid "+bidId, err)
+	}
+}
+
+// afterPersist libera as reservas dos lances rejeitados e dos lances que
+// deixaram de liderar os leilões do lote.
+func (h *bidHolds) afterPersist(
+	ctx context.Context, bidBatch []bid_entity.Bid, rejections []bid_entity.BidRejection) {
+	for _, rejection := range rejections {
+		h.release(ctx, rejection.BidId)
+	}
+
+	seen := make(map[string]bool)
+	for _, bidEntity := range bidBatch {
+		if seen[bidEntity.AuctionId] {
+			continue
+		}
+		seen[bidEntity.AuctionId] = true
+
+		h.releaseOutbid(ctx, bidEntity.AuctionId)
+	}
+}
+
+func (h *bidHolds) releaseOutbid(ctx context.Context, auctionId string) {
+	leadingBid, err := h.bidRepository.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		if err.Err == "not_found" {
+			return
+		}
+		logger.Error("Error trying to find the leading bid of auction "+auctionId, err)
+		return
+	}
+	if leadingBid == nil {
+		return
+	}
+
+	holds, err := h.walletRepository.FindHeldHoldsByAuctionId(ctx, auctionId)
+	if err != nil {
+		logger.Error("Error trying to find holds of auction "+auctionId, err)
+		return
+	}
+
+	for _, hold := range wallet_entity.OutbidHolds(holds, leadingBid.Id, leadingBid.Amount) {
+		h.release(ctx, hold.Id)
+	}
+}
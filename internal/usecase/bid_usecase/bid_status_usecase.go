@@ -0,0 +1,115 @@
+package bid_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type BidStatusOutputDTO struct {
+	Id        string               `json:"id"`
+	AuctionId string               `json:"auction_id"`
+	Status    bid_entity.BidStatus `json:"status"`
+	Reason    string               `json:"reason,omitempty"`
+	UpdatedAt time.Time            `json:"updated_at" time_format:"2006-01-02 15:04:05"`
+}
+
+// bidStatusTracker guarda em memória o resultado do processamento assíncrono
+// dos lances para que o cliente possa consultá-lo. Os registros mais antigos
+// são descartados quando o limite é atingido.
+type bidStatusTracker struct {
+	mu         sync.Mutex
+	statuses   map[string]BidStatusOutputDTO
+	order      []string
+	maxEntries int
+}
+
+func newBidStatusTracker(maxEntries int) *bidStatusTracker {
+	return &bidStatusTracker{
+		statuses:   make(map[string]BidStatusOutputDTO),
+		maxEntries: maxEntries,
+	}
+}
+
+func (t *bidStatusTracker) set(
+	bidEntity bid_entity.Bid, status bid_entity.BidStatus, reason string) BidStatusOutputDTO {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.statuses[bidEntity.Id]; !ok {
+		t.order = append(t.order, bidEntity.Id)
+	}
+
+	bidStatus := BidStatusOutputDTO{
+		Id:        bidEntity.Id,
+		AuctionId: bidEntity.AuctionId,
+		Status:    status,
+		Reason:    reason,
+		UpdatedAt: time.Now(),
+	}
+	t.statuses[bidEntity.Id] = bidStatus
+
+	for len(t.order) > t.maxEntries {
+		delete(t.statuses, t.order[0])
+		t.order = t.order[1:]
+	}
+
+	return bidStatus
+}
+
+func (t *bidStatusTracker) remove(bidId string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// A posição em order é descartada naturalmente quando for a mais antiga
+	delete(t.statuses, bidId)
+}
+
+func (t *bidStatusTracker) get(bidId string) (BidStatusOutputDTO, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status, ok := t.statuses[bidId]
+	return status, ok
+}
+
+// markProcessed marca como aceitos os lances do lote que não foram rejeitados.
+func (t *bidStatusTracker) markProcessed(
+	bidBatch []bid_entity.Bid, rejections []bid_entity.BidRejection) {
+	rejected := make(map[string]string, len(rejections))
+	for _, rejection := range rejections {
+		rejected[rejection.BidId] = rejection.Reason
+	}
+
+	for _, bidEntity := range bidBatch {
+		if reason, ok := rejected[bidEntity.Id]; ok {
+			t.set(bidEntity, bid_entity.Rejected, reason)
+		} else {
+			t.set(bidEntity, bid_entity.Accepted, "")
+		}
+	}
+}
+
+func (bu *BidUseCase) FindBidStatus(
+	ctx context.Context, bidId string) (*BidStatusOutputDTO, *internal_error.InternalError) {
+	status, ok := bu.statuses.get(bidId)
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Bid status not found with this id = %s", bidId))
+	}
+
+	return &status, nil
+}
+
+func getBidStatusMaxEntries() int {
+	value, err := strconv.Atoi(os.Getenv("BID_STATUS_MAX_ENTRIES"))
+	if err != nil || value <= 0 {
+		return 100000
+	}
+	return value
+}
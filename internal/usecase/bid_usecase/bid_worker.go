@@ -0,0 +1,238 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"hash/fnv"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// bidWorker cuida de uma parte do pipeline de lances. Todos os lances de um
+// leilão vão para o mesmo worker, então são gravados na ordem em que foram
+// aceitos, enquanto leilões diferentes são processados em paralelo. O lote é
+// local à goroutine do worker, então nenhum estado é compartilhado.
+type bidWorker struct {
+	id                   int
+	bidChannel           chan bid_entity.Bid
+	bidRepository        bid_entity.BidEntityRepository
+	deadLetterRepository bid_entity.DeadLetterRepositoryInterface
+	statuses             *bidStatusTracker
+	holds                *bidHolds
+	events               *bidEvents
+	maxBatchSize         int
+	batchInsertInterval  time.Duration
+	retry                retryConfig
+}
+
+// retryConfig controla as novas tentativas de um lote após erros transitórios.
+type retryConfig struct {
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+func newBidWorker(
+	id int,
+	bidRepository bid_entity.BidEntityRepository,
+	deadLetterRepository bid_entity.DeadLetterRepositoryInterface,
+	statuses *bidStatusTracker,
+	holds *bidHolds,
+	events *bidEvents,
+	queueCapacity int,
+	maxBatchSize int,
+	batchInsertInterval time.Duration,
+	retry retryConfig) *bidWorker {
+	return &bidWorker{
+		id:                   id,
+		bidChannel:           make(chan bid_entity.Bid, queueCapacity),
+		bidRepository:        bidRepository,
+		deadLetterRepository: deadLetterRepository,
+		statuses:             statuses,
+		holds:                holds,
+		events:               events,
+		maxBatchSize:         maxBatchSize,
+		batchInsertInterval:  batchInsertInterval,
+		retry:                retry,
+	}
+}
+
+func (w *bidWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.batchInsertInterval)
+	defer ticker.Stop()
+
+	var bidBatch []bid_entity.Bid
+
+	for {
+		select {
+		case bidEntity := <-w.bidChannel:
+			bidBatch = append(bidBatch, bidEntity)
+
+			if len(bidBatch) >= w.maxBatchSize {
+				w.processBids(ctx, bidBatch)
+				bidBatch = nil
+			}
+
+		case <-ticker.C:
+			if len(bidBatch) > 0 {
+				w.processBids(ctx, bidBatch)
+				bidBatch = nil
+			}
+
+		case <-ctx.Done():
+			// Drena o que já foi aceito antes de encerrar
+			for {
+				select {
+				case bidEntity := <-w.bidChannel:
+					bidBatch = append(bidBatch, bidEntity)
+				default:
+					w.processBids(ctx, bidBatch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// processBids grava o lote. ctx só sinaliza o encerramento do worker: os
+// lances já foram aceitos, então as gravações usam um contexto próprio e cada
+// lance termina gravado ou na dead letter.
+func (w *bidWorker) processBids(ctx context.Context, bidBatch []bid_entity.Bid) {
+	if len(bidBatch) == 0 {
+		return
+	}
+
+	log.Printf("Worker %d processing batch of %d bids...", w.id, len(bidBatch))
+
+	writeCtx := context.Background()
+	rejections, attempts, err := w.createWithRetry(ctx, bidBatch)
+	if err == nil {
+		w.statuses.markProcessed(bidBatch, rejections)
+		w.holds.afterPersist(writeCtx, bidBatch, rejections)
+		w.events.afterPersist(writeCtx, bidBatch, rejections)
+		log.Printf("Worker %d successfully processed batch of %d bids (%d rejected)",
+			w.id, len(bidBatch), len(rejections))
+		return
+	}
+
+	logger.Error("Error processing batch:", err)
+
+	// Esgotadas as tentativas de um erro transitório, o lote inteiro vai para a dead letter
+	if err.Err == "service_unavailable" {
+		for _, bidEntity := range bidBatch {
+			w.deadLetter(writeCtx, bidEntity, err, attempts)
+		}
+		return
+	}
+
+	// Erro permanente: reprocessa lance a lance para isolar os que falham
+	for _, bidEntity := range bidBatch {
+		single := []bid_entity.Bid{bidEntity}
+
+		rejections, bidAttempts, err := w.createWithRetry(ctx, single)
+		if err == nil {
+			w.statuses.markProcessed(single, rejections)
+			w.holds.afterPersist(writeCtx, single, rejections)
+			w.events.afterPersist(writeCtx, single, rejections)
+			continue
+		}
+
+		w.deadLetter(writeCtx, bidEntity, err, attempts+bidAttempts)
+	}
+}
+
+// createWithRetry tenta gravar o lote, repetindo com backoff exponencial
+// enquanto o repositório reportar um erro transitório. No encerramento do
+// worker não espera pela próxima tentativa e devolve o último erro.
+func (w *bidWorker) createWithRetry(
+	ctx context.Context,
+	bidBatch []bid_entity.Bid) ([]bid_entity.BidRejection, int, *internal_error.InternalError) {
+	backoff := w.retry.backoff
+
+	for attempt := 1; ; attempt++ {
+		rejections, err := w.bidRepository.CreateBid(context.Background(), bidBatch)
+		if err == nil {
+			return rejections, attempt, nil
+		}
+
+		if err.Err != "service_unavailable" || attempt >= w.retry.maxAttempts {
+			return nil, attempt, err
+		}
+
+		log.Printf("Worker %d retrying batch in %s (attempt %d): %v", w.id, backoff, attempt, err)
+		select {
+		case <-ctx.Done():
+			return nil, attempt, err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > w.retry.maxBackoff {
+			backoff = w.retry.maxBackoff
+		}
+	}
+}
+
+func (w *bidWorker) deadLetter(
+	ctx context.Context, bidEntity bid_entity.Bid, cause *internal_error.InternalError, attempts int) {
+	deadLetter := bid_entity.DeadLetterBid{
+		Bid:      bidEntity,
+		Error:    cause.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now(),
+	}
+
+	w.statuses.set(bidEntity, bid_entity.Failed, cause.Error())
+
+	// O saldo não fica preso enquanto o lance aguarda na dead letter; o replay reserva de novo
+	w.holds.release(ctx, bidEntity.Id)
+
+	if err := w.deadLetterRepository.SaveDeadLetter(ctx, deadLetter); err != nil {
+		logger.Error("Error trying to dead letter bid "+bidEntity.Id, err)
+		return
+	}
+
+	log.Printf("Worker %d sent bid %s to dead letter after %d attempts", w.id, bidEntity.Id, attempts)
+}
+
+// shardFor devolve o worker responsável pelo leilão.
+func shardFor(workers []*bidWorker, auctionId string) *bidWorker {
+	hash := fnv.New32a()
+	hash.Write([]byte(auctionId))
+
+	return workers[hash.Sum32()%uint32(len(workers))]
+}
+
+func getRetryConfig() retryConfig {
+	config := retryConfig{
+		maxAttempts: 3,
+		backoff:     500 * time.Millisecond,
+		maxBackoff:  10 * time.Second,
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("BID_RETRY_ATTEMPTS")); err == nil && value > 0 {
+		config.maxAttempts = value
+	}
+
+	if value, err := time.ParseDuration(os.Getenv("BID_RETRY_BACKOFF")); err == nil && value > 0 {
+		config.backoff = value
+	}
+
+	if config.maxBackoff < config.backoff {
+		config.maxBackoff = config.backoff
+	}
+
+	return config
+}
+
+func getBidWorkers() int {
+	value, err := strconv.Atoi(os.Getenv("BID_WORKERS"))
+	if err != nil || value <= 0 {
+		return 4
+	}
+	return value
+}
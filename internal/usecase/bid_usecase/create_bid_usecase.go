@@ -2,10 +2,13 @@ package bid_usecase
 
 import (
 	"context"
-	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/utils"
 	"log"
 	"os"
 	"strconv"
@@ -14,6 +17,7 @@ import (
 )
 
 type BidInputDTO struct {
+	// UserId é preenchido com o usuário autenticado; se enviado, precisa coincidir
 	UserId    string  `json:"user_id"`
 	AuctionId string  `json:"auction_id"`
 	Amount    float64 `json:"amount"`
@@ -25,130 +29,246 @@ type BidOutputDTO struct {
 	AuctionId string    `json:"auction_id"`
 	Amount    float64   `json:"amount"`
 	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	Voided    bool      `json:"voided,omitempty"`
 }
 
 type BidUseCase struct {
 	BidRepository              bid_entity.BidEntityRepository
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	userRepository             user_entity.UserRepositoryInterface
+	restrictionRepository      restriction_entity.RestrictionRepositoryInterface
 	maxBatchSize               int
 	batchInsertInterval        time.Duration
-	bidChannel                 chan bid_entity.Bid
-	wg                         sync.WaitGroup
-}
+	workers                    []*bidWorker
+	stopWorkers                context.CancelFunc
+	workersDone                sync.WaitGroup
+	admission                  admissionConfig
+	counters                   admissionCounters
+	statuses                   *bidStatusTracker
+	holds                      *bidHolds
+	events                     *bidEvents
 
-var bidBatch []bid_entity.Bid
+	*DeadLetterUseCase
+}
 
 type BidUseCaseInterface interface {
 	CreateBid(
 		ctx context.Context,
-		bidInputDTO BidInputDTO) *internal_error.InternalError
+		bidInputDTO BidInputDTO) (*BidStatusOutputDTO, *internal_error.InternalError)
+
+	FindBidStatus(
+		ctx context.Context, bidId string) (*BidStatusOutputDTO, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*BidOutputDTO, *internal_error.InternalError)
 
 	FindBidByAuctionId(
-		ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError)
+		ctx context.Context,
+		auctionId string,
+		sort bid_entity.BidSort,
+		page, limit int64) (*BidListOutputDTO, *internal_error.InternalError)
+
+	FindBidsByUserId(
+		ctx context.Context,
+		userId string,
+		outcome bid_entity.BidOutcome,
+		page, limit int64) (*UserBidListOutputDTO, *internal_error.InternalError)
+
+	FindWinsByUserId(
+		ctx context.Context,
+		userId string,
+		page, limit int64) (*UserBidListOutputDTO, *internal_error.InternalError)
+
+	QueueStats() QueueStatsOutputDTO
+
+	// Close encerra os workers depois de gravarem os lances já aceitos. Deve
+	// ser chamado quando a API já não recebe lances.
+	Close()
+
+	DeadLetterUseCaseInterface
 }
 
-func NewBidUseCase(bidRepository bid_entity.BidEntityRepository, auctionRepositoryInterface auction_entity.AuctionRepositoryInterface) BidUseCaseInterface {
+func NewBidUseCase(
+	bidRepository bid_entity.BidEntityRepository,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	userRepository user_entity.UserRepositoryInterface,
+	deadLetterRepository bid_entity.DeadLetterRepositoryInterface,
+	walletRepository wallet_entity.WalletRepositoryInterface,
+	restrictionRepository restriction_entity.RestrictionRepositoryInterface,
+	eventPublisher auction_entity.AuctionEventPublisher) BidUseCaseInterface {
 	maxSizeInterval := getMaxBatchSizeInterval()
 	maxBatchSize := getMaxBatchSize()
+	retry := getRetryConfig()
 
 	bidUseCase := &BidUseCase{
 		BidRepository:              bidRepository,
 		maxBatchSize:               maxBatchSize,
 		batchInsertInterval:        maxSizeInterval,
-		bidChannel:                 make(chan bid_entity.Bid, 100), // Canal com buffer maior
 		auctionRepositoryInterface: auctionRepositoryInterface,
+		userRepository:             userRepository,
+		restrictionRepository:      restrictionRepository,
+		admission:                  getAdmissionConfig(),
+		statuses:                   newBidStatusTracker(getBidStatusMaxEntries()),
+		holds: &bidHolds{
+			walletRepository: walletRepository,
+			bidRepository:    bidRepository,
+		},
+		events: &bidEvents{
+			publisher:         eventPublisher,
+			auctionRepository: auctionRepositoryInterface,
+		},
 	}
 
-	// Inicia a goroutine para processar bids de forma contínua
-	go bidUseCase.triggerCreateRoutine(context.Background())
-
-	return bidUseCase
-}
-
-func (bu *BidUseCase) triggerCreateRoutine(ctx context.Context) {
-	ticker := time.NewTicker(bu.batchInsertInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case bidEntity := <-bu.bidChannel: // Consome lances do canal
-			bidBatch = append(bidBatch, bidEntity)
-			log.Printf("Bid added to batch. Current batch size: %d", len(bidBatch))
-
-			// Processa o lote se atingir o tamanho máximo
-			if len(bidBatch) >= bu.maxBatchSize {
-				log.Println("Max batch size reached. Processing batch.")
-				bu.processBids(ctx)
-			}
-
-		case <-ticker.C: // Processa a cada intervalo, mesmo que a batch esteja incompleta
-			if len(bidBatch) > 0 {
-				log.Println("Time interval reached. Processing batch.")
-				bu.processBids(ctx)
-			}
-		}
+	bidUseCase.DeadLetterUseCase = &DeadLetterUseCase{
+		bidRepository:        bidRepository,
+		deadLetterRepository: deadLetterRepository,
+		holds:                bidUseCase.holds,
+		statuses:             bidUseCase.statuses,
+		events:               bidUseCase.events,
 	}
-}
 
-func (bu *BidUseCase) processBids(ctx context.Context) {
-	if len(bidBatch) == 0 {
-		return
+	// Inicia um worker por shard; cada leilão sempre cai no mesmo shard
+	ctx, cancel := context.WithCancel(context.Background())
+	bidUseCase.stopWorkers = cancel
+	for i := 0; i < getBidWorkers(); i++ {
+		worker := newBidWorker(
+			i, bidRepository, deadLetterRepository, bidUseCase.statuses, bidUseCase.holds,
+			bidUseCase.events, bidUseCase.admission.queueCapacity, maxBatchSize, maxSizeInterval, retry)
+		bidUseCase.workers = append(bidUseCase.workers, worker)
+		bidUseCase.workersDone.Add(1)
+		go func() {
+			defer bidUseCase.workersDone.Done()
+			worker.run(ctx)
+		}()
 	}
 
-	log.Printf("Processing batch of %d bids...", len(bidBatch))
-
-	// Processa os lances no repositório
-	err := bu.BidRepository.CreateBid(ctx, bidBatch)
-	if err != nil {
-		logger.Error("Error processing batch:", err)
-	} else {
-		log.Printf("Successfully processed batch of %d bids", len(bidBatch))
-	}
+	return bidUseCase
+}
 
-	// Limpa o batch após processamento
-	bidBatch = nil
+func (bu *BidUseCase) Close() {
+	bu.stopWorkers()
+	bu.workersDone.Wait()
 }
 
+// CreateBid valida o lance e o coloca na fila do leilão. A gravação é
+// assíncrona: o status devolvido começa como pendente e pode ser acompanhado
+// por FindBidStatus.
 func (bu *BidUseCase) CreateBid(
 	ctx context.Context,
-	bidInputDTO BidInputDTO) *internal_error.InternalError {
+	bidInputDTO BidInputDTO) (*BidStatusOutputDTO, *internal_error.InternalError) {
 
 	// Buscar o leilão
 	auction, err := bu.auctionRepositoryInterface.FindAuctionById(ctx, bidInputDTO.AuctionId)
 	if err != nil {
 		log.Printf("Erro ao buscar leilão com ID %s: %v", bidInputDTO.AuctionId, err)
-		return err
+		return nil, err
 	}
 
 	if auction == nil {
 		log.Printf("Leilão %s não encontrado", bidInputDTO.AuctionId)
-		return internal_error.NewInternalServerError("auction not found")
+		return nil, internal_error.NewNotFoundError("auction not found").
+			WithCode(internal_error.CodeAuctionNotFound)
 	}
 
 	// Verificar o status do leilão
-	if auction.Status == 1 { // Supondo que 1 seja "encerrado"
+	if auction.Status != auction_entity.Active { // Encerrado ou cancelado
 		log.Printf("Leilão %s encerrado, não é possível aceitar novos lances", bidInputDTO.AuctionId)
-		return internal_error.NewInternalServerError("Leilão encerrado. Não é possível aceitar novos lances.")
+		return nil, internal_error.NewGoneError("Leilão encerrado. Não é possível aceitar novos lances.").
+			WithCode(internal_error.CodeAuctionClosed)
+	}
+
+	// O vendedor não pode dar lances no próprio leilão
+	if auction.SellerId != "" && auction.SellerId == bidInputDTO.UserId {
+		return nil, internal_error.NewForbiddenError("Sellers cannot bid on their own auction").
+			WithCode(internal_error.CodeOwnAuctionBid)
 	}
 
 	// Cria a entidade do lance
 	bidEntity, err := bid_entity.CreateBid(bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount)
 	if err != nil {
+		return nil, err
+	}
+
+	if err := bu.validateBidder(ctx, bidEntity.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := bu.validateRestrictions(ctx, auction.SellerId, bidEntity.UserId); err != nil {
+		return nil, err
+	}
+
+	// Lances de leilões prestes a encerrar têm prioridade quando a fila está cheia
+	auctionEndTime := auction.Timestamp.Add(time.Duration(utils.GetAuctionTimeoutSeconds()) * time.Second)
+	highPriority := time.Until(auctionEndTime) <= bu.admission.priorityWindow
+
+	// Reserva o valor do lance; sem saldo livre suficiente o lance é recusado
+	if err := bu.holds.place(ctx, *bidEntity); err != nil {
+		return nil, err
+	}
+
+	// O status é registrado antes de enfileirar para não sobrescrever o resultado do worker
+	pendingStatus := bu.statuses.set(*bidEntity, bid_entity.Pending, "")
+
+	// Envia ao worker responsável pelo leilão, preservando a ordem dos lances
+	if err := bu.admit(
+		ctx, shardFor(bu.workers, bidEntity.AuctionId), *bidEntity, highPriority); err != nil {
+		bu.statuses.remove(bidEntity.Id)
+		bu.holds.release(ctx, bidEntity.Id)
+		log.Printf("Lance recusado para o leilão %s: %v", bidEntity.AuctionId, err)
+		return nil, err
+	}
+
+	return &pendingStatus, nil
+}
+
+// validateBidder só aceita lances de usuários existentes e ativos. O
+// repositório de usuários é o cache compartilhado, mantendo o caminho rápido.
+func (bu *BidUseCase) validateBidder(
+	ctx context.Context, userId string) *internal_error.InternalError {
+	user, err := bu.userRepository.FindUserById(ctx, userId)
+	if err != nil {
+		if err.Err == "not_found" {
+			return internal_error.NewUnprocessableEntityError("Bidder is not a registered user").
+				WithCode(internal_error.CodeBidderNotRegistered)
+		}
 		return err
 	}
 
-	// Adiciona ao canal para processamento
-	select {
-	case bu.bidChannel <- *bidEntity:
-		log.Println("Bid successfully added to channel")
+	switch user.Status {
+	case user_entity.Active:
+		return nil
+	case user_entity.Suspended:
+		return internal_error.NewForbiddenError("Bidder is suspended").
+			WithCode(internal_error.CodeBidderSuspended)
 	default:
-		// Se o canal estiver cheio, força o processamento do lote atual
-		log.Println("Channel is full. Forcing batch processing.")
-		bu.processBids(ctx)
-		bu.bidChannel <- *bidEntity // Tenta novamente adicionar após esvaziar o batch
+		return internal_error.NewForbiddenError("Bidder is not active").
+			WithCode(internal_error.CodeBidderInactive)
+	}
+}
+
+// validateRestrictions recusa lances de usuários banidos da plataforma ou
+// bloqueados pelo vendedor do leilão.
+func (bu *BidUseCase) validateRestrictions(
+	ctx context.Context, sellerId, userId string) *internal_error.InternalError {
+	ban, err := bu.restrictionRepository.FindActiveBan(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if ban != nil {
+		return ban.Error()
+	}
+
+	if sellerId == "" {
+		return nil
+	}
+
+	blocked, err := bu.restrictionRepository.IsBidderBlocked(ctx, sellerId, userId)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return internal_error.NewForbiddenError("Bidder is blocked by the seller of this auction").
+			WithCode(internal_error.CodeBidderBlocked)
 	}
 
 	return nil
@@ -0,0 +1,881 @@
+package bid_usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/auction_entity/auctiontest"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity/wallettest"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+)
+
+// fakeUserRepository considera ativo qualquer usuário que não esteja em statuses
+type fakeUserRepository struct {
+	statuses map[string]user_entity.UserStatus
+}
+
+func (f *fakeUserRepository) CreateUser(ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	return nil
+}
+
+func (f *fakeUserRepository) UpdateUser(ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	return nil
+}
+
+func (f *fakeUserRepository) UpdateUserStatus(ctx context.Context, userId string, status user_entity.UserStatus) *internal_error.InternalError {
+	return nil
+}
+
+func (f *fakeUserRepository) FindUserById(ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	status, ok := f.statuses[userId]
+	if !ok {
+		status = user_entity.Active
+	}
+	if status == "" {
+		return nil, internal_error.NewNotFoundError("user not found")
+	}
+	return &user_entity.User{Id: userId, Status: status}, nil
+}
+
+func (f *fakeUserRepository) FindUsers(ctx context.Context, page, limit int64) ([]user_entity.User, int64, *internal_error.InternalError) {
+	return nil, 0, nil
+}
+
+// recordingBidRepository guarda os lances persistidos na ordem em que chegaram
+type recordingBidRepository struct {
+	mu          sync.Mutex
+	bids        []bid_entity.Bid
+	rejectAfter *time.Time
+}
+
+func (r *recordingBidRepository) CreateBid(ctx context.Context, bidEntities []bid_entity.Bid) ([]bid_entity.BidRejection, *internal_error.InternalError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rejections []bid_entity.BidRejection
+	for _, bid := range bidEntities {
+		if r.rejectAfter != nil && !bid.Timestamp.Before(*r.rejectAfter) {
+			rejections = append(rejections, bid_entity.BidRejection{BidId: bid.Id, Reason: "bid placed after the auction end"})
+			continue
+		}
+		r.bids = append(r.bids, bid)
+	}
+	return rejections, nil
+}
+
+func (r *recordingBidRepository) FindBidByAuctionId(ctx context.Context, auctionId string, sort bid_entity.BidSort, page, limit int64) ([]bid_entity.Bid, int64, *internal_error.InternalError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var bids []bid_entity.Bid
+	for _, bid := range r.bids {
+		if bid.AuctionId == auctionId {
+			bids = append(bids, bid)
+		}
+	}
+	return bids, int64(len(bids)), nil
+}
+
+func (r *recordingBidRepository) FindBidSummary(ctx context.Context, auctionId string) (*bid_entity.BidSummary, *internal_error.InternalError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := &bid_entity.BidSummary{}
+	bidders := make(map[string]bool)
+	for _, bid := range r.bids {
+		if bid.AuctionId != auctionId || bid.Voided {
+			continue
+		}
+		if summary.Count == 0 {
+			summary.OpeningBid = bid.Amount
+		}
+		summary.Count++
+		bidders[bid.UserId] = true
+		if bid.Amount > summary.HighBid {
+			summary.HighBid = bid.Amount
+		}
+		if bid.Timestamp.After(summary.LastBidAt) {
+			summary.LastBidAt = bid.Timestamp
+		}
+	}
+	summary.UniqueBidders = int64(len(bidders))
+	return summary, nil
+}
+
+func (r *recordingBidRepository) FindBidById(ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return nil, internal_error.NewNotFoundError("bid not found")
+}
+
+func (r *recordingBidRepository) VoidBid(ctx context.Context, bidId string) *internal_error.InternalError {
+	return nil
+}
+
+func (r *recordingBidRepository) FindBidsByUserId(ctx context.Context, userId string, outcome bid_entity.BidOutcome, page, limit int64) ([]bid_entity.UserBid, int64, *internal_error.InternalError) {
+	return nil, 0, nil
+}
+
+func (r *recordingBidRepository) FindWinningBidByAuctionId(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var winningBid *bid_entity.Bid
+	for i, bid := range r.bids {
+		if bid.AuctionId == auctionId && (winningBid == nil || bid.Amount > winningBid.Amount) {
+			winningBid = &r.bids[i]
+		}
+	}
+	if winningBid == nil {
+		return nil, nil
+	}
+	found := *winningBid
+	return &found, nil
+}
+
+// fakeRestrictionRepository guarda banimentos e bloqueios de vendedores em memória
+type fakeRestrictionRepository struct {
+	restriction_entity.RestrictionRepositoryInterface
+	bans    map[string]*restriction_entity.Ban
+	blocked map[string][]string
+}
+
+func (f *fakeRestrictionRepository) FindActiveBan(ctx context.Context, userId string) (*restriction_entity.Ban, *internal_error.InternalError) {
+	ban, ok := f.bans[userId]
+	if !ok || !ban.ActiveAt(time.Now()) {
+		return nil, nil
+	}
+	return ban, nil
+}
+
+func (f *fakeRestrictionRepository) IsBidderBlocked(ctx context.Context, sellerId, bidderId string) (bool, *internal_error.InternalError) {
+	for _, blockedId := range f.blocked[sellerId] {
+		if blockedId == bidderId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordingDeadLetterRepository guarda em memória os lances enviados à dead letter
+type recordingDeadLetterRepository struct {
+	mu          sync.Mutex
+	deadLetters map[string]bid_entity.DeadLetterBid
+}
+
+func (r *recordingDeadLetterRepository) SaveDeadLetter(ctx context.Context, deadLetter bid_entity.DeadLetterBid) *internal_error.InternalError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.deadLetters == nil {
+		r.deadLetters = map[string]bid_entity.DeadLetterBid{}
+	}
+	r.deadLetters[deadLetter.Bid.Id] = deadLetter
+	return nil
+}
+
+func (r *recordingDeadLetterRepository) FindDeadLetters(ctx context.Context) ([]bid_entity.DeadLetterBid, *internal_error.InternalError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deadLetters []bid_entity.DeadLetterBid
+	for _, deadLetter := range r.deadLetters {
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	return deadLetters, nil
+}
+
+func (r *recordingDeadLetterRepository) FindDeadLetterByBidId(ctx context.Context, bidId string) (*bid_entity.DeadLetterBid, *internal_error.InternalError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deadLetter, ok := r.deadLetters[bidId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("dead letter not found")
+	}
+	return &deadLetter, nil
+}
+
+func (r *recordingDeadLetterRepository) DeleteDeadLetter(ctx context.Context, bidId string) *internal_error.InternalError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.deadLetters, bidId)
+	return nil
+}
+
+func (r *recordingBidRepository) snapshot() []bid_entity.Bid {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]bid_entity.Bid(nil), r.bids...)
+}
+
+// bidFixture reúne os dublês de um BidUseCase. Os campos nil recebem um dublê
+// vazio em newBidUseCase, então cada teste só preenche o que verifica.
+type bidFixture struct {
+	bids         bid_entity.BidEntityRepository
+	auctions     auction_entity.AuctionRepositoryInterface
+	users        user_entity.UserRepositoryInterface
+	deadLetters  bid_entity.DeadLetterRepositoryInterface
+	wallets      wallet_entity.WalletRepositoryInterface
+	restrictions restriction_entity.RestrictionRepositoryInterface
+	publisher    auction_entity.AuctionEventPublisher
+}
+
+func (f bidFixture) newBidUseCase() bid_usecase.BidUseCaseInterface {
+	if f.bids == nil {
+		f.bids = &recordingBidRepository{}
+	}
+	if f.auctions == nil {
+		f.auctions = new(auctiontest.MockAuctionRepository)
+	}
+	if f.users == nil {
+		f.users = &fakeUserRepository{}
+	}
+	if f.deadLetters == nil {
+		f.deadLetters = &recordingDeadLetterRepository{}
+	}
+	if f.wallets == nil {
+		f.wallets = wallettest.NewFakeWalletRepository()
+	}
+	if f.restrictions == nil {
+		f.restrictions = &fakeRestrictionRepository{}
+	}
+	return bid_usecase.NewBidUseCase(
+		f.bids, f.auctions, f.users, f.deadLetters, f.wallets, f.restrictions, f.publisher)
+}
+
+func TestCreateBid_KeepsOrderPerAuction(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "3")
+	t.Setenv("BATCH_INSERT_INTERVAL", "10ms")
+	t.Setenv("BID_WORKERS", "4")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	auctionIds := []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+	for _, id := range auctionIds {
+		auctionRepo.On("FindAuctionById", mock.Anything, id).
+			Return(&auction_entity.Auction{Id: id, Status: auction_entity.Active}, nil)
+	}
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+
+	// Um produtor por leilão, todos concorrendo entre si
+	const bidsPerAuction = 20
+	var wg sync.WaitGroup
+	for _, auctionId := range auctionIds {
+		wg.Add(1)
+		go func(auctionId string) {
+			defer wg.Done()
+			for i := 1; i <= bidsPerAuction; i++ {
+				_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+					UserId:    uuid.NewString(),
+					AuctionId: auctionId,
+					Amount:    float64(i),
+				})
+				assert.Nil(t, err)
+			}
+		}(auctionId)
+	}
+	wg.Wait()
+
+	assert.Eventually(t, func() bool {
+		return len(bidRepo.snapshot()) == bidsPerAuction*len(auctionIds)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	lastAmount := map[string]float64{}
+	for _, bid := range bidRepo.snapshot() {
+		assert.Greater(t, bid.Amount, lastAmount[bid.AuctionId])
+		lastAmount[bid.AuctionId] = bid.Amount
+	}
+}
+
+func TestCreateBid_ClosedAuction(t *testing.T) {
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Completed}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+
+	_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId:    uuid.NewString(),
+		AuctionId: auctionId,
+		Amount:    10,
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "gone", err.Err)
+	assert.Equal(t, internal_error.CodeAuctionClosed, err.Code)
+	assert.Empty(t, bidRepo.snapshot())
+}
+
+// blockingBidRepository segura o worker até que release seja fechado
+type blockingBidRepository struct {
+	recordingBidRepository
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingBidRepository) CreateBid(ctx context.Context, bidEntities []bid_entity.Bid) ([]bid_entity.BidRejection, *internal_error.InternalError) {
+	r.entered <- struct{}{}
+	<-r.release
+	return r.recordingBidRepository.CreateBid(ctx, bidEntities)
+}
+
+func TestCreateBid_RejectsWhenQueueIsFull(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+	t.Setenv("BID_WORKERS", "1")
+	t.Setenv("BID_QUEUE_CAPACITY", "1")
+	t.Setenv("BID_ADMISSION_POLICY", "reject")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &blockingBidRepository{
+		entered: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+	defer close(bidRepo.release)
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+	input := bid_usecase.BidInputDTO{UserId: uuid.NewString(), AuctionId: auctionId, Amount: 10}
+
+	// O primeiro lance ocupa o worker, o segundo ocupa a fila
+	_, err := bidUC.CreateBid(context.Background(), input)
+	assert.Nil(t, err)
+	<-bidRepo.entered
+	_, err = bidUC.CreateBid(context.Background(), input)
+	assert.Nil(t, err)
+
+	_, err = bidUC.CreateBid(context.Background(), input)
+	assert.NotNil(t, err)
+	assert.Equal(t, "too_many_requests", err.Err)
+
+	stats := bidUC.QueueStats()
+	assert.Equal(t, 1, stats.Depth)
+	assert.Equal(t, uint64(2), stats.Admitted)
+	assert.Equal(t, uint64(1), stats.Rejected)
+}
+
+func TestCreateBid_WaitsForQueueSpaceUntilTimeout(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+	t.Setenv("BID_WORKERS", "1")
+	t.Setenv("BID_QUEUE_CAPACITY", "1")
+	t.Setenv("BID_ADMISSION_POLICY", "wait")
+	t.Setenv("BID_ADMISSION_TIMEOUT", "100ms")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &blockingBidRepository{
+		entered: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+	defer close(bidRepo.release)
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+	input := bid_usecase.BidInputDTO{UserId: uuid.NewString(), AuctionId: auctionId, Amount: 10}
+
+	_, err := bidUC.CreateBid(context.Background(), input)
+	assert.Nil(t, err)
+	<-bidRepo.entered
+	_, err = bidUC.CreateBid(context.Background(), input)
+	assert.Nil(t, err)
+
+	// Com a fila cheia o lance espera até o timeout de admissão
+	startedAt := time.Now()
+	_, err = bidUC.CreateBid(context.Background(), input)
+	assert.NotNil(t, err)
+	assert.Equal(t, "service_unavailable", err.Err)
+	assert.Equal(t, internal_error.CodeBidQueueUnavailable, err.Code)
+	assert.GreaterOrEqual(t, time.Since(startedAt), 100*time.Millisecond)
+
+	// Se a fila andar antes do timeout, o lance que esperava é admitido
+	admitted := make(chan *internal_error.InternalError)
+	go func() {
+		_, err := bidUC.CreateBid(context.Background(), input)
+		admitted <- err
+	}()
+	bidRepo.release <- struct{}{}
+	assert.Nil(t, <-admitted)
+
+	stats := bidUC.QueueStats()
+	assert.Equal(t, uint64(3), stats.Admitted)
+	assert.Equal(t, uint64(1), stats.TimedOut)
+	assert.Equal(t, uint64(0), stats.Rejected)
+}
+
+func TestCreateBid_ShedsLowPriorityBidsOnly(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+	t.Setenv("BID_WORKERS", "1")
+	t.Setenv("BID_QUEUE_CAPACITY", "2")
+	t.Setenv("BID_ADMISSION_POLICY", "shed")
+	t.Setenv("BID_SHED_THRESHOLD", "0.5")
+	t.Setenv("BID_PRIORITY_WINDOW", "1m")
+	t.Setenv("AUCTION_TIMEOUT_SECONDS", "3600")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &blockingBidRepository{
+		entered: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+	defer close(bidRepo.release)
+
+	// O leilão recente encerra em uma hora; o antigo, dentro da janela de prioridade
+	recentId, endingId := uuid.NewString(), uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, recentId).
+		Return(&auction_entity.Auction{Id: recentId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+	auctionRepo.On("FindAuctionById", mock.Anything, endingId).
+		Return(&auction_entity.Auction{Id: endingId, Status: auction_entity.Active,
+			Timestamp: time.Now().Add(-time.Hour + 30*time.Second)}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+	recent := bid_usecase.BidInputDTO{UserId: uuid.NewString(), AuctionId: recentId, Amount: 10}
+	ending := bid_usecase.BidInputDTO{UserId: uuid.NewString(), AuctionId: endingId, Amount: 10}
+
+	_, err := bidUC.CreateBid(context.Background(), recent)
+	assert.Nil(t, err)
+	<-bidRepo.entered
+	_, err = bidUC.CreateBid(context.Background(), recent)
+	assert.Nil(t, err)
+
+	// A fila atingiu o limite de descarte: o lance de baixa prioridade é descartado
+	_, err = bidUC.CreateBid(context.Background(), recent)
+	assert.NotNil(t, err)
+	assert.Equal(t, "too_many_requests", err.Err)
+	assert.Equal(t, internal_error.CodeBidQueueFull, err.Code)
+
+	// O lance do leilão prestes a encerrar ainda ocupa a vaga restante
+	_, err = bidUC.CreateBid(context.Background(), ending)
+	assert.Nil(t, err)
+
+	stats := bidUC.QueueStats()
+	assert.Equal(t, 2, stats.Depth)
+	assert.Equal(t, uint64(3), stats.Admitted)
+	assert.Equal(t, uint64(1), stats.Shed)
+}
+
+// flakyBidRepository falha com erro transitório enquanto failing for verdadeiro
+type flakyBidRepository struct {
+	recordingBidRepository
+	failing bool
+}
+
+func (r *flakyBidRepository) CreateBid(ctx context.Context, bidEntities []bid_entity.Bid) ([]bid_entity.BidRejection, *internal_error.InternalError) {
+	r.mu.Lock()
+	failing := r.failing
+	r.mu.Unlock()
+
+	if failing {
+		return nil, internal_error.NewServiceUnavailableError("database unavailable")
+	}
+	return r.recordingBidRepository.CreateBid(ctx, bidEntities)
+}
+
+func TestCreateBid_DeadLettersAfterRetriesAndReplays(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+	t.Setenv("BID_WORKERS", "1")
+	t.Setenv("BID_RETRY_ATTEMPTS", "2")
+	t.Setenv("BID_RETRY_BACKOFF", "1ms")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &flakyBidRepository{failing: true}
+	deadLetterRepo := &recordingDeadLetterRepository{}
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo, deadLetters: deadLetterRepo}.newBidUseCase()
+	_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 10,
+	})
+	assert.Nil(t, err)
+
+	var deadLetters []bid_usecase.DeadLetterBidOutputDTO
+	assert.Eventually(t, func() bool {
+		deadLetters, _ = bidUC.FindDeadLetters(context.Background())
+		return len(deadLetters) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, deadLetters[0].Attempts)
+	assert.Equal(t, "database unavailable", deadLetters[0].Error)
+	assert.Empty(t, bidRepo.snapshot())
+
+	// Com o banco de volta, o replay grava o lance e limpa a dead letter
+	bidRepo.mu.Lock()
+	bidRepo.failing = false
+	bidRepo.mu.Unlock()
+
+	assert.Nil(t, bidUC.ReplayDeadLetter(context.Background(), deadLetters[0].Bid.Id))
+	assert.Len(t, bidRepo.snapshot(), 1)
+
+	deadLetters, _ = bidUC.FindDeadLetters(context.Background())
+	assert.Empty(t, deadLetters)
+}
+
+func TestClose_WritesAcceptedBidsBeforeStopping(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "100")
+	t.Setenv("BATCH_INSERT_INTERVAL", "1h")
+	t.Setenv("BID_WORKERS", "1")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+	for i := 1; i <= 3; i++ {
+		_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+			UserId: uuid.NewString(), AuctionId: auctionId, Amount: float64(i),
+		})
+		assert.Nil(t, err)
+	}
+
+	// Nem o lote nem o intervalo se completaram: quem grava é o encerramento
+	bidUC.Close()
+	assert.Len(t, bidRepo.snapshot(), 3)
+}
+
+func TestClose_DoesNotWaitForRetryBackoff(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+	t.Setenv("BID_WORKERS", "1")
+	t.Setenv("BID_RETRY_ATTEMPTS", "3")
+	t.Setenv("BID_RETRY_BACKOFF", "1h")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &flakyBidRepository{failing: true}
+	deadLetterRepo := &recordingDeadLetterRepository{}
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo, deadLetters: deadLetterRepo}.newBidUseCase()
+	_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 10,
+	})
+	assert.Nil(t, err)
+
+	// O lance em espera pela próxima tentativa vai para a dead letter em vez
+	// de segurar o encerramento
+	closed := make(chan struct{})
+	go func() {
+		bidUC.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close waited for the retry backoff")
+	}
+
+	deadLetters, _ := bidUC.FindDeadLetters(context.Background())
+	if assert.Len(t, deadLetters, 1) {
+		assert.Equal(t, "database unavailable", deadLetters[0].Error)
+	}
+	assert.Empty(t, bidRepo.snapshot())
+}
+
+func TestDeadLetterUseCase_ReplaysWithoutBidWorkers(t *testing.T) {
+	bidRepo := &recordingBidRepository{}
+	deadLetterRepo := &recordingDeadLetterRepository{}
+	walletRepo := wallettest.NewFakeWalletRepository()
+
+	bid := bid_entity.Bid{Id: uuid.NewString(), UserId: uuid.NewString(), AuctionId: uuid.NewString(), Amount: 10, Timestamp: time.Now()}
+	deadLetterRepo.SaveDeadLetter(context.Background(), bid_entity.DeadLetterBid{Bid: bid, Error: "database unavailable", Attempts: 2})
+
+	// O comando deadletter não tem workers nem rastreio de status
+	deadLetterUC := bid_usecase.NewDeadLetterUseCase(bidRepo, deadLetterRepo, walletRepo)
+	assert.Nil(t, deadLetterUC.ReplayDeadLetter(context.Background(), bid.Id))
+
+	assert.Len(t, bidRepo.snapshot(), 1)
+	assert.Equal(t, wallet_entity.Held, walletRepo.HoldStatus(bid.Id))
+	deadLetters, _ := deadLetterUC.FindDeadLetters(context.Background())
+	assert.Empty(t, deadLetters)
+
+	err := deadLetterUC.ReplayDeadLetter(context.Background(), bid.Id)
+	assert.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+}
+
+func TestCreateBid_LateBidIsReportedAsRejected(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+
+	auctionEnd := time.Now()
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{rejectAfter: &auctionEnd}
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+
+	bidStatus, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 10,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, bid_entity.Pending, bidStatus.Status)
+
+	assert.Eventually(t, func() bool {
+		current, err := bidUC.FindBidStatus(context.Background(), bidStatus.Id)
+		return err == nil && current.Status == bid_entity.Rejected
+	}, 2*time.Second, 10*time.Millisecond)
+
+	current, _ := bidUC.FindBidStatus(context.Background(), bidStatus.Id)
+	assert.Equal(t, "bid placed after the auction end", current.Reason)
+	assert.Empty(t, bidRepo.snapshot())
+}
+
+func TestCreateBid_RejectsUnknownAndInactiveBidders(t *testing.T) {
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	unknownUser, inactiveUser, suspendedUser := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	userRepo := &fakeUserRepository{statuses: map[string]user_entity.UserStatus{
+		unknownUser:   "",
+		inactiveUser:  user_entity.Inactive,
+		suspendedUser: user_entity.Suspended,
+	}}
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo, users: userRepo}.newBidUseCase()
+
+	for userId, expected := range map[string]internal_error.InternalError{
+		unknownUser:   {Err: "unprocessable_entity", Code: internal_error.CodeBidderNotRegistered, Message: "Bidder is not a registered user"},
+		inactiveUser:  {Err: "forbidden", Code: internal_error.CodeBidderInactive, Message: "Bidder is not active"},
+		suspendedUser: {Err: "forbidden", Code: internal_error.CodeBidderSuspended, Message: "Bidder is suspended"},
+	} {
+		_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+			UserId: userId, AuctionId: auctionId, Amount: 10,
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, expected.Err, err.Err)
+		assert.Equal(t, expected.Code, err.Code)
+		assert.Equal(t, expected.Message, err.Message)
+	}
+
+	assert.Equal(t, 0, bidUC.QueueStats().Depth)
+}
+
+func TestCreateBid_RejectsSellerBiddingOnOwnAuction(t *testing.T) {
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	sellerId, auctionId := uuid.NewString(), uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{
+			Id: auctionId, SellerId: sellerId, Status: auction_entity.Active, Timestamp: time.Now(),
+		}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo}.newBidUseCase()
+
+	_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: sellerId, AuctionId: auctionId, Amount: 10,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "forbidden", err.Err)
+	assert.Equal(t, 0, bidUC.QueueStats().Depth)
+}
+
+func TestCreateBid_RejectsBidAboveAvailableBalance(t *testing.T) {
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	userId, auctionId := uuid.NewString(), uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	walletRepo := &wallettest.FakeWalletRepository{Balances: map[string]float64{userId: 50}}
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo, wallets: walletRepo}.newBidUseCase()
+
+	_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: userId, AuctionId: auctionId, Amount: 80,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "unprocessable_entity", err.Err)
+	assert.Equal(t, internal_error.CodeInsufficientBalance, err.Code)
+	assert.Equal(t, 0, bidUC.QueueStats().Depth)
+}
+
+func TestCreateBid_ReleasesHoldOfOutbidBid(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+	t.Setenv("BID_WORKERS", "1")
+
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+	walletRepo := wallettest.NewFakeWalletRepository()
+
+	auctionId := uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{Id: auctionId, Status: auction_entity.Active, Timestamp: time.Now()}, nil)
+
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo, wallets: walletRepo}.newBidUseCase()
+
+	first, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 10,
+	})
+	assert.Nil(t, err)
+	second, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 20,
+	})
+	assert.Nil(t, err)
+
+	// O lance superado devolve o saldo; o lance líder continua reservado
+	assert.Eventually(t, func() bool {
+		return walletRepo.HoldStatus(first.Id) == wallet_entity.Released
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, wallet_entity.Held, walletRepo.HoldStatus(second.Id))
+}
+
+func TestCreateBid_RejectsBannedAndBlockedBidders(t *testing.T) {
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	bidRepo := &recordingBidRepository{}
+
+	sellerId, auctionId := uuid.NewString(), uuid.NewString()
+	bannedUser, blockedUser, expiredBanUser := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).
+		Return(&auction_entity.Auction{
+			Id: auctionId, SellerId: sellerId, Status: auction_entity.Active, Timestamp: time.Now(),
+		}, nil)
+
+	restrictions := &fakeRestrictionRepository{
+		bans: map[string]*restriction_entity.Ban{
+			bannedUser:     {UserId: bannedUser, Reason: "fraude"},
+			expiredBanUser: {UserId: expiredBanUser, Reason: "spam", ExpiresAt: time.Now().Add(-time.Minute)},
+		},
+		blocked: map[string][]string{sellerId: {blockedUser}},
+	}
+	bidUC := bidFixture{bids: bidRepo, auctions: auctionRepo, restrictions: restrictions}.newBidUseCase()
+
+	for userId, message := range map[string]string{
+		bannedUser:  "User is banned from the platform: fraude",
+		blockedUser: "Bidder is blocked by the seller of this auction",
+	} {
+		_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+			UserId: userId, AuctionId: auctionId, Amount: 10,
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, "forbidden", err.Err)
+		assert.Equal(t, message, err.Message)
+	}
+
+	// Um banimento expirado não impede novos lances
+	_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+		UserId: expiredBanUser, AuctionId: auctionId, Amount: 10,
+	})
+	assert.Nil(t, err)
+}
+
+func TestFindBidByAuctionId_ReturnsPageWithSummary(t *testing.T) {
+	auctionId, otherAuctionId := uuid.NewString(), uuid.NewString()
+	bidderA, bidderB := uuid.NewString(), uuid.NewString()
+	opening := time.Now().Add(-time.Hour)
+
+	bidRepo := &recordingBidRepository{bids: []bid_entity.Bid{
+		{Id: "b1", UserId: bidderA, AuctionId: auctionId, Amount: 10, Timestamp: opening},
+		{Id: "b2", UserId: bidderB, AuctionId: auctionId, Amount: 25, Timestamp: opening.Add(time.Minute)},
+		{Id: "b3", UserId: bidderA, AuctionId: auctionId, Amount: 40, Timestamp: opening.Add(2 * time.Minute), Voided: true},
+		{Id: "b4", UserId: bidderA, AuctionId: auctionId, Amount: 30, Timestamp: opening.Add(3 * time.Minute)},
+		{Id: "b5", UserId: bidderB, AuctionId: otherAuctionId, Amount: 99, Timestamp: opening},
+	}}
+	bidUC := bidFixture{bids: bidRepo}.newBidUseCase()
+
+	output, err := bidUC.FindBidByAuctionId(context.Background(), auctionId, bid_entity.BidSortNewest, 1, 20)
+	assert.Nil(t, err)
+	assert.Len(t, output.Bids, 4)
+	assert.Equal(t, int64(4), output.Total)
+
+	// O resumo ignora o lance anulado
+	assert.Equal(t, int64(3), output.Summary.Count)
+	assert.Equal(t, int64(2), output.Summary.UniqueBidders)
+	assert.Equal(t, 30.0, *output.Summary.HighBid)
+	assert.Equal(t, 10.0, *output.Summary.OpeningBid)
+	assert.Equal(t, opening.Add(3*time.Minute), *output.Summary.LastBidAt)
+
+	empty, err := bidUC.FindBidByAuctionId(context.Background(), uuid.NewString(), bid_entity.BidSortHighest, 1, 20)
+	assert.Nil(t, err)
+	assert.Empty(t, empty.Bids)
+	assert.Equal(t, int64(0), empty.Summary.Count)
+	assert.Nil(t, empty.Summary.HighBid)
+	assert.Nil(t, empty.Summary.LastBidAt)
+}
+
+// recordingEventPublisher guarda os eventos publicados pelo pipeline
+type recordingEventPublisher struct {
+	mu     sync.Mutex
+	events []auction_entity.AuctionEvent
+}
+
+func (p *recordingEventPublisher) Publish(event auction_entity.AuctionEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *recordingEventPublisher) snapshot() []auction_entity.AuctionEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]auction_entity.AuctionEvent(nil), p.events...)
+}
+
+func TestCreateBid_PublishesAcceptedBidsAndPriceChange(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "2")
+	t.Setenv("BATCH_INSERT_INTERVAL", "10ms")
+	t.Setenv("BID_WORKERS", "1")
+
+	auctionId := uuid.NewString()
+	firstBidder, leadingBidder := uuid.NewString(), uuid.NewString()
+
+	// O leilão já reflete o estado gravado após o lote
+	auctionRepo := new(auctiontest.MockAuctionRepository)
+	auctionRepo.On("FindAuctionById", mock.Anything, auctionId).Return(&auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, CurrentPrice: 20, BidCount: 2, LeadingBidderId: leadingBidder,
+	}, nil)
+
+	publisher := &recordingEventPublisher{}
+	bidUC := bidFixture{auctions: auctionRepo, publisher: publisher}.newBidUseCase()
+
+	for bidder, amount := range map[string]float64{firstBidder: 10, leadingBidder: 20} {
+		_, err := bidUC.CreateBid(context.Background(), bid_usecase.BidInputDTO{
+			UserId: bidder, AuctionId: auctionId, Amount: amount,
+		})
+		assert.Nil(t, err)
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(publisher.snapshot()) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	counts := map[auction_entity.AuctionEventType]int{}
+	for _, event := range publisher.snapshot() {
+		counts[event.Type]++
+		assert.Equal(t, auctionId, event.Auction.Id)
+
+		if event.Type == auction_entity.EventPriceChanged {
+			assert.Equal(t, leadingBidder, event.Bid.BidderId)
+			assert.Equal(t, 20.0, event.Bid.Amount)
+		}
+	}
+	assert.Equal(t, 2, counts[auction_entity.EventBidAccepted])
+	assert.Equal(t, 1, counts[auction_entity.EventPriceChanged])
+}
@@ -0,0 +1,138 @@
+package bid_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"log"
+	"time"
+)
+
+type DeadLetterBidOutputDTO struct {
+	Bid      BidOutputDTO `json:"bid"`
+	Error    string       `json:"error"`
+	Attempts int          `json:"attempts"`
+	FailedAt time.Time    `json:"failed_at" time_format:"2006-01-02 15:04:05"`
+}
+
+// DeadLetterUseCase administra os lances que esgotaram as tentativas de
+// gravação. Não inicia workers, então também serve ao comando deadletter, que
+// roda fora do servidor; lá statuses e events ficam nil.
+type DeadLetterUseCase struct {
+	bidRepository        bid_entity.BidEntityRepository
+	deadLetterRepository bid_entity.DeadLetterRepositoryInterface
+	holds                *bidHolds
+	statuses             *bidStatusTracker
+	events               *bidEvents
+}
+
+type DeadLetterUseCaseInterface interface {
+	FindDeadLetters(
+		ctx context.Context) ([]DeadLetterBidOutputDTO, *internal_error.InternalError)
+
+	ReplayDeadLetter(
+		ctx context.Context, bidId string) *internal_error.InternalError
+
+	DiscardDeadLetter(
+		ctx context.Context, bidId string) *internal_error.InternalError
+}
+
+func NewDeadLetterUseCase(
+	bidRepository bid_entity.BidEntityRepository,
+	deadLetterRepository bid_entity.DeadLetterRepositoryInterface,
+	walletRepository wallet_entity.WalletRepositoryInterface) DeadLetterUseCaseInterface {
+	return &DeadLetterUseCase{
+		bidRepository:        bidRepository,
+		deadLetterRepository: deadLetterRepository,
+		holds: &bidHolds{
+			walletRepository: walletRepository,
+			bidRepository:    bidRepository,
+		},
+	}
+}
+
+func (du *DeadLetterUseCase) FindDeadLetters(
+	ctx context.Context) ([]DeadLetterBidOutputDTO, *internal_error.InternalError) {
+	deadLetters, err := du.deadLetterRepository.FindDeadLetters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadLetterOutputs []DeadLetterBidOutputDTO
+	for _, deadLetter := range deadLetters {
+		deadLetterOutputs = append(deadLetterOutputs, DeadLetterBidOutputDTO{
+			Bid: BidOutputDTO{
+				Id:        deadLetter.Bid.Id,
+				UserId:    deadLetter.Bid.UserId,
+				AuctionId: deadLetter.Bid.AuctionId,
+				Amount:    deadLetter.Bid.Amount,
+				Timestamp: deadLetter.Bid.Timestamp,
+			},
+			Error:    deadLetter.Error,
+			Attempts: deadLetter.Attempts,
+			FailedAt: deadLetter.FailedAt,
+		})
+	}
+
+	return deadLetterOutputs, nil
+}
+
+// ReplayDeadLetter tenta gravar novamente um lance da dead letter. Em caso de
+// sucesso ele sai da dead letter; senão o erro e o número de tentativas são atualizados.
+func (du *DeadLetterUseCase) ReplayDeadLetter(
+	ctx context.Context, bidId string) *internal_error.InternalError {
+	deadLetter, err := du.deadLetterRepository.FindDeadLetterByBidId(ctx, bidId)
+	if err != nil {
+		return err
+	}
+
+	// O saldo foi liberado quando o lance caiu na dead letter
+	if err := du.holds.place(ctx, deadLetter.Bid); err != nil {
+		return err
+	}
+
+	rejections, err := du.bidRepository.CreateBid(ctx, []bid_entity.Bid{deadLetter.Bid})
+	if err != nil {
+		du.holds.release(ctx, bidId)
+		deadLetter.Error = err.Error()
+		deadLetter.Attempts++
+		deadLetter.FailedAt = time.Now()
+
+		if saveErr := du.deadLetterRepository.SaveDeadLetter(ctx, *deadLetter); saveErr != nil {
+			return saveErr
+		}
+		return err
+	}
+
+	if du.statuses != nil {
+		du.statuses.markProcessed([]bid_entity.Bid{deadLetter.Bid}, rejections)
+	}
+	du.holds.afterPersist(ctx, []bid_entity.Bid{deadLetter.Bid}, rejections)
+	if du.events != nil {
+		du.events.afterPersist(ctx, []bid_entity.Bid{deadLetter.Bid}, rejections)
+	}
+	if err := du.deadLetterRepository.DeleteDeadLetter(ctx, bidId); err != nil {
+		return err
+	}
+
+	// Um lance que chegou atrasado não volta a ser válido; sai da dead letter como rejeitado
+	if len(rejections) > 0 {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Bid %s rejected: %s", bidId, rejections[0].Reason))
+	}
+
+	log.Printf("Dead letter bid %s replayed successfully", bidId)
+	return nil
+}
+
+func (du *DeadLetterUseCase) DiscardDeadLetter(
+	ctx context.Context, bidId string) *internal_error.InternalError {
+	if err := du.deadLetterRepository.DeleteDeadLetter(ctx, bidId); err != nil {
+		return err
+	}
+
+	log.Printf("Dead letter bid %s discarded", bidId)
+	return nil
+}
@@ -2,17 +2,45 @@ package bid_usecase
 
 import (
 	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"time"
 )
 
+// BidSummaryOutputDTO resume os lances válidos do leilão; sem lances os
+// valores e a data do último lance são nulos.
+type BidSummaryOutputDTO struct {
+	Count         int64      `json:"count"`
+	UniqueBidders int64      `json:"unique_bidders"`
+	HighBid       *float64   `json:"high_bid"`
+	OpeningBid    *float64   `json:"opening_bid"`
+	LastBidAt     *time.Time `json:"last_bid_at"`
+}
+
+type BidListOutputDTO struct {
+	Bids    []BidOutputDTO      `json:"bids"`
+	Summary BidSummaryOutputDTO `json:"summary"`
+	Page    int64               `json:"page"`
+	Limit   int64               `json:"limit"`
+	Total   int64               `json:"total"`
+}
+
 func (bu *BidUseCase) FindBidByAuctionId(
-	ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError) {
-	bidList, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId)
+	ctx context.Context,
+	auctionId string,
+	sort bid_entity.BidSort,
+	page, limit int64) (*BidListOutputDTO, *internal_error.InternalError) {
+	bidList, total, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId, sort, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := bu.BidRepository.FindBidSummary(ctx, auctionId)
 	if err != nil {
 		return nil, err
 	}
 
-	var bidOutputList []BidOutputDTO
+	bidOutputList := make([]BidOutputDTO, 0, len(bidList))
 	for _, bid := range bidList {
 		bidOutputList = append(bidOutputList, BidOutputDTO{
 			Id:        bid.Id,
@@ -20,10 +48,32 @@ func (bu *BidUseCase) FindBidByAuctionId(
 			AuctionId: bid.AuctionId,
 			Amount:    bid.Amount,
 			Timestamp: bid.Timestamp,
+			Voided:    bid.Voided,
 		})
 	}
 
-	return bidOutputList, nil
+	return &BidListOutputDTO{
+		Bids:    bidOutputList,
+		Summary: toBidSummaryOutputDTO(*summary),
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+	}, nil
+}
+
+func toBidSummaryOutputDTO(summary bid_entity.BidSummary) BidSummaryOutputDTO {
+	summaryOutput := BidSummaryOutputDTO{
+		Count:         summary.Count,
+		UniqueBidders: summary.UniqueBidders,
+	}
+
+	if summary.Count > 0 {
+		summaryOutput.HighBid = &summary.HighBid
+		summaryOutput.OpeningBid = &summary.OpeningBid
+		summaryOutput.LastBidAt = &summary.LastBidAt
+	}
+
+	return summaryOutput
 }
 
 func (bu *BidUseCase) FindWinningBidByAuctionId(
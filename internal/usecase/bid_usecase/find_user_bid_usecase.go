@@ -0,0 +1,68 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type UserBidOutputDTO struct {
+	BidOutputDTO
+	Outcome       bid_entity.BidOutcome        `json:"outcome"`
+	ProductName   string                       `json:"product_name"`
+	AuctionStatus auction_entity.AuctionStatus `json:"auction_status"`
+	Settlement    wallet_entity.HoldStatus     `json:"settlement,omitempty"`
+}
+
+type UserBidListOutputDTO struct {
+	Bids  []UserBidOutputDTO `json:"bids"`
+	Page  int64              `json:"page"`
+	Limit int64              `json:"limit"`
+	Total int64              `json:"total"`
+}
+
+func (bu *BidUseCase) FindBidsByUserId(
+	ctx context.Context,
+	userId string,
+	outcome bid_entity.BidOutcome,
+	page, limit int64) (*UserBidListOutputDTO, *internal_error.InternalError) {
+	userBids, total, err := bu.BidRepository.FindBidsByUserId(ctx, userId, outcome, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userBidOutputs := make([]UserBidOutputDTO, 0, len(userBids))
+	for _, userBid := range userBids {
+		userBidOutputs = append(userBidOutputs, UserBidOutputDTO{
+			BidOutputDTO: BidOutputDTO{
+				Id:        userBid.Bid.Id,
+				UserId:    userBid.Bid.UserId,
+				AuctionId: userBid.Bid.AuctionId,
+				Amount:    userBid.Bid.Amount,
+				Timestamp: userBid.Bid.Timestamp,
+				Voided:    userBid.Bid.Voided,
+			},
+			Outcome:       userBid.Outcome(),
+			ProductName:   userBid.Auction.ProductName,
+			AuctionStatus: userBid.Auction.Status,
+			Settlement:    userBid.Settlement,
+		})
+	}
+
+	return &UserBidListOutputDTO{
+		Bids:  userBidOutputs,
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}, nil
+}
+
+// FindWinsByUserId lista os lances vencedores do usuário em leilões encerrados.
+func (bu *BidUseCase) FindWinsByUserId(
+	ctx context.Context,
+	userId string,
+	page, limit int64) (*UserBidListOutputDTO, *internal_error.InternalError) {
+	return bu.FindBidsByUserId(ctx, userId, bid_entity.OutcomeWon, page, limit)
+}
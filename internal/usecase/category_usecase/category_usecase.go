@@ -0,0 +1,197 @@
+package category_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// CategoryInputDTO cria uma categoria. Sem slug ele é gerado a partir do nome;
+// sem parent_id a categoria é uma raiz.
+type CategoryInputDTO struct {
+	Name     string `json:"name" binding:"required,min=2,max=60"`
+	Slug     string `json:"slug" binding:"omitempty,max=60"`
+	ParentId string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+// CategoryUpdateInputDTO altera o nome ou desativa a categoria. O slug e o pai
+// não mudam, porque o caminho é gravado nos leilões.
+type CategoryUpdateInputDTO struct {
+	Name   *string `json:"name" binding:"omitempty,min=2,max=60"`
+	Active *bool   `json:"active"`
+}
+
+// CategoryOutputDTO é um nó da árvore de categorias; Path é o valor usado em
+// category ao criar e ao filtrar leilões.
+type CategoryOutputDTO struct {
+	Id        string              `json:"id"`
+	Slug      string              `json:"slug"`
+	Name      string              `json:"name"`
+	Path      string              `json:"path"`
+	ParentId  *string             `json:"parent_id"`
+	Active    bool                `json:"active"`
+	Children  []CategoryOutputDTO `json:"children"`
+	CreatedAt time.Time           `json:"created_at" time_format:"2006-01-02 15:04:05"`
+	UpdatedAt time.Time           `json:"updated_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type CategoryUseCase struct {
+	categoryRepository    category_entity.CategoryRepositoryInterface
+	adminActionRepository admin_entity.AdminActionRepositoryInterface
+}
+
+type CategoryUseCaseInterface interface {
+	// FindCategories devolve a árvore; sem includeInactive, categorias
+	// inativas e as suas subcategorias ficam de fora.
+	FindCategories(
+		ctx context.Context, includeInactive bool) ([]CategoryOutputDTO, *internal_error.InternalError)
+
+	CreateCategory(
+		ctx context.Context,
+		adminId string,
+		input CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	UpdateCategory(
+		ctx context.Context,
+		adminId, id string,
+		input CategoryUpdateInputDTO) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	DeleteCategory(
+		ctx context.Context, adminId, id string) *internal_error.InternalError
+}
+
+func NewCategoryUseCase(
+	categoryRepository category_entity.CategoryRepositoryInterface,
+	adminActionRepository admin_entity.AdminActionRepositoryInterface) CategoryUseCaseInterface {
+	return &CategoryUseCase{
+		categoryRepository:    categoryRepository,
+		adminActionRepository: adminActionRepository,
+	}
+}
+
+func (cu *CategoryUseCase) FindCategories(
+	ctx context.Context, includeInactive bool) ([]CategoryOutputDTO, *internal_error.InternalError) {
+	categories, err := cu.categoryRepository.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategoryTree(categories, "", includeInactive), nil
+}
+
+func (cu *CategoryUseCase) CreateCategory(
+	ctx context.Context,
+	adminId string,
+	input CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError) {
+	var parent *category_entity.Category
+	if input.ParentId != "" {
+		var err *internal_error.InternalError
+		if parent, err = cu.categoryRepository.FindCategoryById(ctx, input.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	category, err := category_entity.NewCategory(input.Name, input.Slug, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cu.categoryRepository.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if err := cu.record(ctx, adminId, admin_entity.CreateCategory, category.Id); err != nil {
+		return nil, err
+	}
+
+	output := toCategoryOutputDTO(*category)
+	return &output, nil
+}
+
+func (cu *CategoryUseCase) UpdateCategory(
+	ctx context.Context,
+	adminId, id string,
+	input CategoryUpdateInputDTO) (*CategoryOutputDTO, *internal_error.InternalError) {
+	category, err := cu.categoryRepository.FindCategoryById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		category.Name = *input.Name
+	}
+
+	if input.Active != nil {
+		category.Active = *input.Active
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+	category.UpdatedAt = time.Now()
+
+	if err := cu.categoryRepository.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	if err := cu.record(ctx, adminId, admin_entity.UpdateCategory, category.Id); err != nil {
+		return nil, err
+	}
+
+	output := toCategoryOutputDTO(*category)
+	return &output, nil
+}
+
+// DeleteCategory só remove categorias sem subcategorias nem leilões; para
+// tirar uma categoria em uso de circulação, desative-a.
+func (cu *CategoryUseCase) DeleteCategory(
+	ctx context.Context, adminId, id string) *internal_error.InternalError {
+	if err := cu.categoryRepository.DeleteCategory(ctx, id); err != nil {
+		return err
+	}
+
+	return cu.record(ctx, adminId, admin_entity.DeleteCategory, id)
+}
+
+func (cu *CategoryUseCase) record(
+	ctx context.Context, adminId string, action admin_entity.ActionType, id string) *internal_error.InternalError {
+	return cu.adminActionRepository.SaveAdminAction(ctx, admin_entity.NewAdminAction(
+		adminId, action, admin_entity.CategoryTarget, id, ""))
+}
+
+// toCategoryTree monta os filhos de parentId a partir da lista plana.
+func toCategoryTree(
+	categories []category_entity.Category, parentId string, includeInactive bool) []CategoryOutputDTO {
+	tree := []CategoryOutputDTO{}
+	for _, category := range categories {
+		if category.ParentId != parentId || (!category.Active && !includeInactive) {
+			continue
+		}
+
+		output := toCategoryOutputDTO(category)
+		output.Children = toCategoryTree(categories, category.Id, includeInactive)
+		tree = append(tree, output)
+	}
+
+	return tree
+}
+
+func toCategoryOutputDTO(category category_entity.Category) CategoryOutputDTO {
+	output := CategoryOutputDTO{
+		Id:        category.Id,
+		Slug:      category.Slug,
+		Name:      category.Name,
+		Path:      category.Path,
+		Active:    category.Active,
+		Children:  []CategoryOutputDTO{},
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+	if category.ParentId != "" {
+		output.ParentId = &category.ParentId
+	}
+
+	return output
+}
@@ -0,0 +1,142 @@
+package category_usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/admin_entity"
+	"fullcycle-auction_go/internal/entity/admin_entity/admintest"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+)
+
+// fakeCategoryRepository guarda as categorias em memória, na ordem de criação
+type fakeCategoryRepository struct {
+	categories []category_entity.Category
+}
+
+func (f *fakeCategoryRepository) CreateCategory(ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	for _, existing := range f.categories {
+		if existing.Path == category.Path {
+			return internal_error.NewConflictError("Category already exists")
+		}
+	}
+	f.categories = append(f.categories, *category)
+	return nil
+}
+
+func (f *fakeCategoryRepository) UpdateCategory(ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	for i := range f.categories {
+		if f.categories[i].Id == category.Id {
+			f.categories[i] = *category
+			return nil
+		}
+	}
+	return internal_error.NewNotFoundError("Category not found")
+}
+
+func (f *fakeCategoryRepository) DeleteCategory(ctx context.Context, id string) *internal_error.InternalError {
+	return nil
+}
+
+func (f *fakeCategoryRepository) AddAuction(ctx context.Context, id string) *internal_error.InternalError {
+	return nil
+}
+
+func (f *fakeCategoryRepository) RemoveAuction(ctx context.Context, id string) *internal_error.InternalError {
+	return nil
+}
+
+func (f *fakeCategoryRepository) FindCategoryById(ctx context.Context, id string) (*category_entity.Category, *internal_error.InternalError) {
+	for _, category := range f.categories {
+		if category.Id == id {
+			return &category, nil
+		}
+	}
+	return nil, internal_error.NewNotFoundError("Category not found")
+}
+
+func (f *fakeCategoryRepository) FindCategoryByPath(ctx context.Context, path string) (*category_entity.Category, *internal_error.InternalError) {
+	return nil, internal_error.NewNotFoundError("Category not found")
+}
+
+func (f *fakeCategoryRepository) FindCategories(ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	return f.categories, nil
+}
+
+func TestCreateCategory_NestsUnderParentAndIsAudited(t *testing.T) {
+	categories := &fakeCategoryRepository{}
+	adminActions := &admintest.RecordingAdminActionRepository{}
+	categoryUC := category_usecase.NewCategoryUseCase(categories, adminActions)
+
+	parent, err := categoryUC.CreateCategory(context.Background(), "admin-1",
+		category_usecase.CategoryInputDTO{Name: "Eletrônicos"})
+	assert.Nil(t, err)
+	assert.Nil(t, parent.ParentId)
+
+	child, err := categoryUC.CreateCategory(context.Background(), "admin-1",
+		category_usecase.CategoryInputDTO{Name: "Celulares", ParentId: parent.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "eletronicos/celulares", child.Path)
+	assert.Equal(t, parent.Id, *child.ParentId)
+
+	_, err = categoryUC.CreateCategory(context.Background(), "admin-1",
+		category_usecase.CategoryInputDTO{Name: "Celulares", ParentId: parent.Id})
+	assert.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+
+	_, err = categoryUC.CreateCategory(context.Background(), "admin-1",
+		category_usecase.CategoryInputDTO{Name: "Tablets", ParentId: "missing"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+
+	assert.Len(t, adminActions.Actions, 2)
+	assert.Equal(t, admin_entity.CreateCategory, adminActions.Actions[1].Action)
+	assert.Equal(t, admin_entity.CategoryTarget, adminActions.Actions[1].TargetType)
+	assert.Equal(t, child.Id, adminActions.Actions[1].TargetId)
+}
+
+func TestFindCategories_HidesInactiveSubtrees(t *testing.T) {
+	now := time.Now()
+	categories := &fakeCategoryRepository{categories: []category_entity.Category{
+		{Id: "c1", Slug: "electronics", Name: "Electronics", Path: "electronics", Active: true, CreatedAt: now},
+		{Id: "c2", Slug: "phones", Name: "Phones", ParentId: "c1", Path: "electronics/phones", Active: true, CreatedAt: now},
+		{Id: "c3", Slug: "toys", Name: "Toys", Path: "toys", CreatedAt: now},
+		{Id: "c4", Slug: "dolls", Name: "Dolls", ParentId: "c3", Path: "toys/dolls", Active: true, CreatedAt: now},
+	}}
+	categoryUC := category_usecase.NewCategoryUseCase(categories, &admintest.RecordingAdminActionRepository{})
+
+	active, err := categoryUC.FindCategories(context.Background(), false)
+	assert.Nil(t, err)
+	assert.Len(t, active, 1)
+	assert.Equal(t, "electronics", active[0].Path)
+	assert.Equal(t, "electronics/phones", active[0].Children[0].Path)
+	assert.Empty(t, active[0].Children[0].Children)
+
+	all, err := categoryUC.FindCategories(context.Background(), true)
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+	assert.False(t, all[1].Active)
+	assert.Equal(t, "toys/dolls", all[1].Children[0].Path)
+}
+
+func TestUpdateCategory_DeactivatesAndRenames(t *testing.T) {
+	categories := &fakeCategoryRepository{categories: []category_entity.Category{
+		{Id: "c1", Slug: "electronics", Name: "Electronics", Path: "electronics", Active: true},
+	}}
+	categoryUC := category_usecase.NewCategoryUseCase(categories, &admintest.RecordingAdminActionRepository{})
+
+	name, active := "Eletrônicos", false
+	output, err := categoryUC.UpdateCategory(context.Background(), "admin-1", "c1",
+		category_usecase.CategoryUpdateInputDTO{Name: &name, Active: &active})
+	assert.Nil(t, err)
+	assert.Equal(t, "Eletrônicos", output.Name)
+	assert.False(t, output.Active)
+	// O slug e o caminho não mudam com o nome
+	assert.Equal(t, "electronics", output.Path)
+	assert.False(t, categories.categories[0].Active)
+}
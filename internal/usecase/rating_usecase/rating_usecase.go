@@ -0,0 +1,155 @@
+package rating_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/rating_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type RatingInputDTO struct {
+	Score   int    `json:"score" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+type RatingOutputDTO struct {
+	Id        string                  `json:"id"`
+	AuctionId string                  `json:"auction_id"`
+	RaterId   string                  `json:"rater_id"`
+	RateeId   string                  `json:"ratee_id"`
+	RaterRole rating_entity.RaterRole `json:"rater_role"`
+	Score     int                     `json:"score"`
+	Comment   string                  `json:"comment,omitempty"`
+	CreatedAt time.Time               `json:"created_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type RatingListOutputDTO struct {
+	Ratings []RatingOutputDTO `json:"ratings"`
+	Page    int64             `json:"page"`
+	Limit   int64             `json:"limit"`
+	Total   int64             `json:"total"`
+}
+
+type RatingUseCase struct {
+	ratingRepository  rating_entity.RatingRepositoryInterface
+	auctionRepository auction_entity.AuctionRepositoryInterface
+	bidRepository     bid_entity.BidEntityRepository
+}
+
+type RatingUseCaseInterface interface {
+	RateAuction(
+		ctx context.Context,
+		auctionId, raterId string,
+		input RatingInputDTO) (*RatingOutputDTO, *internal_error.InternalError)
+
+	FindRatingsByUserId(
+		ctx context.Context,
+		userId string,
+		page, limit int64) (*RatingListOutputDTO, *internal_error.InternalError)
+}
+
+func NewRatingUseCase(
+	ratingRepository rating_entity.RatingRepositoryInterface,
+	auctionRepository auction_entity.AuctionRepositoryInterface,
+	bidRepository bid_entity.BidEntityRepository) RatingUseCaseInterface {
+	return &RatingUseCase{
+		ratingRepository:  ratingRepository,
+		auctionRepository: auctionRepository,
+		bidRepository:     bidRepository,
+	}
+}
+
+// RateAuction registra a avaliação de um lado da venda sobre o outro. Só o
+// vendedor e o comprador (dono do lance vencedor) de um leilão encerrado
+// podem avaliar, cada um uma única vez.
+func (ru *RatingUseCase) RateAuction(
+	ctx context.Context,
+	auctionId, raterId string,
+	input RatingInputDTO) (*RatingOutputDTO, *internal_error.InternalError) {
+	auction, err := ru.auctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.Status != auction_entity.Completed || auction.SellerId == "" {
+		return nil, internal_error.NewConflictError("Only settled auctions can be rated").
+			WithCode(internal_error.CodeAuctionNotSettled)
+	}
+
+	winningBid, err := ru.bidRepository.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewConflictError("Only settled auctions can be rated").
+				WithCode(internal_error.CodeAuctionNotSettled)
+		}
+		return nil, err
+	}
+
+	// Abaixo da reserva o leilão terminou sem venda, então não há comprador
+	if winningBid.Amount < auction.ReservePrice {
+		return nil, internal_error.NewConflictError("Only settled auctions can be rated").
+			WithCode(internal_error.CodeAuctionNotSettled)
+	}
+
+	var raterRole rating_entity.RaterRole
+	var rateeId string
+	switch raterId {
+	case auction.SellerId:
+		raterRole, rateeId = rating_entity.Seller, winningBid.UserId
+	case winningBid.UserId:
+		raterRole, rateeId = rating_entity.Buyer, auction.SellerId
+	default:
+		return nil, internal_error.NewForbiddenError("Only the buyer and the seller can rate this auction").
+			WithCode(internal_error.CodeNotAuctionParty)
+	}
+
+	rating, err := rating_entity.CreateRating(
+		auctionId, raterId, rateeId, raterRole, input.Score, input.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ru.ratingRepository.CreateRating(ctx, rating); err != nil {
+		return nil, err
+	}
+
+	ratingOutputDTO := toRatingOutputDTO(*rating)
+	return &ratingOutputDTO, nil
+}
+
+func (ru *RatingUseCase) FindRatingsByUserId(
+	ctx context.Context,
+	userId string,
+	page, limit int64) (*RatingListOutputDTO, *internal_error.InternalError) {
+	ratings, total, err := ru.ratingRepository.FindRatingsByUserId(ctx, userId, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ratingOutputs := make([]RatingOutputDTO, 0, len(ratings))
+	for _, rating := range ratings {
+		ratingOutputs = append(ratingOutputs, toRatingOutputDTO(rating))
+	}
+
+	return &RatingListOutputDTO{
+		Ratings: ratingOutputs,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+	}, nil
+}
+
+func toRatingOutputDTO(rating rating_entity.Rating) RatingOutputDTO {
+	return RatingOutputDTO{
+		Id:        rating.Id,
+		AuctionId: rating.AuctionId,
+		RaterId:   rating.RaterId,
+		RateeId:   rating.RateeId,
+		RaterRole: rating.RaterRole,
+		Score:     rating.Score,
+		Comment:   rating.Comment,
+		CreatedAt: rating.CreatedAt,
+	}
+}
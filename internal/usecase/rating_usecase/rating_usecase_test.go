@@ -0,0 +1,156 @@
+package rating_usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/rating_entity"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/rating_usecase"
+)
+
+type fakeAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auctions map[string]*auction_entity.Auction
+}
+
+func (f *fakeAuctionRepository) FindAuctionById(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, ok := f.auctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("auction not found")
+	}
+	return auction, nil
+}
+
+type fakeBidRepository struct {
+	bid_entity.BidEntityRepository
+	winners map[string]*bid_entity.Bid
+}
+
+func (f *fakeBidRepository) FindWinningBidByAuctionId(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	bid, ok := f.winners[auctionId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("bid not found")
+	}
+	return bid, nil
+}
+
+// fakeRatingRepository aplica em memória a unicidade por leilão e avaliador
+type fakeRatingRepository struct {
+	rating_entity.RatingRepositoryInterface
+	ratings []rating_entity.Rating
+}
+
+func (f *fakeRatingRepository) CreateRating(ctx context.Context, rating *rating_entity.Rating) *internal_error.InternalError {
+	for _, existing := range f.ratings {
+		if existing.AuctionId == rating.AuctionId && existing.RaterId == rating.RaterId {
+			return internal_error.NewConflictError("You have already rated this auction")
+		}
+	}
+	f.ratings = append(f.ratings, *rating)
+	return nil
+}
+
+// newRatingUseCase monta o caso de uso sobre os mesmos leilões em todos os
+// testes. Com bids nil, os vencedores vêm da memória.
+func newRatingUseCase(bids bid_entity.BidEntityRepository) (rating_usecase.RatingUseCaseInterface, *fakeRatingRepository) {
+	auctions := &fakeAuctionRepository{auctions: map[string]*auction_entity.Auction{
+		"settled": {Id: "settled", SellerId: "seller-1", Status: auction_entity.Completed},
+		"active":  {Id: "active", SellerId: "seller-1", Status: auction_entity.Active},
+		"unsold":  {Id: "unsold", SellerId: "seller-1", Status: auction_entity.Completed},
+		"reserve": {Id: "reserve", SellerId: "seller-1", Status: auction_entity.Completed, ReservePrice: 80},
+	}}
+	if bids == nil {
+		bids = &fakeBidRepository{winners: map[string]*bid_entity.Bid{
+			"settled": {Id: "b1", AuctionId: "settled", UserId: "buyer-1", Amount: 100},
+			"active":  {Id: "b2", AuctionId: "active", UserId: "buyer-1", Amount: 50},
+			"reserve": {Id: "b3", AuctionId: "reserve", UserId: "buyer-1", Amount: 70},
+		}}
+	}
+	ratings := &fakeRatingRepository{}
+
+	return rating_usecase.NewRatingUseCase(ratings, auctions, bids), ratings
+}
+
+func TestRateAuction_BuyerAndSellerRateEachOtherOnce(t *testing.T) {
+	ratingUC, ratings := newRatingUseCase(nil)
+	input := rating_usecase.RatingInputDTO{Score: 5, Comment: "Tudo certo"}
+
+	rating, err := ratingUC.RateAuction(context.Background(), "settled", "buyer-1", input)
+	assert.Nil(t, err)
+	assert.Equal(t, "seller-1", rating.RateeId)
+	assert.Equal(t, rating_entity.Buyer, rating.RaterRole)
+
+	rating, err = ratingUC.RateAuction(context.Background(), "settled", "seller-1", input)
+	assert.Nil(t, err)
+	assert.Equal(t, "buyer-1", rating.RateeId)
+	assert.Equal(t, rating_entity.Seller, rating.RaterRole)
+
+	_, err = ratingUC.RateAuction(context.Background(), "settled", "buyer-1", input)
+	assert.Equal(t, "conflict", err.Err)
+
+	assert.Len(t, ratings.ratings, 2)
+}
+
+func TestRateAuction_RequiresSettledAuctionAndParticipant(t *testing.T) {
+	ratingUC, ratings := newRatingUseCase(nil)
+	input := rating_usecase.RatingInputDTO{Score: 4}
+
+	_, err := ratingUC.RateAuction(context.Background(), "active", "buyer-1", input)
+	assert.Equal(t, "conflict", err.Err)
+
+	// Leilão encerrado sem lances não tem comprador
+	_, err = ratingUC.RateAuction(context.Background(), "unsold", "seller-1", input)
+	assert.Equal(t, "conflict", err.Err)
+
+	// Nem o que terminou abaixo do preço de reserva
+	_, err = ratingUC.RateAuction(context.Background(), "reserve", "buyer-1", input)
+	assert.Equal(t, "conflict", err.Err)
+	assert.Equal(t, internal_error.CodeAuctionNotSettled, err.Code)
+
+	_, err = ratingUC.RateAuction(context.Background(), "settled", "stranger", input)
+	assert.Equal(t, "forbidden", err.Err)
+
+	assert.Empty(t, ratings.ratings)
+}
+
+// A regra de leilão sem vencedor depende do not_found devolvido pelo
+// repositório de lances, então aqui ele responde pelo driver com mtest.
+func TestRateAuction_UnsoldAuctionWithBidRepository(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	input := rating_usecase.RatingInputDTO{Score: 4}
+
+	mt.Run("winner", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: "b1"},
+			{Key: "user_id", Value: "buyer-1"},
+			{Key: "auction_id", Value: "settled"},
+			{Key: "amount", Value: 100.0},
+			{Key: "timestamp", Value: int64(1)},
+		}))
+		ratingUC, _ := newRatingUseCase(bid.NewBidRepository(mt.DB, nil))
+
+		rating, err := ratingUC.RateAuction(context.Background(), "settled", "seller-1", input)
+		assert.Nil(t, err)
+		assert.Equal(t, "buyer-1", rating.RateeId)
+	})
+
+	mt.Run("only voided bids", func(mt *mtest.T) {
+		// Só com lances anulados o leilão não foi vendido: conflito, não erro interno
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.bids", mtest.FirstBatch))
+		ratingUC, _ := newRatingUseCase(bid.NewBidRepository(mt.DB, nil))
+
+		_, err := ratingUC.RateAuction(context.Background(), "unsold", "seller-1", input)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, "conflict", err.Err)
+			assert.Equal(t, internal_error.CodeAuctionNotSettled, err.Code)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package restriction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/restriction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type BlockInputDTO struct {
+	UserId string `json:"user_id" binding:"required,uuid"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type SellerBlockOutputDTO struct {
+	BidderId  string    `json:"bidder_id"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type RestrictionUseCase struct {
+	restrictionRepository restriction_entity.RestrictionRepositoryInterface
+	userRepository        user_entity.UserRepositoryInterface
+}
+
+type RestrictionUseCaseInterface interface {
+	BlockBidder(
+		ctx context.Context,
+		sellerId string,
+		input BlockInputDTO) *internal_error.InternalError
+
+	UnblockBidder(
+		ctx context.Context, sellerId, bidderId string) *internal_error.InternalError
+
+	FindBlockedBidders(
+		ctx context.Context, sellerId string) ([]SellerBlockOutputDTO, *internal_error.InternalError)
+}
+
+func NewRestrictionUseCase(
+	restrictionRepository restriction_entity.RestrictionRepositoryInterface,
+	userRepository user_entity.UserRepositoryInterface) RestrictionUseCaseInterface {
+	return &RestrictionUseCase{
+		restrictionRepository: restrictionRepository,
+		userRepository:        userRepository,
+	}
+}
+
+// BlockBidder impede o licitante de dar lances em qualquer leilão do vendedor.
+func (ru *RestrictionUseCase) BlockBidder(
+	ctx context.Context,
+	sellerId string,
+	input BlockInputDTO) *internal_error.InternalError {
+	block, err := restriction_entity.NewSellerBlock(sellerId, input.UserId, input.Reason)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ru.userRepository.FindUserById(ctx, input.UserId); err != nil {
+		return err
+	}
+
+	return ru.restrictionRepository.BlockBidder(ctx, block)
+}
+
+func (ru *RestrictionUseCase) UnblockBidder(
+	ctx context.Context, sellerId, bidderId string) *internal_error.InternalError {
+	return ru.restrictionRepository.UnblockBidder(ctx, sellerId, bidderId)
+}
+
+func (ru *RestrictionUseCase) FindBlockedBidders(
+	ctx context.Context, sellerId string) ([]SellerBlockOutputDTO, *internal_error.InternalError) {
+	blocks, err := ru.restrictionRepository.FindBlockedBidders(ctx, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	blockOutputs := make([]SellerBlockOutputDTO, 0, len(blocks))
+	for _, block := range blocks {
+		blockOutputs = append(blockOutputs, SellerBlockOutputDTO{
+			BidderId:  block.BidderId,
+			Reason:    block.Reason,
+			CreatedAt: block.CreatedAt,
+		})
+	}
+
+	return blockOutputs, nil
+}
@@ -0,0 +1,59 @@
+package user_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type UserInputDTO struct {
+	Name  string `json:"name" binding:"required,min=2,max=100"`
+	Email string `json:"email" binding:"required,email"`
+	// O papel de administrador só é concedido por outro administrador
+	Roles []user_entity.Role `json:"roles" binding:"omitempty,dive,oneof=bidder seller"`
+}
+
+type UserUpdateInputDTO struct {
+	Name  *string `json:"name" binding:"omitempty,min=2,max=100"`
+	Email *string `json:"email" binding:"omitempty,email"`
+}
+
+func (u *UserUseCase) CreateUser(
+	ctx context.Context, userInput UserInputDTO) (*UserOutputDTO, *internal_error.InternalError) {
+	userEntity, err := user_entity.CreateUser(
+		userInput.Name, userInput.Email, userInput.Roles...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.UserRepository.CreateUser(ctx, userEntity); err != nil {
+		return nil, err
+	}
+
+	return toUserOutputDTO(userEntity), nil
+}
+
+func (u *UserUseCase) UpdateUser(
+	ctx context.Context,
+	id string,
+	userInput UserUpdateInputDTO) (*UserOutputDTO, *internal_error.InternalError) {
+	userEntity, err := u.UserRepository.FindUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := userEntity.Update(userInput.Name, userInput.Email); err != nil {
+		return nil, err
+	}
+
+	if err := u.UserRepository.UpdateUser(ctx, userEntity); err != nil {
+		return nil, err
+	}
+
+	return toUserOutputDTO(userEntity), nil
+}
+
+func (u *UserUseCase) DeactivateUser(
+	ctx context.Context, id string) *internal_error.InternalError {
+	return u.UserRepository.UpdateUserStatus(ctx, id, user_entity.Inactive)
+}
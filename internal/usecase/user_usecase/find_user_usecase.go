@@ -2,29 +2,74 @@ package user_usecase
 
 import (
 	"context"
+	"fullcycle-auction_go/internal/entity/rating_entity"
 	"fullcycle-auction_go/internal/entity/user_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"time"
 )
 
-func NewUserUseCase(userRepository user_entity.UserRepositoryInterface) UserUseCaseInterface {
+func NewUserUseCase(
+	userRepository user_entity.UserRepositoryInterface,
+	ratingRepository rating_entity.RatingRepositoryInterface) UserUseCaseInterface {
 	return &UserUseCase{
-		userRepository,
+		UserRepository:   userRepository,
+		ratingRepository: ratingRepository,
 	}
 }
 
 type UserUseCase struct {
-	UserRepository user_entity.UserRepositoryInterface
+	UserRepository   user_entity.UserRepositoryInterface
+	ratingRepository rating_entity.RatingRepositoryInterface
 }
 
+// UserOutputDTO só traz o email para o próprio usuário e para os
+// administradores; o perfil público o omite.
 type UserOutputDTO struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Email     string                 `json:"email,omitempty"`
+	Status    user_entity.UserStatus `json:"status"`
+	Roles     []user_entity.Role     `json:"roles"`
+	CreatedAt time.Time              `json:"created_at" time_format:"2006-01-02 15:04:05"`
+
+	// Reputation só é preenchida na consulta de um usuário
+	Reputation *ReputationOutputDTO `json:"reputation,omitempty"`
+}
+
+type ReputationOutputDTO struct {
+	AverageScore       float64 `json:"average_score"`
+	RatingCount        int64   `json:"rating_count"`
+	CompletedSales     int64   `json:"completed_sales"`
+	CompletedPurchases int64   `json:"completed_purchases"`
+}
+
+type UserListOutputDTO struct {
+	Users []UserOutputDTO `json:"users"`
+	Page  int64           `json:"page"`
+	Limit int64           `json:"limit"`
+	Total int64           `json:"total"`
 }
 
 type UserUseCaseInterface interface {
+	CreateUser(
+		ctx context.Context,
+		userInput UserInputDTO) (*UserOutputDTO, *internal_error.InternalError)
+
+	UpdateUser(
+		ctx context.Context,
+		id string,
+		userInput UserUpdateInputDTO) (*UserOutputDTO, *internal_error.InternalError)
+
+	DeactivateUser(
+		ctx context.Context, id string) *internal_error.InternalError
+
 	FindUserById(
 		ctx context.Context,
 		id string) (*UserOutputDTO, *internal_error.InternalError)
+
+	FindUsers(
+		ctx context.Context,
+		page, limit int64) (*UserListOutputDTO, *internal_error.InternalError)
 }
 
 func (u *UserUseCase) FindUserById(
@@ -34,8 +79,51 @@ func (u *UserUseCase) FindUserById(
 		return nil, err
 	}
 
-	return &UserOutputDTO{
-		Id:   userEntity.Id,
-		Name: userEntity.Name,
+	reputation, err := u.ratingRepository.FindReputation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// O perfil é público, então não expõe o email
+	userOutputDTO := toUserOutputDTO(userEntity)
+	userOutputDTO.Email = ""
+	userOutputDTO.Reputation = &ReputationOutputDTO{
+		AverageScore:       reputation.AverageScore,
+		RatingCount:        reputation.RatingCount,
+		CompletedSales:     reputation.CompletedSales,
+		CompletedPurchases: reputation.CompletedPurchases,
+	}
+
+	return userOutputDTO, nil
+}
+
+func (u *UserUseCase) FindUsers(
+	ctx context.Context, page, limit int64) (*UserListOutputDTO, *internal_error.InternalError) {
+	users, total, err := u.UserRepository.FindUsers(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userOutputs := []UserOutputDTO{}
+	for _, userEntity := range users {
+		userOutputs = append(userOutputs, *toUserOutputDTO(&userEntity))
+	}
+
+	return &UserListOutputDTO{
+		Users: userOutputs,
+		Page:  page,
+		Limit: limit,
+		Total: total,
 	}, nil
 }
+
+func toUserOutputDTO(userEntity *user_entity.User) *UserOutputDTO {
+	return &UserOutputDTO{
+		Id:        userEntity.Id,
+		Name:      userEntity.Name,
+		Email:     userEntity.Email,
+		Status:    userEntity.Status,
+		Roles:     userEntity.Roles,
+		CreatedAt: userEntity.CreatedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package user_usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/rating_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+)
+
+// fakeUserRepository guarda os usuários em memória
+type fakeUserRepository struct {
+	user_entity.UserRepositoryInterface
+	users map[string]user_entity.User
+}
+
+func (f *fakeUserRepository) FindUserById(ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	user, ok := f.users[userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("User not found")
+	}
+	return &user, nil
+}
+
+func (f *fakeUserRepository) UpdateUser(ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	f.users[userEntity.Id] = *userEntity
+	return nil
+}
+
+type fakeRatingRepository struct {
+	rating_entity.RatingRepositoryInterface
+}
+
+func (f *fakeRatingRepository) FindReputation(ctx context.Context, userId string) (*rating_entity.Reputation, *internal_error.InternalError) {
+	return &rating_entity.Reputation{RatingCount: 2, AverageScore: 4.5}, nil
+}
+
+func newUseCase() (user_usecase.UserUseCaseInterface, *fakeUserRepository) {
+	users := &fakeUserRepository{users: map[string]user_entity.User{
+		"u1": {Id: "u1", Name: "Maria Silva", Email: "maria@example.com",
+			Status: user_entity.Active, Roles: []user_entity.Role{user_entity.RoleBidder}},
+		// Usuário cadastrado antes do email ser obrigatório
+		"legacy": {Id: "legacy", Name: "João", Status: user_entity.Active,
+			Roles: []user_entity.Role{user_entity.RoleBidder, user_entity.RoleSeller}},
+	}}
+	return user_usecase.NewUserUseCase(users, &fakeRatingRepository{}), users
+}
+
+func TestUpdateUser_TrimsNameAndNormalizesEmail(t *testing.T) {
+	userUC, users := newUseCase()
+	name, email := "  Maria Souza  ", " Maria.Souza@Example.com "
+
+	user, err := userUC.UpdateUser(context.Background(), "u1", user_usecase.UserUpdateInputDTO{
+		Name: &name, Email: &email,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Maria Souza", user.Name)
+	assert.Equal(t, "maria.souza@example.com", user.Email)
+	assert.Equal(t, "Maria Souza", users.users["u1"].Name)
+
+	// Espaços não contam para o tamanho mínimo do nome
+	blank := "  M  "
+	_, err = userUC.UpdateUser(context.Background(), "u1", user_usecase.UserUpdateInputDTO{Name: &blank})
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, "Maria Souza", users.users["u1"].Name)
+}
+
+func TestUpdateUser_LegacyUserWithoutEmail(t *testing.T) {
+	userUC, users := newUseCase()
+
+	name := "João Pereira"
+	user, err := userUC.UpdateUser(context.Background(), "legacy", user_usecase.UserUpdateInputDTO{Name: &name})
+	assert.Nil(t, err)
+	assert.Equal(t, "João Pereira", user.Name)
+	assert.Empty(t, users.users["legacy"].Email)
+
+	// Quem informa um email precisa informar um válido
+	invalid := "joao.example.com"
+	_, err = userUC.UpdateUser(context.Background(), "legacy", user_usecase.UserUpdateInputDTO{Email: &invalid})
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+
+	email := "joao@example.com"
+	user, err = userUC.UpdateUser(context.Background(), "legacy", user_usecase.UserUpdateInputDTO{Email: &email})
+	assert.Nil(t, err)
+	assert.Equal(t, "joao@example.com", user.Email)
+}
+
+func TestUpdateUser_UnknownUser(t *testing.T) {
+	userUC, _ := newUseCase()
+	name := "Maria Souza"
+
+	_, err := userUC.UpdateUser(context.Background(), "missing", user_usecase.UserUpdateInputDTO{Name: &name})
+	assert.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+}
+
+func TestFindUserById_HidesTheEmailOfThePublicProfile(t *testing.T) {
+	userUC, _ := newUseCase()
+
+	user, err := userUC.FindUserById(context.Background(), "u1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Maria Silva", user.Name)
+	assert.Empty(t, user.Email)
+	assert.Equal(t, int64(2), user.Reputation.RatingCount)
+
+	raw, _ := json.Marshal(user)
+	assert.NotContains(t, string(raw), "email")
+}
@@ -0,0 +1,147 @@
+package wallet_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type WalletAmountInputDTO struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+type WalletOutputDTO struct {
+	UserId    string    `json:"user_id"`
+	Balance   float64   `json:"balance"`
+	Held      float64   `json:"held"`
+	Available float64   `json:"available"`
+	UpdatedAt time.Time `json:"updated_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type LedgerEntryOutputDTO struct {
+	Id        string                        `json:"id"`
+	Type      wallet_entity.LedgerEntryType `json:"type"`
+	Amount    float64                       `json:"amount"`
+	Reference string                        `json:"reference,omitempty"`
+	CreatedAt time.Time                     `json:"created_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type LedgerListOutputDTO struct {
+	Entries []LedgerEntryOutputDTO `json:"entries"`
+	Page    int64                  `json:"page"`
+	Limit   int64                  `json:"limit"`
+	Total   int64                  `json:"total"`
+}
+
+type WalletUseCase struct {
+	walletRepository wallet_entity.WalletRepositoryInterface
+}
+
+type WalletUseCaseInterface interface {
+	FindWallet(
+		ctx context.Context, userId string) (*WalletOutputDTO, *internal_error.InternalError)
+
+	Deposit(
+		ctx context.Context,
+		userId string,
+		input WalletAmountInputDTO) (*WalletOutputDTO, *internal_error.InternalError)
+
+	Withdraw(
+		ctx context.Context,
+		userId string,
+		input WalletAmountInputDTO) (*WalletOutputDTO, *internal_error.InternalError)
+
+	FindLedger(
+		ctx context.Context,
+		userId string,
+		page, limit int64) (*LedgerListOutputDTO, *internal_error.InternalError)
+}
+
+func NewWalletUseCase(walletRepository wallet_entity.WalletRepositoryInterface) WalletUseCaseInterface {
+	return &WalletUseCase{
+		walletRepository: walletRepository,
+	}
+}
+
+func (wu *WalletUseCase) FindWallet(
+	ctx context.Context, userId string) (*WalletOutputDTO, *internal_error.InternalError) {
+	wallet, err := wu.walletRepository.FindWallet(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletOutputDTO(wallet), nil
+}
+
+func (wu *WalletUseCase) Deposit(
+	ctx context.Context,
+	userId string,
+	input WalletAmountInputDTO) (*WalletOutputDTO, *internal_error.InternalError) {
+	if err := wallet_entity.ValidateAmount(input.Amount); err != nil {
+		return nil, err
+	}
+
+	wallet, err := wu.walletRepository.Deposit(ctx, userId, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletOutputDTO(wallet), nil
+}
+
+// Withdraw só retira do saldo livre; valores reservados por lances ficam presos
+// até o lance ser superado ou o leilão ser liquidado.
+func (wu *WalletUseCase) Withdraw(
+	ctx context.Context,
+	userId string,
+	input WalletAmountInputDTO) (*WalletOutputDTO, *internal_error.InternalError) {
+	if err := wallet_entity.ValidateAmount(input.Amount); err != nil {
+		return nil, err
+	}
+
+	wallet, err := wu.walletRepository.Withdraw(ctx, userId, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletOutputDTO(wallet), nil
+}
+
+func (wu *WalletUseCase) FindLedger(
+	ctx context.Context,
+	userId string,
+	page, limit int64) (*LedgerListOutputDTO, *internal_error.InternalError) {
+	entries, total, err := wu.walletRepository.FindLedger(ctx, userId, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entryOutputs := make([]LedgerEntryOutputDTO, 0, len(entries))
+	for _, entry := range entries {
+		entryOutputs = append(entryOutputs, LedgerEntryOutputDTO{
+			Id:        entry.Id,
+			Type:      entry.Type,
+			Amount:    entry.Amount,
+			Reference: entry.Reference,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+
+	return &LedgerListOutputDTO{
+		Entries: entryOutputs,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+	}, nil
+}
+
+func toWalletOutputDTO(wallet *wallet_entity.Wallet) *WalletOutputDTO {
+	return &WalletOutputDTO{
+		UserId:    wallet.UserId,
+		Balance:   wallet.Balance,
+		Held:      wallet.Held,
+		Available: wallet.Available(),
+		UpdatedAt: wallet.UpdatedAt,
+	}
+}
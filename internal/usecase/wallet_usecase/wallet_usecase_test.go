@@ -0,0 +1,67 @@
+package wallet_usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/wallet_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/wallet_usecase"
+)
+
+// fakeWalletRepository mantém uma única carteira em memória
+type fakeWalletRepository struct {
+	wallet_entity.WalletRepositoryInterface
+	wallet wallet_entity.Wallet
+}
+
+func (f *fakeWalletRepository) FindWallet(ctx context.Context, userId string) (*wallet_entity.Wallet, *internal_error.InternalError) {
+	wallet := f.wallet
+	wallet.UserId = userId
+	return &wallet, nil
+}
+
+func (f *fakeWalletRepository) Deposit(ctx context.Context, userId string, amount float64) (*wallet_entity.Wallet, *internal_error.InternalError) {
+	f.wallet.Balance += amount
+	return f.FindWallet(ctx, userId)
+}
+
+func (f *fakeWalletRepository) Withdraw(ctx context.Context, userId string, amount float64) (*wallet_entity.Wallet, *internal_error.InternalError) {
+	if f.wallet.Available() < amount {
+		return nil, internal_error.NewUnprocessableEntityError("Insufficient available balance").
+			WithCode(internal_error.CodeInsufficientBalance)
+	}
+	f.wallet.Balance -= amount
+	return f.FindWallet(ctx, userId)
+}
+
+func TestWalletUseCase_DepositAndWithdrawAvailableBalance(t *testing.T) {
+	walletRepo := &fakeWalletRepository{wallet: wallet_entity.Wallet{Held: 30}}
+	walletUC := wallet_usecase.NewWalletUseCase(walletRepo)
+
+	wallet, err := walletUC.Deposit(context.Background(), "u1", wallet_usecase.WalletAmountInputDTO{Amount: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 100.0, wallet.Balance)
+	assert.Equal(t, 70.0, wallet.Available)
+
+	// O valor reservado por lances não pode ser sacado
+	_, err = walletUC.Withdraw(context.Background(), "u1", wallet_usecase.WalletAmountInputDTO{Amount: 80})
+	assert.NotNil(t, err)
+	assert.Equal(t, "unprocessable_entity", err.Err)
+	assert.Equal(t, internal_error.CodeInsufficientBalance, err.Code)
+
+	wallet, err = walletUC.Withdraw(context.Background(), "u1", wallet_usecase.WalletAmountInputDTO{Amount: 70})
+	assert.Nil(t, err)
+	assert.Equal(t, 30.0, wallet.Balance)
+	assert.Equal(t, 0.0, wallet.Available)
+}
+
+func TestWalletUseCase_RejectsInvalidAmount(t *testing.T) {
+	walletUC := wallet_usecase.NewWalletUseCase(&fakeWalletRepository{})
+
+	_, err := walletUC.Deposit(context.Background(), "u1", wallet_usecase.WalletAmountInputDTO{Amount: -5})
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+}
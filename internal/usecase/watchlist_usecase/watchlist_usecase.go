@@ -0,0 +1,103 @@
+package watchlist_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/watchlist_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"time"
+)
+
+// WatchlistItemOutputDTO traz o leilão acompanhado com o seu estado ao vivo.
+type WatchlistItemOutputDTO struct {
+	Auction   auction_usecase.AuctionOutputDTO `json:"auction"`
+	WatchedAt time.Time                        `json:"watched_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type WatchlistOutputDTO struct {
+	Items []WatchlistItemOutputDTO `json:"items"`
+	Page  int64                    `json:"page"`
+	Limit int64                    `json:"limit"`
+	Total int64                    `json:"total"`
+}
+
+type WatchlistUseCase struct {
+	watchlistRepository watchlist_entity.WatchlistRepositoryInterface
+	auctionUseCase      auction_usecase.AuctionUseCaseInterface
+}
+
+type WatchlistUseCaseInterface interface {
+	WatchAuction(
+		ctx context.Context, userId, auctionId string) *internal_error.InternalError
+
+	UnwatchAuction(
+		ctx context.Context, userId, auctionId string) *internal_error.InternalError
+
+	// FindWatchlist só mostra a lista ao próprio usuário.
+	FindWatchlist(
+		ctx context.Context,
+		viewerId, userId string,
+		page, limit int64) (*WatchlistOutputDTO, *internal_error.InternalError)
+}
+
+func NewWatchlistUseCase(
+	watchlistRepository watchlist_entity.WatchlistRepositoryInterface,
+	auctionUseCase auction_usecase.AuctionUseCaseInterface) WatchlistUseCaseInterface {
+	return &WatchlistUseCase{
+		watchlistRepository: watchlistRepository,
+		auctionUseCase:      auctionUseCase,
+	}
+}
+
+// WatchAuction só aceita leilões ativos; acompanhar de novo não tem efeito.
+func (wu *WatchlistUseCase) WatchAuction(
+	ctx context.Context, userId, auctionId string) *internal_error.InternalError {
+	auction, err := wu.auctionUseCase.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if auction.Status != auction_entity.Active {
+		return internal_error.NewGoneError("Only active auctions can be watched").
+			WithCode(internal_error.CodeAuctionClosed)
+	}
+
+	return wu.watchlistRepository.WatchAuction(ctx, watchlist_entity.NewWatch(userId, auctionId))
+}
+
+func (wu *WatchlistUseCase) UnwatchAuction(
+	ctx context.Context, userId, auctionId string) *internal_error.InternalError {
+	return wu.watchlistRepository.UnwatchAuction(ctx, userId, auctionId)
+}
+
+func (wu *WatchlistUseCase) FindWatchlist(
+	ctx context.Context,
+	viewerId, userId string,
+	page, limit int64) (*WatchlistOutputDTO, *internal_error.InternalError) {
+	if viewerId != userId {
+		return nil, internal_error.NewForbiddenError("Users can only see their own watchlist").
+			WithCode(internal_error.CodeNotWatchlistOwner)
+	}
+
+	watches, total, err := wu.watchlistRepository.FindWatchesByUserId(ctx, userId, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os leilões vêm com a lista, lidos juntos em uma única consulta
+	items := make([]WatchlistItemOutputDTO, 0, len(watches))
+	for _, watch := range watches {
+		items = append(items, WatchlistItemOutputDTO{
+			Auction:   wu.auctionUseCase.ToAuctionOutput(watch.Auction),
+			WatchedAt: watch.CreatedAt,
+		})
+	}
+
+	return &WatchlistOutputDTO{
+		Items: items,
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}, nil
+}
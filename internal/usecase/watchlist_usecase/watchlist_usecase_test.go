@@ -0,0 +1,125 @@
+package watchlist_usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/watchlist_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/watchlist_usecase"
+)
+
+// fakeWatchlistRepository guarda os leilões acompanhados em memória
+type fakeWatchlistRepository struct {
+	watches []watchlist_entity.Watch
+}
+
+func (f *fakeWatchlistRepository) WatchAuction(ctx context.Context, watch *watchlist_entity.Watch) *internal_error.InternalError {
+	for _, existing := range f.watches {
+		if existing.UserId == watch.UserId && existing.AuctionId == watch.AuctionId {
+			return nil
+		}
+	}
+	f.watches = append(f.watches, *watch)
+	return nil
+}
+
+func (f *fakeWatchlistRepository) UnwatchAuction(ctx context.Context, userId, auctionId string) *internal_error.InternalError {
+	for i, watch := range f.watches {
+		if watch.UserId == userId && watch.AuctionId == auctionId {
+			f.watches = append(f.watches[:i], f.watches[i+1:]...)
+			return nil
+		}
+	}
+	return internal_error.NewNotFoundError("Auction is not in the watchlist")
+}
+
+func (f *fakeWatchlistRepository) FindWatchesByUserId(ctx context.Context, userId string, page, limit int64) ([]watchlist_entity.Watch, int64, *internal_error.InternalError) {
+	var watches []watchlist_entity.Watch
+	for _, watch := range f.watches {
+		if watch.UserId == userId {
+			watches = append(watches, watch)
+		}
+	}
+	return watches, int64(len(watches)), nil
+}
+
+// fakeAuctionUseCase devolve os leilões informados já com o estado ao vivo
+type fakeAuctionUseCase struct {
+	auction_usecase.AuctionUseCaseInterface
+	auctions map[string]auction_usecase.AuctionOutputDTO
+}
+
+func (f *fakeAuctionUseCase) FindAuctionById(ctx context.Context, id string) (*auction_usecase.AuctionOutputDTO, *internal_error.InternalError) {
+	auction, ok := f.auctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError("Auction not found")
+	}
+	return &auction, nil
+}
+
+func (f *fakeAuctionUseCase) ToAuctionOutput(auctionEntity auction_entity.Auction) auction_usecase.AuctionOutputDTO {
+	return f.auctions[auctionEntity.Id]
+}
+
+func newUseCase() (watchlist_usecase.WatchlistUseCaseInterface, *fakeWatchlistRepository) {
+	watchlists := &fakeWatchlistRepository{}
+	auctions := &fakeAuctionUseCase{auctions: map[string]auction_usecase.AuctionOutputDTO{
+		"a1": {Id: "a1", Status: auction_entity.Active, CurrentPrice: 120, SecondsRemaining: 600},
+		"a2": {Id: "a2", Status: auction_entity.Completed},
+	}}
+	return watchlist_usecase.NewWatchlistUseCase(watchlists, auctions), watchlists
+}
+
+func TestWatchAuction_OnlyActiveAuctions(t *testing.T) {
+	watchlistUC, watchlists := newUseCase()
+
+	assert.Nil(t, watchlistUC.WatchAuction(context.Background(), "u1", "a1"))
+	// Acompanhar de novo não duplica
+	assert.Nil(t, watchlistUC.WatchAuction(context.Background(), "u1", "a1"))
+	assert.Len(t, watchlists.watches, 1)
+
+	err := watchlistUC.WatchAuction(context.Background(), "u1", "a2")
+	assert.NotNil(t, err)
+	assert.Equal(t, "gone", err.Err)
+
+	err = watchlistUC.WatchAuction(context.Background(), "u1", "missing")
+	assert.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+}
+
+func TestFindWatchlist_ShowsLiveStateToOwnerOnly(t *testing.T) {
+	watchlistUC, watchlists := newUseCase()
+	watchedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	watchlists.watches = []watchlist_entity.Watch{
+		{UserId: "u1", AuctionId: "a1", CreatedAt: watchedAt, Auction: auction_entity.Auction{Id: "a1"}},
+	}
+
+	_, err := watchlistUC.FindWatchlist(context.Background(), "u2", "u1", 1, 20)
+	assert.NotNil(t, err)
+	assert.Equal(t, "forbidden", err.Err)
+
+	watchlist, err := watchlistUC.FindWatchlist(context.Background(), "u1", "u1", 1, 20)
+	assert.Nil(t, err)
+	assert.Len(t, watchlist.Items, 1)
+	assert.Equal(t, 120.0, watchlist.Items[0].Auction.CurrentPrice)
+	assert.Equal(t, int64(600), watchlist.Items[0].Auction.SecondsRemaining)
+	assert.Equal(t, watchedAt, watchlist.Items[0].WatchedAt)
+}
+
+func TestUnwatchAuction_OnlyWatchedAuctions(t *testing.T) {
+	watchlistUC, watchlists := newUseCase()
+	assert.Nil(t, watchlistUC.WatchAuction(context.Background(), "u1", "a1"))
+	assert.Nil(t, watchlistUC.WatchAuction(context.Background(), "u2", "a1"))
+	assert.Nil(t, watchlistUC.UnwatchAuction(context.Background(), "u1", "a1"))
+	assert.Len(t, watchlists.watches, 1)
+
+	err := watchlistUC.UnwatchAuction(context.Background(), "u1", "a1")
+	assert.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+}